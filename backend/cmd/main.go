@@ -3,26 +3,57 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"expvar"
+	"flag"
 	"fmt"
+	"io"
+	"io/fs"
 	"log"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
+	"github.com/Cadimodev/haiji/backend/internal/classroom"
+	"github.com/Cadimodev/haiji/backend/internal/cluster"
 	"github.com/Cadimodev/haiji/backend/internal/config"
 	"github.com/Cadimodev/haiji/backend/internal/database"
 	"github.com/Cadimodev/haiji/backend/internal/game"
 	"github.com/Cadimodev/haiji/backend/internal/handlers"
+	"github.com/Cadimodev/haiji/backend/internal/league"
+	"github.com/Cadimodev/haiji/backend/internal/progression"
 	"github.com/Cadimodev/haiji/backend/internal/router"
 	"github.com/Cadimodev/haiji/backend/internal/service"
+	"github.com/Cadimodev/haiji/backend/internal/srs"
+	"github.com/Cadimodev/haiji/backend/internal/tickets"
 
 	_ "github.com/lib/pq"
 )
 
+// How often ended league weeks are looked for
+const leagueCheckInterval = 10 * time.Minute
+
+// How often expired shares are deleted
+const shareCleanupInterval = time.Hour
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImport(os.Args[2:]); err != nil {
+			log.Fatalf("Import failed: %s", err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "role" {
+		if err := runRole(os.Args[2:]); err != nil {
+			log.Fatalf("Setting role failed: %s", err)
+		}
+		return
+	}
+
 	fmt.Println("Starting haiji server...")
 
 	apiCFG, err := config.Load()
@@ -51,17 +82,75 @@ func main() {
 	// Initialize dependencies
 	dbQueries := database.New(dbConn)
 	txManager := database.NewSqlTxManager(dbConn)
-	authService := service.NewAuthService(txManager, dbQueries, apiCFG.JWTSecret, string(apiCFG.RefreshPepper), apiCFG.Platform)
-	hub := game.NewHub()
+
+	var roomDirectory cluster.Directory = cluster.NewMemoryDirectory()
+	var bus cluster.Bus = cluster.NewMemoryBus()
+	var ticketStore tickets.Store = tickets.NewMemoryStore()
+	if apiCFG.ClusterMode == "postgres" {
+		roomDirectory = cluster.NewPostgresDirectory(dbQueries)
+		bus = cluster.NewPostgresBus(dbQueries, apiCFG.DBURL)
+		ticketStore = tickets.NewPostgresStore(dbQueries)
+	}
+	hub, err := game.NewClusteredHub(apiCFG.InstanceID, roomDirectory, bus)
+	if err != nil {
+		slog.Error("Error starting game hub", "error", err)
+		os.Exit(1)
+	}
+	hub.AllowOrigins(append([]string{apiCFG.CorsAllowedOrigin}, apiCFG.WSAllowedOrigins...)...)
+
+	scheduler, err := srs.New(apiCFG.SRSAlgorithm)
+	if err != nil {
+		slog.Error("Error creating review scheduler", "error", err)
+		os.Exit(1)
+	}
+
+	levelCurve := progression.Curve{Base: apiCFG.XPLevelBase, Exponent: apiCFG.XPLevelExponent}
+
+	achievementService := service.NewAchievementService(dbQueries, hub)
+	leagueService := service.NewLeagueService(txManager, dbQueries, league.DefaultRules)
+	progressionService := service.NewProgressionService(txManager, dbQueries, levelCurve, hub, leagueService, achievementService)
+	authService := service.NewAuthService(txManager, dbQueries, apiCFG.JWTSecret, string(apiCFG.RefreshPepper), apiCFG.Platform, levelCurve)
+	dailyService := service.NewDailyService(dbQueries)
+	reviewService := service.NewReviewService(txManager, dbQueries, scheduler)
+	curriculumService := service.NewCurriculumService(txManager)
+	practiceService := service.NewPracticeService(txManager, dbQueries, achievementService, progressionService, curriculumService)
+	ghostService := service.NewGhostService(dbQueries, hub)
+	deckService := service.NewDeckService(txManager, dbQueries)
+	shareService := service.NewShareService(dbQueries, filepath.Join(apiCFG.AssetsRoot, "share-cards"))
+
+	hub.AddResultStore(ghostService)
+	hub.AddResultStore(achievementService)
+	hub.AddResultStore(progressionService)
+	hub.SetDeckResolver(deckService)
+	hub.SetShareStore(shareService)
 	go hub.Run()
+	go leagueService.RunScheduler(leagueCheckInterval)
+	go shareService.RunCleanup(shareCleanupInterval)
 
 	// Initialize handlers
 	userHandler := handlers.NewUserHandler(dbQueries, authService, apiCFG)
 	authHandler := handlers.NewAuthHandler(dbQueries, authService, apiCFG)
-	gameHandler := handlers.NewGameHandler(dbQueries, apiCFG, hub)
+	gameHandler := handlers.NewGameHandler(dbQueries, apiCFG, hub, ticketStore)
 	systemHandler := handlers.NewSystemHandler(dbQueries, apiCFG)
+	dailyHandler := handlers.NewDailyHandler(dailyService)
+	ghostHandler := handlers.NewGhostHandler(ghostService)
+	reviewHandler := handlers.NewReviewHandler(reviewService)
+	practiceHandler := handlers.NewPracticeHandler(practiceService)
+	achievementHandler := handlers.NewAchievementHandler(achievementService)
+	progressionHandler := handlers.NewProgressionHandler(progressionService)
+	deckHandler := handlers.NewDeckHandler(deckService)
+	handwritingHandler := handlers.NewHandwritingHandler()
+	kanjiHandler := handlers.NewKanjiHandler(service.NewKanjiService(txManager, dbQueries))
+	readingHandler := handlers.NewReadingHandler(service.NewReadingService(txManager, dbQueries, curriculumService))
+	quizHandler := handlers.NewQuizHandler(service.NewQuizService(dbQueries))
+	curriculumHandler := handlers.NewCurriculumHandler(curriculumService)
+	leagueHandler := handlers.NewLeagueHandler(leagueService)
+	classroomHandler := handlers.NewClassroomHandler(service.NewClassroomService(dbQueries, hub))
+	sprintHandler := handlers.NewSprintHandler(service.NewSprintService(dbQueries, apiCFG.JWTSecret))
+	shopHandler := handlers.NewShopHandler(service.NewShopService(txManager, dbQueries))
+	shareHandler := handlers.NewShareHandler(shareService)
 
-	mux := router.New(apiCFG, userHandler, authHandler, gameHandler, systemHandler)
+	mux := router.New(apiCFG, userHandler, authHandler, gameHandler, systemHandler, dailyHandler, ghostHandler, reviewHandler, practiceHandler, achievementHandler, progressionHandler, deckHandler, handwritingHandler, kanjiHandler, readingHandler, quizHandler, curriculumHandler, classroomHandler, sprintHandler, leagueHandler, shopHandler, shareHandler)
 
 	srv := &http.Server{
 		Addr:              ":" + apiCFG.Port,
@@ -81,6 +170,19 @@ func main() {
 		}
 	}()
 
+	// Monitoring counters (websocket flood violations, memstats, ...) stay
+	// off the public listener
+	if apiCFG.DebugAddr != "" {
+		debugMux := http.NewServeMux()
+		debugMux.Handle("GET /debug/vars", expvar.Handler())
+		go func() {
+			slog.Info("Debug server listening", "addr", apiCFG.DebugAddr)
+			if err := http.ListenAndServe(apiCFG.DebugAddr, debugMux); err != nil {
+				slog.Error("Debug listen failed", "error", err)
+			}
+		}()
+	}
+
 	// Wait for interrupt signal using channel for graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -97,5 +199,150 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Other instances must stop routing players to our rooms
+	if err := hub.Shutdown(ctx); err != nil {
+		slog.Error("Error releasing rooms", "error", err)
+	}
+	bus.Close()
+
 	slog.Info("Server exiting")
 }
+
+const importUsage = `usage:
+  haiji import kanji -kanjidic FILE [-kanjivg DIR]
+  haiji import reading [-jmdict FILE] [-tatoeba FILE]`
+
+// runImport loads reference data from local files into the database.
+func runImport(args []string) error {
+	if len(args) == 0 {
+		return errors.New(importUsage)
+	}
+	switch args[0] {
+	case "kanji":
+		return importKanji(args[1:])
+	case "reading":
+		return importReading(args[1:])
+	}
+	return errors.New(importUsage)
+}
+
+func importKanji(args []string) error {
+	flags := flag.NewFlagSet("import kanji", flag.ContinueOnError)
+	kanjidicPath := flags.String("kanjidic", "", "KANJIDIC2 XML file")
+	kanjivgDir := flags.String("kanjivg", "", "KanjiVG kanji directory of SVG files (optional)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *kanjidicPath == "" {
+		return errors.New("-kanjidic is required")
+	}
+
+	kanjidic, err := os.Open(*kanjidicPath)
+	if err != nil {
+		return err
+	}
+	defer kanjidic.Close()
+	var kanjivg fs.FS
+	if *kanjivgDir != "" {
+		kanjivg = os.DirFS(*kanjivgDir)
+	}
+
+	dbConn, err := openImportDB()
+	if err != nil {
+		return err
+	}
+	defer dbConn.Close()
+
+	kanjiService := service.NewKanjiService(database.NewSqlTxManager(dbConn), database.New(dbConn))
+	result, err := kanjiService.Import(context.Background(), kanjidic, kanjivg)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d kanji, %d with stroke paths\n", result.Kanji, result.StrokePaths)
+	return nil
+}
+
+func importReading(args []string) error {
+	flags := flag.NewFlagSet("import reading", flag.ContinueOnError)
+	jmdictPath := flags.String("jmdict", "", "JMdict XML file")
+	tatoebaPath := flags.String("tatoeba", "", "Tatoeba sentences or sentence pairs TSV file")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *jmdictPath == "" && *tatoebaPath == "" {
+		return errors.New("-jmdict or -tatoeba is required")
+	}
+
+	// A nil reader skips its source
+	var jmdict, tatoeba io.Reader
+	if *jmdictPath != "" {
+		f, err := os.Open(*jmdictPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		jmdict = f
+	}
+	if *tatoebaPath != "" {
+		f, err := os.Open(*tatoebaPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		tatoeba = f
+	}
+
+	dbConn, err := openImportDB()
+	if err != nil {
+		return err
+	}
+	defer dbConn.Close()
+
+	txManager := database.NewSqlTxManager(dbConn)
+	readingService := service.NewReadingService(txManager, database.New(dbConn), service.NewCurriculumService(txManager))
+	result, err := readingService.Import(context.Background(), jmdict, tatoeba)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d words and %d sentences written in catalog kana\n", result.Words, result.Sentences)
+	return nil
+}
+
+const roleUsage = `usage:
+  haiji role USERNAME teacher|student`
+
+// runRole makes a user a teacher, who can create classes, or a student.
+func runRole(args []string) error {
+	if len(args) != 2 {
+		return errors.New(roleUsage)
+	}
+	role, err := classroom.ParseRole(args[1])
+	if err != nil {
+		return fmt.Errorf("%w\n%s", err, roleUsage)
+	}
+
+	dbConn, err := openImportDB()
+	if err != nil {
+		return err
+	}
+	defer dbConn.Close()
+
+	classroomService := service.NewClassroomService(database.New(dbConn), nil)
+	if err := classroomService.SetRole(context.Background(), args[0], role); err != nil {
+		return err
+	}
+	fmt.Printf("%s is now a %s\n", args[0], role)
+	return nil
+}
+
+func openImportDB() (*sql.DB, error) {
+	apiCFG, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	dbConn, err := sql.Open("postgres", apiCFG.DBURL)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	return dbConn, nil
+}
@@ -0,0 +1,102 @@
+// Package achievements declares the achievement rules and turns recorded
+// activity into counter increments they are evaluated against.
+package achievements
+
+import "github.com/Cadimodev/haiji/backend/internal/kana"
+
+// Counters are per-user running totals. Every rule unlocks once its counter
+// reaches the target.
+const (
+	CounterBattlesPlayed   = "battles_played"
+	CounterBattleWins      = "battle_wins"
+	CounterPracticeAnswers = "practice_answers"
+	CounterPerfectSession  = "perfect_sessions"
+	// Perfect sessions made only of katakana combinations (キャ, シュ, ...)
+	CounterPerfectKatakanaCombination = "perfect_katakana_combination_sessions"
+	// Longest daily streak, as kept by the progression streak
+	CounterBestStreak = "best_streak"
+)
+
+// A session needs at least this many answers to count as perfect.
+const PerfectSessionMinAnswers = 20
+
+type Rule struct {
+	ID          string
+	Name        string
+	Description string
+	Counter     string
+	Target      int64
+}
+
+// Rules in display order.
+var Rules = []Rule{
+	{ID: "first_battle", Name: "First Battle", Description: "Finish a kana battle", Counter: CounterBattlesPlayed, Target: 1},
+	{ID: "first_battle_win", Name: "First Victory", Description: "Win a kana battle", Counter: CounterBattleWins, Target: 1},
+	{ID: "battle_wins_10", Name: "Champion", Description: "Win 10 kana battles", Counter: CounterBattleWins, Target: 10},
+	{ID: "practice_100", Name: "Getting Started", Description: "Answer 100 practice questions", Counter: CounterPracticeAnswers, Target: 100},
+	{ID: "practice_1000", Name: "Dedicated", Description: "Answer 1,000 practice questions", Counter: CounterPracticeAnswers, Target: 1000},
+	{ID: "perfect_session", Name: "Flawless", Description: "Finish a practice session of 20+ answers without a mistake", Counter: CounterPerfectSession, Target: 1},
+	{ID: "perfect_katakana_combination", Name: "Yōon Master", Description: "100% accuracy on a katakana combinations session of 20+ answers", Counter: CounterPerfectKatakanaCombination, Target: 1},
+	{ID: "streak_7", Name: "Week Streak", Description: "Keep a 7-day streak", Counter: CounterBestStreak, Target: 7},
+	{ID: "streak_30", Name: "Month Streak", Description: "Keep a 30-day streak", Counter: CounterBestStreak, Target: 30},
+}
+
+func GetRule(id string) (Rule, bool) {
+	for _, r := range Rules {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Reached returns the rules whose counters have hit their targets.
+func Reached(counters map[string]int64) []Rule {
+	var reached []Rule
+	for _, r := range Rules {
+		if counters[r.Counter] >= r.Target {
+			reached = append(reached, r)
+		}
+	}
+	return reached
+}
+
+type Answer struct {
+	Kana    string
+	Correct bool
+}
+
+// PracticeIncrements returns the counter increments for a finished session.
+func PracticeIncrements(answers []Answer) map[string]int64 {
+	inc := map[string]int64{CounterPracticeAnswers: int64(len(answers))}
+	if len(answers) < PerfectSessionMinAnswers {
+		return inc
+	}
+
+	katakanaCombination := true
+	for _, a := range answers {
+		if !a.Correct {
+			return inc
+		}
+		g, ok := kana.GroupOf(a.Kana)
+		if !ok || g.Category != kana.CategoryKatakana || g.Section != kana.SectionCombination {
+			katakanaCombination = false
+		}
+	}
+
+	inc[CounterPerfectSession] = 1
+	if katakanaCombination {
+		inc[CounterPerfectKatakanaCombination] = 1
+	}
+	return inc
+}
+
+// BattleIncrements returns the counter increments for a finished battle.
+// Placement is 1-based and tied scores share a placement.
+func BattleIncrements(placement, players int) map[string]int64 {
+	inc := map[string]int64{CounterBattlesPlayed: 1}
+	if placement == 1 && players > 1 {
+		inc[CounterBattleWins] = 1
+	}
+	return inc
+}
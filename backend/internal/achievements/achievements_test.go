@@ -0,0 +1,80 @@
+package achievements
+
+import "testing"
+
+func repeat(kana string, correct bool, n int) []Answer {
+	answers := make([]Answer, n)
+	for i := range answers {
+		answers[i] = Answer{Kana: kana, Correct: correct}
+	}
+	return answers
+}
+
+func TestPracticeIncrements(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers []Answer
+		want    map[string]int64
+	}{
+		{
+			name:    "short session only counts answers",
+			answers: repeat("キャ", true, PerfectSessionMinAnswers-1),
+			want:    map[string]int64{CounterPracticeAnswers: PerfectSessionMinAnswers - 1},
+		},
+		{
+			name:    "perfect katakana combinations",
+			answers: repeat("キャ", true, PerfectSessionMinAnswers),
+			want: map[string]int64{
+				CounterPracticeAnswers:            PerfectSessionMinAnswers,
+				CounterPerfectSession:             1,
+				CounterPerfectKatakanaCombination: 1,
+			},
+		},
+		{
+			name:    "perfect but mixed with hiragana",
+			answers: append(repeat("キャ", true, PerfectSessionMinAnswers), Answer{Kana: "あ", Correct: true}),
+			want: map[string]int64{
+				CounterPracticeAnswers: PerfectSessionMinAnswers + 1,
+				CounterPerfectSession:  1,
+			},
+		},
+		{
+			name:    "one mistake",
+			answers: append(repeat("キャ", true, PerfectSessionMinAnswers), Answer{Kana: "キュ", Correct: false}),
+			want:    map[string]int64{CounterPracticeAnswers: PerfectSessionMinAnswers + 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PracticeIncrements(tt.answers)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("%s = %d, want %d", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestBattleIncrements(t *testing.T) {
+	if inc := BattleIncrements(1, 3); inc[CounterBattleWins] != 1 || inc[CounterBattlesPlayed] != 1 {
+		t.Errorf("Winner: got %v", inc)
+	}
+	if inc := BattleIncrements(2, 3); inc[CounterBattleWins] != 0 {
+		t.Errorf("Runner-up shouldn't win: got %v", inc)
+	}
+	if inc := BattleIncrements(1, 1); inc[CounterBattleWins] != 0 {
+		t.Errorf("Playing alone isn't a win: got %v", inc)
+	}
+}
+
+func TestReached(t *testing.T) {
+	reached := Reached(map[string]int64{CounterBattleWins: 10})
+	if len(reached) != 2 || reached[0].ID != "first_battle_win" || reached[1].ID != "battle_wins_10" {
+		t.Errorf("Unexpected rules reached: %+v", reached)
+	}
+}
@@ -0,0 +1,65 @@
+// Package classroom holds the rules of classroom mode: who may teach, when an
+// assignment counts as done, and how progress reports are exported.
+package classroom
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Role string
+
+const (
+	RoleStudent Role = "student"
+	RoleTeacher Role = "teacher"
+)
+
+var ErrUnknownRole = errors.New("unknown role")
+
+func ParseRole(name string) (Role, error) {
+	switch Role(name) {
+	case RoleStudent, RoleTeacher:
+		return Role(name), nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownRole, name)
+}
+
+type Status string
+
+const (
+	StatusNotStarted Status = "not_started"
+	StatusInProgress Status = "in_progress"
+	StatusCompleted  Status = "completed"
+	StatusMissed     Status = "missed"
+)
+
+// Progress is a student's practice of an assignment's characters while it
+// was open.
+type Progress struct {
+	Attempts      int
+	Correct       int
+	KanaPracticed int
+}
+
+func (p Progress) Accuracy() float64 {
+	if p.Attempts == 0 {
+		return 0
+	}
+	return float64(p.Correct) / float64(p.Attempts)
+}
+
+// AssignmentStatus grades progress on an assignment of kanaCount characters.
+// It is completed once every character was practiced at the target accuracy
+// or better, and missed if that hadn't happened by the due date.
+func AssignmentStatus(p Progress, kanaCount int, target float64, due, now time.Time) Status {
+	switch {
+	case p.KanaPracticed >= kanaCount && p.Accuracy() >= target:
+		return StatusCompleted
+	case !now.Before(due):
+		return StatusMissed
+	case p.Attempts == 0:
+		return StatusNotStarted
+	}
+	return StatusInProgress
+}
@@ -0,0 +1,89 @@
+package classroom
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestAssignmentStatus(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	due := now.Add(24 * time.Hour)
+	tests := []struct {
+		name     string
+		progress Progress
+		now      time.Time
+		want     Status
+	}{
+		{"untouched", Progress{}, now, StatusNotStarted},
+		{"some practice", Progress{Attempts: 4, Correct: 4, KanaPracticed: 4}, now, StatusInProgress},
+		{"below target", Progress{Attempts: 10, Correct: 7, KanaPracticed: 5}, now, StatusInProgress},
+		{"done", Progress{Attempts: 10, Correct: 9, KanaPracticed: 5}, now, StatusCompleted},
+		{"done before due stays done", Progress{Attempts: 10, Correct: 9, KanaPracticed: 5}, due.Add(time.Hour), StatusCompleted},
+		{"late", Progress{Attempts: 10, Correct: 7, KanaPracticed: 5}, due, StatusMissed},
+	}
+	for _, tt := range tests {
+		if got := AssignmentStatus(tt.progress, 5, 0.8, due, tt.now); got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	due := time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)
+	r := Report{
+		ClassName: "1-A",
+		Assignments: []Assignment{
+			{ID: uuid.New(), Title: "Vowels", TargetAccuracy: 0.8, DueAt: due},
+			{ID: uuid.New(), Title: "K-row, again", TargetAccuracy: 0.9, DueAt: due},
+		},
+		Students: []StudentReport{{
+			Username: "hana",
+			JoinedAt: due.Add(-72 * time.Hour),
+			Assignments: []AssignmentReport{
+				{Progress{Attempts: 10, Correct: 9, KanaPracticed: 5}, StatusCompleted},
+				{Progress{}, StatusNotStarted},
+			},
+			Battles: BattleStats{Battles: 3, ClassBattles: 1, BestScore: 42, AverageScore: 30.5},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, r); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Output isn't valid CSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("Expected a header and a row per assignment, got %d rows", len(rows))
+	}
+	want := []string{
+		"hana", "2026-03-08T00:00:00Z",
+		"Vowels", "2026-03-11T00:00:00Z", "0.80",
+		"10", "9", "0.90", "completed",
+		"3", "1", "42", "30.50",
+	}
+	for i, v := range want {
+		if rows[1][i] != v {
+			t.Errorf("Column %s: expected %q, got %q", rows[0][i], v, rows[1][i])
+		}
+	}
+	// Titles with commas are quoted, not split
+	if rows[2][2] != "K-row, again" || rows[2][8] != "not_started" {
+		t.Errorf("Unexpected second row: %v", rows[2])
+	}
+
+	// Without assignments each student still gets a row
+	r.Assignments = nil
+	buf.Reset()
+	WriteCSV(&buf, r)
+	rows, _ = csv.NewReader(&buf).ReadAll()
+	if len(rows) != 2 || rows[1][0] != "hana" || rows[1][2] != "" || rows[1][9] != "3" {
+		t.Errorf("Unexpected rows without assignments: %v", rows)
+	}
+}
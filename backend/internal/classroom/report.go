@@ -0,0 +1,95 @@
+package classroom
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Report struct {
+	ClassName   string
+	GeneratedAt time.Time
+	Assignments []Assignment
+	Students    []StudentReport
+}
+
+type Assignment struct {
+	ID             uuid.UUID
+	Title          string
+	TargetAccuracy float64
+	DueAt          time.Time
+}
+
+type StudentReport struct {
+	UserID   uuid.UUID
+	Username string
+	JoinedAt time.Time
+	// In the order of Report.Assignments
+	Assignments []AssignmentReport
+	Battles     BattleStats
+}
+
+type AssignmentReport struct {
+	Progress
+	Status Status
+}
+
+// BattleStats covers the battles a student played since joining the class.
+type BattleStats struct {
+	Battles      int
+	ClassBattles int // in rooms opened for the class
+	BestScore    int
+	AverageScore float64
+}
+
+var csvHeader = []string{
+	"student", "joined_at",
+	"assignment", "due_at", "target_accuracy",
+	"attempts", "correct", "accuracy", "status",
+	"battles", "class_battles", "best_score", "average_score",
+}
+
+// WriteCSV writes one row per student and assignment, or a single row with
+// empty assignment columns for a class without assignments.
+func WriteCSV(w io.Writer, r Report) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, s := range r.Students {
+		student := []string{s.Username, s.JoinedAt.UTC().Format(time.RFC3339)}
+		battles := []string{
+			strconv.Itoa(s.Battles.Battles),
+			strconv.Itoa(s.Battles.ClassBattles),
+			strconv.Itoa(s.Battles.BestScore),
+			formatFloat(s.Battles.AverageScore),
+		}
+		if len(r.Assignments) == 0 {
+			row := append(append(student, make([]string, 7)...), battles...)
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+			continue
+		}
+		for i, a := range r.Assignments {
+			p := s.Assignments[i]
+			row := append([]string(nil), student...)
+			row = append(row,
+				a.Title, a.DueAt.UTC().Format(time.RFC3339), formatFloat(a.TargetAccuracy),
+				strconv.Itoa(p.Attempts), strconv.Itoa(p.Correct), formatFloat(p.Accuracy()), string(p.Status),
+			)
+			if err := cw.Write(append(row, battles...)); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
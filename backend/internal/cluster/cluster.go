@@ -0,0 +1,79 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message types relayed between backend instances.
+const (
+	// Sent to the owner instance when a remote client joins one of its rooms.
+	TypeJoin = "JOIN"
+	// Sent to the owner instance when a remote client leaves or disconnects.
+	TypeLeave = "LEAVE"
+	// Sent to the owner instance with a raw websocket message from a remote client.
+	TypeClientMessage = "CLIENT_MSG"
+	// Sent to the instance holding the websocket with a payload to write to it.
+	TypeDeliver = "DELIVER"
+	// Broadcast with a payload for every websocket a user has open.
+	TypeNotify = "NOTIFY"
+	// Sent to the instance holding the websocket when the client is no longer
+	// in the owner's room, because it closed or never took the client in.
+	TypeDetach = "DETACH"
+)
+
+// RoomLease is how long a claim outlives its owner. Owners renew their
+// claims well within it, so a room whose lease ran out belongs to an
+// instance that died without releasing it.
+const RoomLease = 30 * time.Second
+
+var (
+	ErrRoomNotFound = errors.New("room not found")
+	ErrRoomClaimed  = errors.New("room code already claimed")
+)
+
+// Message is the envelope exchanged over the Bus.
+type Message struct {
+	Type     string          `json:"type"`
+	From     string          `json:"from"`
+	Room     string          `json:"room,omitempty"`
+	ClientID string          `json:"clientId,omitempty"`
+	UserID   uuid.UUID       `json:"userId,omitempty"`
+	Username string          `json:"username,omitempty"`
+	Payload  json.RawMessage `json:"payload,omitempty"`
+
+	// Equipped cosmetics of a joining user, by slot
+	Cosmetics map[string]string `json:"cosmetics,omitempty"`
+	// Romanization systems a joining user's answers are graded in
+	RomajiSystems []string `json:"romajiSystems,omitempty"`
+}
+
+// Directory keeps track of which instance runs each room.
+type Directory interface {
+	// Claim registers the room code for the instance. Returns ErrRoomClaimed
+	// if the code is already taken by a live claim.
+	Claim(ctx context.Context, code, instanceID string) error
+	// Owner returns the instance running the room or ErrRoomNotFound.
+	Owner(ctx context.Context, code string) (string, error)
+	// Renew extends the lease of every room claimed by the instance.
+	Renew(ctx context.Context, instanceID string) error
+	Release(ctx context.Context, code string) error
+	// ReleaseAll drops every room claimed by the instance (used on shutdown).
+	ReleaseAll(ctx context.Context, instanceID string) error
+}
+
+// Bus delivers messages addressed to a single instance, or to all of them.
+// Delivery is best effort: messages published to an instance nobody listens
+// for are dropped.
+type Bus interface {
+	Publish(ctx context.Context, instanceID string, msg Message) error
+	// Broadcast delivers the message to every subscribed instance, the
+	// sender included.
+	Broadcast(ctx context.Context, msg Message) error
+	Subscribe(instanceID string) (<-chan Message, error)
+	Close() error
+}
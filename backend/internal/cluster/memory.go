@@ -0,0 +1,148 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// MemoryDirectory is a process-local Directory. Hubs sharing the same
+// instance see each other's rooms, which is enough for single-node
+// deployments and tests.
+type MemoryDirectory struct {
+	mu    sync.RWMutex
+	rooms map[string]string // code -> instanceID
+}
+
+func NewMemoryDirectory() *MemoryDirectory {
+	return &MemoryDirectory{rooms: make(map[string]string)}
+}
+
+func (d *MemoryDirectory) Claim(ctx context.Context, code, instanceID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.rooms[code]; ok {
+		return ErrRoomClaimed
+	}
+	d.rooms[code] = instanceID
+	return nil
+}
+
+func (d *MemoryDirectory) Owner(ctx context.Context, code string) (string, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	owner, ok := d.rooms[code]
+	if !ok {
+		return "", ErrRoomNotFound
+	}
+	return owner, nil
+}
+
+// Renew is a no-op: memory claims die with the process that holds them.
+func (d *MemoryDirectory) Renew(ctx context.Context, instanceID string) error {
+	return nil
+}
+
+func (d *MemoryDirectory) Release(ctx context.Context, code string) error {
+	d.mu.Lock()
+	delete(d.rooms, code)
+	d.mu.Unlock()
+	return nil
+}
+
+func (d *MemoryDirectory) ReleaseAll(ctx context.Context, instanceID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for code, owner := range d.rooms {
+		if owner == instanceID {
+			delete(d.rooms, code)
+		}
+	}
+	return nil
+}
+
+// MemoryBus is a process-local Bus backed by buffered channels.
+type MemoryBus struct {
+	mu     sync.RWMutex
+	subs   map[string]chan Message
+	closed bool
+}
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{subs: make(map[string]chan Message)}
+}
+
+func (b *MemoryBus) Publish(ctx context.Context, instanceID string, msg Message) error {
+	if err := checkSize(msg); err != nil {
+		return err
+	}
+	// Hold the read lock while sending so Close can't close the channel under us
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	ch, ok := b.subs[instanceID]
+	if !ok {
+		// Same semantics as NOTIFY without listeners
+		return nil
+	}
+
+	select {
+	case ch <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *MemoryBus) Broadcast(ctx context.Context, msg Message) error {
+	if err := checkSize(msg); err != nil {
+		return err
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, ch := range b.subs {
+		select {
+		case ch <- msg:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// checkSize refuses what PostgresBus would, so single-process tests catch
+// messages too large for a real cluster.
+func checkSize(msg Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if len(data) >= maxNotifyPayload {
+		return ErrPayloadTooLarge
+	}
+	return nil
+}
+
+func (b *MemoryBus) Subscribe(instanceID string) (<-chan Message, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if ch, ok := b.subs[instanceID]; ok {
+		return ch, nil
+	}
+	ch := make(chan Message, 256)
+	b.subs[instanceID] = ch
+	return ch, nil
+}
+
+func (b *MemoryBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	for id, ch := range b.subs {
+		close(ch)
+		delete(b.subs, id)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/lib/pq"
+)
+
+// Postgres limits NOTIFY payloads to 8000 bytes (default build).
+const maxNotifyPayload = 8000
+
+var ErrPayloadTooLarge = errors.New("bus message exceeds NOTIFY payload limit")
+
+// PostgresDirectory stores room ownership in the game_rooms table.
+type PostgresDirectory struct {
+	db database.Querier
+}
+
+func NewPostgresDirectory(db database.Querier) *PostgresDirectory {
+	return &PostgresDirectory{db: db}
+}
+
+func (d *PostgresDirectory) Claim(ctx context.Context, code, instanceID string) error {
+	n, err := d.db.ClaimGameRoom(ctx, database.ClaimGameRoomParams{
+		Code:         code,
+		InstanceID:   instanceID,
+		LeaseSeconds: int32(RoomLease / time.Second),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrRoomClaimed
+	}
+	return nil
+}
+
+func (d *PostgresDirectory) Owner(ctx context.Context, code string) (string, error) {
+	owner, err := d.db.GetGameRoomOwner(ctx, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrRoomNotFound
+	}
+	return owner, err
+}
+
+func (d *PostgresDirectory) Renew(ctx context.Context, instanceID string) error {
+	return d.db.RenewGameRooms(ctx, database.RenewGameRoomsParams{
+		LeaseSeconds: int32(RoomLease / time.Second),
+		InstanceID:   instanceID,
+	})
+}
+
+func (d *PostgresDirectory) Release(ctx context.Context, code string) error {
+	return d.db.DeleteGameRoom(ctx, code)
+}
+
+func (d *PostgresDirectory) ReleaseAll(ctx context.Context, instanceID string) error {
+	return d.db.DeleteGameRoomsByInstance(ctx, instanceID)
+}
+
+// PostgresBus relays messages with LISTEN/NOTIFY. Each instance listens on
+// its own channel, so a NOTIFY reaches exactly one instance.
+type PostgresBus struct {
+	db    database.Querier
+	dbURL string
+
+	mu        sync.Mutex
+	listeners []*pq.Listener
+}
+
+func NewPostgresBus(db database.Querier, dbURL string) *PostgresBus {
+	return &PostgresBus{db: db, dbURL: dbURL}
+}
+
+func channelName(instanceID string) string {
+	return "haiji_" + instanceID
+}
+
+// Every instance also listens on this channel for broadcasts.
+const broadcastChannel = "haiji__all"
+
+func (b *PostgresBus) Publish(ctx context.Context, instanceID string, msg Message) error {
+	return b.notify(ctx, channelName(instanceID), msg)
+}
+
+func (b *PostgresBus) Broadcast(ctx context.Context, msg Message) error {
+	return b.notify(ctx, broadcastChannel, msg)
+}
+
+func (b *PostgresBus) notify(ctx context.Context, channel string, msg Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if len(data) >= maxNotifyPayload {
+		return ErrPayloadTooLarge
+	}
+	return b.db.Notify(ctx, database.NotifyParams{
+		Channel: channel,
+		Payload: string(data),
+	})
+}
+
+func (b *PostgresBus) Subscribe(instanceID string) (<-chan Message, error) {
+	listener := pq.NewListener(b.dbURL, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			slog.Warn("Cluster listener event", "event", ev, "error", err)
+		}
+	})
+	for _, channel := range []string{channelName(instanceID), broadcastChannel} {
+		if err := listener.Listen(channel); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("listen on %s: %w", channel, err)
+		}
+	}
+
+	b.mu.Lock()
+	b.listeners = append(b.listeners, listener)
+	b.mu.Unlock()
+
+	out := make(chan Message, 256)
+	go func() {
+		defer close(out)
+		for n := range listener.Notify {
+			// nil notifications signal a reconnect; anything sent meanwhile is lost
+			if n == nil {
+				slog.Warn("Cluster listener reconnected", "instance", instanceID)
+				continue
+			}
+			var msg Message
+			if err := json.Unmarshal([]byte(n.Extra), &msg); err != nil {
+				slog.Warn("Invalid cluster message", "error", err)
+				continue
+			}
+			out <- msg
+		}
+	}()
+	return out, nil
+}
+
+func (b *PostgresBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var errs []error
+	for _, l := range b.listeners {
+		errs = append(errs, l.Close())
+	}
+	b.listeners = nil
+	return errors.Join(errs...)
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -17,6 +19,26 @@ type ApiConfig struct {
 
 	DBURL             string
 	CorsAllowedOrigin string
+
+	// Extra origins allowed to open game websockets (CorsAllowedOrigin is always allowed)
+	WSAllowedOrigins []string
+
+	// Cluster: "memory" (single instance) or "postgres" (LISTEN/NOTIFY)
+	ClusterMode string
+	InstanceID  string
+
+	// Bind websocket tickets to the IP that requested them
+	WSTicketBindIP bool
+
+	// Review scheduler: "fsrs" (default) or "sm2"
+	SRSAlgorithm string
+
+	// Level curve: level n to n+1 costs XPLevelBase * n^XPLevelExponent XP
+	XPLevelBase     float64
+	XPLevelExponent float64
+
+	// Internal listener for /debug/vars, e.g. "127.0.0.1:6060"; empty disables it
+	DebugAddr string
 }
 
 func Load() (*ApiConfig, error) {
@@ -30,6 +52,18 @@ func Load() (*ApiConfig, error) {
 	assetsRoot := os.Getenv("ASSETS_ROOT")
 	dbURL := os.Getenv("DB_URL")
 	corsAllowedOrigin := os.Getenv("CORS_ALLOWED_ORIGIN")
+	clusterMode := os.Getenv("CLUSTER_MODE")
+	instanceID := os.Getenv("INSTANCE_ID")
+	wsTicketBindIP := os.Getenv("WS_TICKET_BIND_IP") == "true"
+	srsAlgorithm := os.Getenv("SRS_ALGORITHM")
+	debugAddr := os.Getenv("DEBUG_ADDR")
+
+	var wsAllowedOrigins []string
+	for _, origin := range strings.Split(os.Getenv("WS_ALLOWED_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			wsAllowedOrigins = append(wsAllowedOrigins, origin)
+		}
+	}
 
 	if platform == "" {
 		return nil, fmt.Errorf("PLATFORM environment variable is not set")
@@ -56,6 +90,42 @@ func Load() (*ApiConfig, error) {
 		return nil, fmt.Errorf("CORS_ALLOWED_ORIGIN environment variable is not set")
 	}
 
+	if clusterMode == "" {
+		clusterMode = "memory"
+	}
+	if clusterMode != "memory" && clusterMode != "postgres" {
+		return nil, fmt.Errorf("CLUSTER_MODE must be memory or postgres, got %q", clusterMode)
+	}
+	if instanceID == "" {
+		// Container hostnames are unique per replica
+		instanceID, _ = os.Hostname()
+	}
+	if instanceID == "" {
+		return nil, fmt.Errorf("INSTANCE_ID environment variable is not set and hostname is unavailable")
+	}
+
+	if srsAlgorithm == "" {
+		srsAlgorithm = "fsrs"
+	}
+	if srsAlgorithm != "fsrs" && srsAlgorithm != "sm2" {
+		return nil, fmt.Errorf("SRS_ALGORITHM must be fsrs or sm2, got %q", srsAlgorithm)
+	}
+
+	xpLevelBase, err := parseFloatEnv("XP_LEVEL_BASE", 100)
+	if err != nil {
+		return nil, err
+	}
+	if xpLevelBase < 1 {
+		return nil, fmt.Errorf("XP_LEVEL_BASE must be at least 1, got %v", xpLevelBase)
+	}
+	xpLevelExponent, err := parseFloatEnv("XP_LEVEL_EXPONENT", 1.5)
+	if err != nil {
+		return nil, err
+	}
+	if xpLevelExponent < 0 || xpLevelExponent > 4 {
+		return nil, fmt.Errorf("XP_LEVEL_EXPONENT must be between 0 and 4, got %v", xpLevelExponent)
+	}
+
 	return &ApiConfig{
 		JWTSecret:     jwtSecret,
 		Platform:      platform,
@@ -66,5 +136,31 @@ func Load() (*ApiConfig, error) {
 
 		DBURL:             dbURL,
 		CorsAllowedOrigin: corsAllowedOrigin,
+		WSAllowedOrigins:  wsAllowedOrigins,
+
+		ClusterMode: clusterMode,
+		InstanceID:  instanceID,
+
+		WSTicketBindIP: wsTicketBindIP,
+
+		SRSAlgorithm: srsAlgorithm,
+
+		XPLevelBase:     xpLevelBase,
+		XPLevelExponent: xpLevelExponent,
+
+		DebugAddr: debugAddr,
 	}, nil
 }
+
+// parseFloatEnv reads an optional numeric variable.
+func parseFloatEnv(name string, def float64) (float64, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number, got %q", name, raw)
+	}
+	return v, nil
+}
@@ -0,0 +1,136 @@
+// Package curriculum is the lesson plan new learners follow: hiragana row by
+// row, then its voiced rows and combinations, then the same for katakana.
+// Each lesson unlocks the next once its characters are mastered.
+package curriculum
+
+import (
+	"fmt"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+)
+
+// RecentAnswers is how many of a character's latest answers count towards
+// mastery, so early mistakes stop counting as the learner improves.
+const RecentAnswers = 20
+
+type Status string
+
+const (
+	StatusLocked     Status = "locked"
+	StatusUnlocked   Status = "unlocked"
+	StatusInProgress Status = "in_progress"
+	StatusMastered   Status = "mastered"
+)
+
+type Lesson struct {
+	ID     string
+	Title  string
+	Groups []string
+	// Every character needs MinAttempts recent answers and the lesson's
+	// recent accuracy must reach Threshold
+	MinAttempts int
+	Threshold   float64
+}
+
+// Stat is a character's recent answers.
+type Stat struct {
+	Attempts int
+	Correct  int
+}
+
+const (
+	basicThreshold       = 0.9
+	combinationThreshold = 0.85
+	minAttempts          = 5
+)
+
+var lessons = func() []Lesson {
+	var ls []Lesson
+	for _, script := range []struct {
+		prefix, name string
+		plan         [][]string
+	}{
+		{"hiragana", "Hiragana", [][]string{
+			{"hsingle"}, {"hk"}, {"hs"}, {"ht"}, {"hn"}, {"hh"}, {"hm"},
+			{"hy", "hr"}, {"hw", "hn1"},
+			{"hg", "hz"}, {"hd", "hb", "hp"},
+			{"hkya", "hsha", "hcha", "hnya", "hhya", "hmya", "hrya"},
+			{"hgya", "hja", "hbya", "hpya"},
+		}},
+		{"katakana", "Katakana", [][]string{
+			{"ksingle"}, {"kk"}, {"ks"}, {"kt"}, {"kn"}, {"kh"}, {"km"},
+			{"ky", "kr"}, {"kw", "kn1"},
+			{"kg", "kz"}, {"kd", "kb", "kp"},
+			{"kkya", "ksha", "kcha", "knya", "khya", "kmya", "krya"},
+			{"kgya", "kja", "kbya", "kpya"},
+		}},
+	} {
+		for i, groups := range script.plan {
+			l := Lesson{
+				ID:          fmt.Sprintf("%s-%d", script.prefix, i+1),
+				Title:       script.name + ": " + title(groups),
+				Groups:      groups,
+				MinAttempts: minAttempts,
+				Threshold:   basicThreshold,
+			}
+			if g, _ := kana.GetGroup(groups[0]); g.Section == kana.SectionCombination {
+				l.Threshold = combinationThreshold
+			}
+			ls = append(ls, l)
+		}
+	}
+	return ls
+}()
+
+// title joins the labels of the groups, e.g. "や-row, ら-row".
+func title(groups []string) string {
+	s := ""
+	for i, id := range groups {
+		g, _ := kana.GetGroup(id)
+		if i > 0 {
+			s += ", "
+		}
+		s += g.Label
+	}
+	return s
+}
+
+// Lessons returns the lesson plan in order.
+func Lessons() []Lesson {
+	return append([]Lesson(nil), lessons...)
+}
+
+// Kana returns the characters the lesson teaches.
+func (l Lesson) Kana() []string {
+	chars, _ := kana.CharsFor(l.Groups)
+	out := make([]string, len(chars))
+	for i, c := range chars {
+		out[i] = c.Kana
+	}
+	return out
+}
+
+// Accuracy returns the lesson's recent accuracy and whether any of its
+// characters has been answered.
+func (l Lesson) Accuracy(stats map[string]Stat) (float64, bool) {
+	attempts, correct := 0, 0
+	for _, k := range l.Kana() {
+		attempts += stats[k].Attempts
+		correct += stats[k].Correct
+	}
+	if attempts == 0 {
+		return 0, false
+	}
+	return float64(correct) / float64(attempts), true
+}
+
+// Mastered reports whether the recent answers meet the lesson's thresholds.
+func (l Lesson) Mastered(stats map[string]Stat) bool {
+	for _, k := range l.Kana() {
+		if stats[k].Attempts < l.MinAttempts {
+			return false
+		}
+	}
+	accuracy, ok := l.Accuracy(stats)
+	return ok && accuracy >= l.Threshold
+}
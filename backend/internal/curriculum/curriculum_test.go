@@ -0,0 +1,53 @@
+package curriculum
+
+import (
+	"testing"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+)
+
+func TestLessonsCoverTheCatalog(t *testing.T) {
+	seen := make(map[string]string)
+	for _, l := range Lessons() {
+		if len(l.Kana()) == 0 {
+			t.Errorf("%s: no kana", l.ID)
+		}
+		for _, g := range l.Groups {
+			if prev, ok := seen[g]; ok {
+				t.Errorf("%s: group %s already taught in %s", l.ID, g, prev)
+			}
+			seen[g] = l.ID
+		}
+	}
+	for _, g := range kana.Groups() {
+		if _, ok := seen[g.ID]; !ok {
+			t.Errorf("Group %s is not in any lesson", g.ID)
+		}
+	}
+}
+
+func TestMastered(t *testing.T) {
+	vowels := Lessons()[0]
+	stats := make(map[string]Stat)
+	for _, k := range vowels.Kana() {
+		stats[k] = Stat{Attempts: 10, Correct: 10}
+	}
+	if !vowels.Mastered(stats) {
+		t.Error("Expected perfect answers to master the lesson")
+	}
+
+	// One character short of the minimum attempts
+	stats["あ"] = Stat{Attempts: vowels.MinAttempts - 1, Correct: vowels.MinAttempts - 1}
+	if vowels.Mastered(stats) {
+		t.Error("Expected every character to need the minimum attempts")
+	}
+
+	// 40 of 50 is below the 0.9 threshold
+	stats["あ"] = Stat{Attempts: 10, Correct: 0}
+	if accuracy, _ := vowels.Accuracy(stats); accuracy != 0.8 {
+		t.Errorf("Expected 0.8 accuracy, got %v", accuracy)
+	}
+	if vowels.Mastered(stats) {
+		t.Error("Expected accuracy below the threshold not to master the lesson")
+	}
+}
@@ -6,12 +6,222 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
 )
 
+type BattleRun struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	RoomCode        string
+	Groups          []string
+	DurationSeconds int32
+	FinalScore      int32
+	Events          json.RawMessage
+	CreatedAt       time.Time
+	Direction       string
+	Choices         int32
+	Mode            string
+}
+
+type Class struct {
+	ID        uuid.UUID
+	TeacherID uuid.UUID
+	Name      string
+	JoinCode  string
+	CreatedAt time.Time
+}
+
+type ClassAssignment struct {
+	ID             uuid.UUID
+	ClassID        uuid.UUID
+	Title          string
+	Groups         []string
+	Kana           []string
+	TargetAccuracy float64
+	DueAt          time.Time
+	CreatedAt      time.Time
+}
+
+type ClassBattle struct {
+	ID        int64
+	ClassID   uuid.UUID
+	RoomCode  string
+	CreatedAt time.Time
+}
+
+type ClassMember struct {
+	ClassID  uuid.UUID
+	UserID   uuid.UUID
+	JoinedAt time.Time
+}
+
+type CoinAccount struct {
+	ID         int64
+	UserID     uuid.NullUUID
+	SystemName sql.NullString
+	Balance    int64
+	CreatedAt  time.Time
+}
+
+type CoinEntry struct {
+	ID         int64
+	TransferID int64
+	AccountID  int64
+	Amount     int64
+	CreatedAt  time.Time
+}
+
+type CoinTransfer struct {
+	ID        int64
+	Kind      string
+	Reference string
+	CreatedAt time.Time
+}
+
+type CurriculumProgress struct {
+	UserID     uuid.UUID
+	LessonID   string
+	UnlockedAt time.Time
+	MasteredAt sql.NullTime
+}
+
+type DailyAttempt struct {
+	ID            int64
+	UserID        uuid.UUID
+	ChallengeDate time.Time
+	StartedAt     time.Time
+	FinishedAt    sql.NullTime
+	Correct       int32
+	Total         int32
+	DurationMs    sql.NullInt32
+}
+
+type DailyChallenge struct {
+	ChallengeDate time.Time
+	Seed          int64
+	Length        int32
+	CreatedAt     time.Time
+}
+
+type Deck struct {
+	ID        uuid.UUID
+	Code      string
+	OwnerID   uuid.UUID
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type DeckEntry struct {
+	DeckID   uuid.UUID
+	Position int32
+	Prompt   string
+	Answer   string
+}
+
+type EquippedCosmetic struct {
+	UserID uuid.UUID
+	Slot   string
+	ItemID string
+}
+
+type GameRoom struct {
+	Code       string
+	InstanceID string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+}
+
+type GhostChallenge struct {
+	Code      string
+	RunID     uuid.UUID
+	CreatedBy uuid.UUID
+	CreatedAt time.Time
+}
+
+type GhostResult struct {
+	ID              int64
+	ChallengeCode   string
+	RunID           uuid.UUID
+	ChallengerID    uuid.UUID
+	GhostUserID     uuid.UUID
+	ChallengerScore int32
+	GhostScore      int32
+	CreatedAt       time.Time
+}
+
+type Kanji struct {
+	Literal     string
+	Grade       sql.NullInt32
+	Jlpt        sql.NullInt32
+	StrokeCount int32
+	Frequency   sql.NullInt32
+	Meanings    []string
+	OnReadings  []string
+	KunReadings []string
+	StrokePaths []string
+	UpdatedAt   time.Time
+}
+
+type LeagueMember struct {
+	UserID    uuid.UUID
+	WeekStart time.Time
+	Tier      int32
+	Bucket    int32
+	JoinedAt  time.Time
+	Points    sql.NullInt64
+	Outcome   sql.NullString
+}
+
+type LeaguePlayer struct {
+	UserID    uuid.UUID
+	Tier      int32
+	UpdatedAt time.Time
+}
+
+type LeagueWeek struct {
+	WeekStart time.Time
+	ClosedAt  sql.NullTime
+}
+
+type PracticeAnswer struct {
+	ID         int64
+	SessionID  uuid.UUID
+	UserID     uuid.UUID
+	Kana       string
+	Expected   string
+	Given      string
+	Correct    bool
+	LatencyMs  int32
+	AnsweredAt time.Time
+}
+
+type PracticeSession struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Groups     []string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	CreatedAt  time.Time
+	Direction  string
+}
+
+type ReadingItem struct {
+	ID        int64
+	Kind      string
+	Source    string
+	SourceID  string
+	Text      string
+	Written   string
+	Meaning   string
+	Groups    []string
+	CreatedAt time.Time
+}
+
 type RefreshToken struct {
 	ID         int64
 	UserID     uuid.UUID
@@ -24,6 +234,52 @@ type RefreshToken struct {
 	Ip         pqtype.Inet
 }
 
+type ShareCard struct {
+	ID        uuid.UUID
+	RoomCode  string
+	Groups    []string
+	Standings json.RawMessage
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+type SprintRun struct {
+	ID         int64
+	UserID     uuid.UUID
+	GroupSet   string
+	Duration   int32
+	Seed       int64
+	Correct    int32
+	Answered   int32
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+type SrsCard struct {
+	UserID       uuid.UUID
+	Kana         string
+	State        int16
+	Stability    float64
+	Difficulty   float64
+	EaseFactor   float64
+	IntervalDays int32
+	Reps         int32
+	Lapses       int32
+	DueAt        time.Time
+	LastReviewAt sql.NullTime
+	CreatedAt    time.Time
+}
+
+type SrsReviewLog struct {
+	ID         int64
+	UserID     uuid.UUID
+	Kana       string
+	Grade      int16
+	ResponseMs int32
+	State      int16
+	ReviewedAt time.Time
+}
+
 type User struct {
 	ID             uuid.UUID
 	CreatedAt      time.Time
@@ -31,4 +287,40 @@ type User struct {
 	Email          string
 	Username       string
 	HashedPassword string
+	TimeZone       string
+	Xp             int64
+	StreakCurrent  int32
+	StreakLongest  int32
+	StreakLastDay  sql.NullTime
+	StreakFreezes  int32
+	RomajiSystems  []string
+	Role           string
+}
+
+type UserAchievement struct {
+	UserID        uuid.UUID
+	AchievementID string
+	UnlockedAt    time.Time
+}
+
+type UserCosmetic struct {
+	UserID      uuid.UUID
+	ItemID      string
+	TransferID  int64
+	PurchasedAt time.Time
+}
+
+type UserCounter struct {
+	UserID    uuid.UUID
+	Name      string
+	Value     int64
+	UpdatedAt time.Time
+}
+
+type WsTicket struct {
+	TokenHash []byte
+	UserID    uuid.UUID
+	Ip        sql.NullString
+	CreatedAt time.Time
+	ExpiresAt time.Time
 }
@@ -6,24 +6,138 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AddClassMember(ctx context.Context, arg AddClassMemberParams) error
+	AddCoinBalance(ctx context.Context, arg AddCoinBalanceParams) error
+	AddOwnedCosmetic(ctx context.Context, arg AddOwnedCosmeticParams) (int64, error)
+	ClaimGameRoom(ctx context.Context, arg ClaimGameRoomParams) (int64, error)
+	CloseLeagueWeek(ctx context.Context, weekStart time.Time) (int64, error)
+	CountDailyAttemptsAhead(ctx context.Context, arg CountDailyAttemptsAheadParams) (int64, error)
+	CountDueSRSCards(ctx context.Context, arg CountDueSRSCardsParams) (int64, error)
+	CountKanjiByJLPT(ctx context.Context) ([]CountKanjiByJLPTRow, error)
+	CountSRSCardsCreatedSince(ctx context.Context, arg CountSRSCardsCreatedSinceParams) (int64, error)
+	CreateAssignment(ctx context.Context, arg CreateAssignmentParams) (ClassAssignment, error)
+	CreateBattleRun(ctx context.Context, arg CreateBattleRunParams) (BattleRun, error)
+	CreateClass(ctx context.Context, arg CreateClassParams) (Class, error)
+	CreateClassBattle(ctx context.Context, arg CreateClassBattleParams) error
+	CreateCoinEntry(ctx context.Context, arg CreateCoinEntryParams) error
+	CreateCoinTransfer(ctx context.Context, arg CreateCoinTransferParams) (int64, error)
+	CreateDailyAttempt(ctx context.Context, arg CreateDailyAttemptParams) (DailyAttempt, error)
+	CreateDeck(ctx context.Context, arg CreateDeckParams) (Deck, error)
+	CreateDeckEntries(ctx context.Context, arg CreateDeckEntriesParams) error
+	CreateGhostChallenge(ctx context.Context, arg CreateGhostChallengeParams) (GhostChallenge, error)
+	CreateGhostResult(ctx context.Context, arg CreateGhostResultParams) (GhostResult, error)
+	CreateLeagueWeek(ctx context.Context, weekStart time.Time) error
+	CreatePracticeAnswers(ctx context.Context, arg CreatePracticeAnswersParams) error
+	CreatePracticeSession(ctx context.Context, arg CreatePracticeSessionParams) (PracticeSession, error)
 	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error)
+	CreateSRSReviewLog(ctx context.Context, arg CreateSRSReviewLogParams) error
+	CreateShareCard(ctx context.Context, arg CreateShareCardParams) error
+	CreateSprintRun(ctx context.Context, arg CreateSprintRunParams) (SprintRun, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserAchievement(ctx context.Context, arg CreateUserAchievementParams) (int64, error)
+	CreateWSTicket(ctx context.Context, arg CreateWSTicketParams) error
+	CreateXPLedgerEntry(ctx context.Context, arg CreateXPLedgerEntryParams) error
+	DeleteDeck(ctx context.Context, id uuid.UUID) error
+	DeleteDeckEntries(ctx context.Context, deckID uuid.UUID) error
+	DeleteExpiredShareCards(ctx context.Context) ([]uuid.UUID, error)
+	DeleteExpiredWSTickets(ctx context.Context) error
+	DeleteGameRoom(ctx context.Context, code string) error
+	DeleteGameRoomsByInstance(ctx context.Context, instanceID string) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	EnsureCoinAccount(ctx context.Context, userID uuid.NullUUID) error
+	EquipCosmetic(ctx context.Context, arg EquipCosmeticParams) error
+	FindOpenLeagueBucket(ctx context.Context, arg FindOpenLeagueBucketParams) (int32, error)
+	FinishDailyAttempt(ctx context.Context, arg FinishDailyAttemptParams) (DailyAttempt, error)
 	GetActiveRefreshTokenByTokenHash(ctx context.Context, tokenHash []byte) (RefreshToken, error)
+	GetActivityHeatmap(ctx context.Context, arg GetActivityHeatmapParams) ([]GetActivityHeatmapRow, error)
+	GetAssignmentProgress(ctx context.Context, classID uuid.UUID) ([]GetAssignmentProgressRow, error)
+	GetBattleRun(ctx context.Context, id uuid.UUID) (BattleRun, error)
+	GetCharacterStats(ctx context.Context, arg GetCharacterStatsParams) ([]GetCharacterStatsRow, error)
+	GetClass(ctx context.Context, id uuid.UUID) (Class, error)
+	GetClassBattleStats(ctx context.Context, classID uuid.UUID) ([]GetClassBattleStatsRow, error)
+	GetClassByJoinCode(ctx context.Context, joinCode string) (Class, error)
+	GetCoinAccountForUpdate(ctx context.Context, userID uuid.NullUUID) (CoinAccount, error)
+	GetCoinBalance(ctx context.Context, userID uuid.NullUUID) (int64, error)
+	GetConfusionMatrix(ctx context.Context, arg GetConfusionMatrixParams) ([]GetConfusionMatrixRow, error)
+	GetDailyAttempt(ctx context.Context, arg GetDailyAttemptParams) (DailyAttempt, error)
+	GetDailyLeaderboard(ctx context.Context, arg GetDailyLeaderboardParams) ([]GetDailyLeaderboardRow, error)
+	GetDeckByCode(ctx context.Context, code string) (GetDeckByCodeRow, error)
+	GetGameRoomOwner(ctx context.Context, code string) (string, error)
+	GetGhostChallenge(ctx context.Context, code string) (GetGhostChallengeRow, error)
+	GetKanji(ctx context.Context, literal string) (Kanji, error)
+	GetLeagueBucketStandings(ctx context.Context, arg GetLeagueBucketStandingsParams) ([]GetLeagueBucketStandingsRow, error)
+	GetLeagueMember(ctx context.Context, arg GetLeagueMemberParams) (LeagueMember, error)
+	GetLeagueTier(ctx context.Context, userID uuid.UUID) (int32, error)
+	GetReadingItem(ctx context.Context, id int64) (ReadingItem, error)
+	GetRecentCharacterAccuracy(ctx context.Context, arg GetRecentCharacterAccuracyParams) ([]GetRecentCharacterAccuracyRow, error)
+	GetSRSCards(ctx context.Context, arg GetSRSCardsParams) ([]SrsCard, error)
+	GetShareCard(ctx context.Context, id uuid.UUID) (ShareCard, error)
+	GetSprintBest(ctx context.Context, arg GetSprintBestParams) (GetSprintBestRow, error)
+	GetSprintPercentile(ctx context.Context, arg GetSprintPercentileParams) (GetSprintPercentileRow, error)
+	GetSystemCoinAccountID(ctx context.Context, systemName sql.NullString) (int64, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (User, error)
 	GetUserByUsername(ctx context.Context, username string) (User, error)
+	GetUserForUpdate(ctx context.Context, id uuid.UUID) (User, error)
 	GetUserFromRefreshTokenHash(ctx context.Context, tokenHash []byte) (User, error)
+	IncrementUserCounter(ctx context.Context, arg IncrementUserCounterParams) (int64, error)
+	IsClassMember(ctx context.Context, arg IsClassMemberParams) (bool, error)
+	IsCosmeticOwned(ctx context.Context, arg IsCosmeticOwnedParams) (bool, error)
+	JoinLeague(ctx context.Context, arg JoinLeagueParams) error
+	ListAssignments(ctx context.Context, classID uuid.UUID) ([]ClassAssignment, error)
+	ListBattleRunsByUser(ctx context.Context, arg ListBattleRunsByUserParams) ([]BattleRun, error)
+	ListClassMembers(ctx context.Context, classID uuid.UUID) ([]ListClassMembersRow, error)
+	ListClassesForUser(ctx context.Context, userID uuid.UUID) ([]ListClassesForUserRow, error)
+	ListCoinEntries(ctx context.Context, arg ListCoinEntriesParams) ([]ListCoinEntriesRow, error)
+	ListCurriculumProgress(ctx context.Context, userID uuid.UUID) ([]CurriculumProgress, error)
+	ListDeckEntries(ctx context.Context, deckID uuid.UUID) ([]ListDeckEntriesRow, error)
+	ListDeckEntriesByCodes(ctx context.Context, codes []string) ([]ListDeckEntriesByCodesRow, error)
+	ListDecksByOwner(ctx context.Context, ownerID uuid.UUID) ([]ListDecksByOwnerRow, error)
+	ListDueSRSCards(ctx context.Context, arg ListDueSRSCardsParams) ([]SrsCard, error)
+	ListEquippedCosmetics(ctx context.Context, userID uuid.UUID) ([]EquippedCosmetic, error)
+	ListGhostResultsForUser(ctx context.Context, arg ListGhostResultsForUserParams) ([]ListGhostResultsForUserRow, error)
+	ListKanjiByJLPT(ctx context.Context, arg ListKanjiByJLPTParams) ([]Kanji, error)
+	ListKanjiReadingsByJLPT(ctx context.Context, levels []int32) ([]ListKanjiReadingsByJLPTRow, error)
+	ListLeagueWeekStandings(ctx context.Context, arg ListLeagueWeekStandingsParams) ([]ListLeagueWeekStandingsRow, error)
+	ListOpenLeagueWeeks(ctx context.Context, weekStart time.Time) ([]time.Time, error)
+	ListOwnedCosmetics(ctx context.Context, userID uuid.UUID) ([]UserCosmetic, error)
+	ListReadableItems(ctx context.Context, arg ListReadableItemsParams) ([]ReadingItem, error)
+	ListSRSCardKana(ctx context.Context, userID uuid.UUID) ([]string, error)
+	ListSprintHistory(ctx context.Context, arg ListSprintHistoryParams) ([]SprintRun, error)
+	ListUserAchievements(ctx context.Context, userID uuid.UUID) ([]UserAchievement, error)
+	ListUserCounters(ctx context.Context, userID uuid.UUID) ([]UserCounter, error)
+	MarkLessonMastered(ctx context.Context, arg MarkLessonMasteredParams) (int64, error)
+	NextLeagueBucket(ctx context.Context, arg NextLeagueBucketParams) (int32, error)
+	Notify(ctx context.Context, arg NotifyParams) error
+	RaiseUserCounter(ctx context.Context, arg RaiseUserCounterParams) (int64, error)
+	RedeemWSTicket(ctx context.Context, tokenHash []byte) (WsTicket, error)
+	RemoveClassMember(ctx context.Context, arg RemoveClassMemberParams) (int64, error)
+	RenameDeck(ctx context.Context, arg RenameDeckParams) error
+	RenewGameRooms(ctx context.Context, arg RenewGameRoomsParams) error
 	Reset(ctx context.Context) error
 	RevokeAllRefreshTokensForUser(ctx context.Context, userID uuid.UUID) error
 	RevokeRefreshTokenByHash(ctx context.Context, tokenHash []byte) error
 	RevokeRefreshTokenByID(ctx context.Context, id int64) error
+	SetLeagueOutcome(ctx context.Context, arg SetLeagueOutcomeParams) error
+	SetLeagueTier(ctx context.Context, arg SetLeagueTierParams) error
+	SetUserRole(ctx context.Context, arg SetUserRoleParams) (int64, error)
+	UnequipCosmetic(ctx context.Context, arg UnequipCosmeticParams) (int64, error)
+	UnlockLesson(ctx context.Context, arg UnlockLessonParams) error
+	UpdateKanjiStrokePaths(ctx context.Context, arg UpdateKanjiStrokePathsParams) (int64, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserProgress(ctx context.Context, arg UpdateUserProgressParams) error
+	UpdateUserSettings(ctx context.Context, arg UpdateUserSettingsParams) (User, error)
+	UpsertDailyChallenge(ctx context.Context, arg UpsertDailyChallengeParams) (DailyChallenge, error)
+	UpsertKanji(ctx context.Context, arg UpsertKanjiParams) error
+	UpsertReadingItem(ctx context.Context, arg UpsertReadingItemParams) error
+	UpsertSRSCard(ctx context.Context, arg UpsertSRSCardParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sqlc-dev/pqtype"
 )
 
@@ -77,7 +78,7 @@ func (q *Queries) GetActiveRefreshTokenByTokenHash(ctx context.Context, tokenHas
 }
 
 const getUserFromRefreshTokenHash = `-- name: GetUserFromRefreshTokenHash :one
-SELECT u.id, u.created_at, u.updated_at, u.email, u.username, u.hashed_password
+SELECT u.id, u.created_at, u.updated_at, u.email, u.username, u.hashed_password, u.time_zone, u.xp, u.streak_current, u.streak_longest, u.streak_last_day, u.streak_freezes, u.romaji_systems, u.role
 FROM refresh_tokens rt
 JOIN users u ON u.id = rt.user_id
 WHERE rt.token_hash = $1
@@ -96,6 +97,14 @@ func (q *Queries) GetUserFromRefreshTokenHash(ctx context.Context, tokenHash []b
 		&i.Email,
 		&i.Username,
 		&i.HashedPassword,
+		&i.TimeZone,
+		&i.Xp,
+		&i.StreakCurrent,
+		&i.StreakLongest,
+		&i.StreakLastDay,
+		&i.StreakFreezes,
+		pq.Array(&i.RomajiSystems),
+		&i.Role,
 	)
 	return i, err
 }
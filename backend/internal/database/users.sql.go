@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createUser = `-- name: CreateUser :one
@@ -21,7 +22,7 @@ VALUES (
     $2,
     $3
 )
-RETURNING id, created_at, updated_at, email, username, hashed_password
+RETURNING id, created_at, updated_at, email, username, hashed_password, time_zone, xp, streak_current, streak_longest, streak_last_day, streak_freezes, romaji_systems, role
 `
 
 type CreateUserParams struct {
@@ -40,6 +41,14 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Email,
 		&i.Username,
 		&i.HashedPassword,
+		&i.TimeZone,
+		&i.Xp,
+		&i.StreakCurrent,
+		&i.StreakLongest,
+		&i.StreakLastDay,
+		&i.StreakFreezes,
+		pq.Array(&i.RomajiSystems),
+		&i.Role,
 	)
 	return i, err
 }
@@ -55,7 +64,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, created_at, updated_at, email, username, hashed_password FROM users
+SELECT id, created_at, updated_at, email, username, hashed_password, time_zone, xp, streak_current, streak_longest, streak_last_day, streak_freezes, romaji_systems, role FROM users
 WHERE email = $1
 `
 
@@ -69,12 +78,20 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Email,
 		&i.Username,
 		&i.HashedPassword,
+		&i.TimeZone,
+		&i.Xp,
+		&i.StreakCurrent,
+		&i.StreakLongest,
+		&i.StreakLastDay,
+		&i.StreakFreezes,
+		pq.Array(&i.RomajiSystems),
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, created_at, updated_at, email, username, hashed_password FROM users
+SELECT id, created_at, updated_at, email, username, hashed_password, time_zone, xp, streak_current, streak_longest, streak_last_day, streak_freezes, romaji_systems, role FROM users
 WHERE id = $1
 `
 
@@ -88,12 +105,20 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.Email,
 		&i.Username,
 		&i.HashedPassword,
+		&i.TimeZone,
+		&i.Xp,
+		&i.StreakCurrent,
+		&i.StreakLongest,
+		&i.StreakLastDay,
+		&i.StreakFreezes,
+		pq.Array(&i.RomajiSystems),
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, created_at, updated_at, email, username, hashed_password FROM users
+SELECT id, created_at, updated_at, email, username, hashed_password, time_zone, xp, streak_current, streak_longest, streak_last_day, streak_freezes, romaji_systems, role FROM users
 WHERE username = $1
 `
 
@@ -107,6 +132,14 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 		&i.Email,
 		&i.Username,
 		&i.HashedPassword,
+		&i.TimeZone,
+		&i.Xp,
+		&i.StreakCurrent,
+		&i.StreakLongest,
+		&i.StreakLastDay,
+		&i.StreakFreezes,
+		pq.Array(&i.RomajiSystems),
+		&i.Role,
 	)
 	return i, err
 }
@@ -114,7 +147,7 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 const updateUser = `-- name: UpdateUser :one
 UPDATE users SET email = $2, hashed_password = $3, username = $4, updated_at = NOW()
 WHERE id = $1
-RETURNING id, created_at, updated_at, email, username, hashed_password
+RETURNING id, created_at, updated_at, email, username, hashed_password, time_zone, xp, streak_current, streak_longest, streak_last_day, streak_freezes, romaji_systems, role
 `
 
 type UpdateUserParams struct {
@@ -139,6 +172,14 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Email,
 		&i.Username,
 		&i.HashedPassword,
+		&i.TimeZone,
+		&i.Xp,
+		&i.StreakCurrent,
+		&i.StreakLongest,
+		&i.StreakLastDay,
+		&i.StreakFreezes,
+		pq.Array(&i.RomajiSystems),
+		&i.Role,
 	)
 	return i, err
 }
@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+type AchievementResponse struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Unlocked    bool       `json:"unlocked"`
+	UnlockedAt  *time.Time `json:"unlocked_at,omitempty"`
+	Progress    int64      `json:"progress"` // capped at target
+	Target      int64      `json:"target"`
+}
@@ -0,0 +1,94 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateClassRequest struct {
+	Name string `json:"name" validate:"required,max=80"`
+}
+
+type JoinClassRequest struct {
+	Code string `json:"code" validate:"required,max=16"`
+}
+
+type CreateAssignmentRequest struct {
+	Title          string    `json:"title" validate:"required,max=80"`
+	Groups         []string  `json:"groups" validate:"required,min=1,max=50"`
+	TargetAccuracy float64   `json:"target_accuracy" validate:"required,gt=0,lte=1"`
+	DueAt          time.Time `json:"due_at" validate:"required"`
+}
+
+type ClassResponse struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	TeacherID       uuid.UUID `json:"teacher_id"`
+	TeacherUsername string    `json:"teacher_username"`
+	JoinCode        string    `json:"join_code,omitempty"` // only shown to the teacher
+	MemberCount     int       `json:"member_count"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type ClassDetailResponse struct {
+	ClassResponse
+	// Only shown to the teacher
+	Members     []ClassMemberResponse `json:"members,omitempty"`
+	Assignments []AssignmentResponse  `json:"assignments"`
+}
+
+type ClassMemberResponse struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type AssignmentResponse struct {
+	ID             uuid.UUID `json:"id"`
+	Title          string    `json:"title"`
+	Groups         []string  `json:"groups"`
+	Kana           []string  `json:"kana"`
+	TargetAccuracy float64   `json:"target_accuracy"` // 0 to 1
+	DueAt          time.Time `json:"due_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	// A student's own progress
+	Progress *AssignmentProgressResponse `json:"progress,omitempty"`
+}
+
+type AssignmentProgressResponse struct {
+	AssignmentID  uuid.UUID `json:"assignment_id"`
+	Attempts      int       `json:"attempts"`
+	Correct       int       `json:"correct"`
+	Accuracy      float64   `json:"accuracy"` // 0 to 1
+	KanaPracticed int       `json:"kana_practiced"`
+	Status        string    `json:"status"` // not_started, in_progress, completed or missed
+}
+
+type ClassReportResponse struct {
+	ClassID     uuid.UUID               `json:"class_id"`
+	ClassName   string                  `json:"class_name"`
+	GeneratedAt time.Time               `json:"generated_at"`
+	Assignments []AssignmentResponse    `json:"assignments"`
+	Students    []StudentReportResponse `json:"students"`
+}
+
+type StudentReportResponse struct {
+	UserID      uuid.UUID                    `json:"user_id"`
+	Username    string                       `json:"username"`
+	JoinedAt    time.Time                    `json:"joined_at"`
+	Assignments []AssignmentProgressResponse `json:"assignments"` // in the report's order
+	Battles     BattleStatsResponse          `json:"battles"`
+}
+
+// BattleStatsResponse covers the battles a student played since joining.
+type BattleStatsResponse struct {
+	Battles      int     `json:"battles"`
+	ClassBattles int     `json:"class_battles"` // in rooms opened for the class
+	BestScore    int     `json:"best_score"`
+	AverageScore float64 `json:"average_score"`
+}
+
+type ClassBattleResponse struct {
+	RoomCode string `json:"room_code"`
+}
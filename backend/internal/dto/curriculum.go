@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+type CurriculumResponse struct {
+	Lessons []LessonResponse `json:"lessons"`
+	// Groups of the unlocked lessons, for picking what to practice
+	UnlockedGroups []string `json:"unlocked_groups"`
+}
+
+type LessonResponse struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Groups      []string   `json:"groups"`
+	Kana        []string   `json:"kana"`
+	Status      string     `json:"status"` // locked, unlocked, in_progress or mastered
+	MinAttempts int        `json:"min_attempts"`
+	Threshold   float64    `json:"threshold"` // 0 to 1
+	Attempts    int        `json:"attempts"`  // among each character's recent answers
+	Accuracy    float64    `json:"accuracy"`  // 0 to 1
+	UnlockedAt  *time.Time `json:"unlocked_at,omitempty"`
+	MasteredAt  *time.Time `json:"mastered_at,omitempty"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/google/uuid"
+)
+
+type DailyChallengeResponse struct {
+	Date    string `json:"date"` // YYYY-MM-DD (UTC)
+	Length  int    `json:"length"`
+	Started bool   `json:"started"`
+	// Only present once the caller has started today's attempt
+	Sequence []kana.Char          `json:"sequence,omitempty"`
+	Result   *DailyResultResponse `json:"result,omitempty"`
+}
+
+type DailyStartResponse struct {
+	Date      string      `json:"date"`
+	StartedAt time.Time   `json:"started_at"`
+	Sequence  []kana.Char `json:"sequence"`
+}
+
+type DailyFinishRequest struct {
+	Date    string   `json:"date" validate:"required,datetime=2006-01-02"`
+	Answers []string `json:"answers" validate:"required,max=200"`
+}
+
+type DailyResultResponse struct {
+	Date       string    `json:"date"`
+	Correct    int       `json:"correct"`
+	Total      int       `json:"total"`
+	DurationMs int       `json:"duration_ms"`
+	FinishedAt time.Time `json:"finished_at"`
+	Rank       int       `json:"rank"`
+}
+
+type DailyLeaderboardEntry struct {
+	Rank       int       `json:"rank"`
+	UserID     uuid.UUID `json:"user_id"`
+	Username   string    `json:"username"`
+	Correct    int       `json:"correct"`
+	Total      int       `json:"total"`
+	DurationMs int       `json:"duration_ms"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+)
+
+type DeckEntryRequest struct {
+	Kana    string `json:"kana" validate:"required,max=16"`
+	Romanji string `json:"romanji" validate:"required,max=40"`
+}
+
+type DeckRequest struct {
+	Name    string             `json:"name" validate:"required,max=60"`
+	Entries []DeckEntryRequest `json:"entries" validate:"required,min=1,max=200,dive"`
+}
+
+type DeckResponse struct {
+	Code      string      `json:"code"`
+	Group     string      `json:"group"` // use in groups, e.g. "deck:ABC123"
+	Name      string      `json:"name"`
+	Owner     string      `json:"owner"`
+	Entries   []kana.Char `json:"entries"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+type DeckSummaryResponse struct {
+	Code       string    `json:"code"`
+	Group      string    `json:"group"`
+	Name       string    `json:"name"`
+	EntryCount int       `json:"entry_count"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
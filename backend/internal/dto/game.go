@@ -1,6 +1,20 @@
 package dto
 
 type CreateRoomRequest struct {
-	Duration int      `json:"duration" validate:"required,min=30,max=600"`
-	Groups   []string `json:"groups" validate:"required,min=1"`
+	Duration  int      `json:"duration" validate:"required_unless=Presenter true,omitempty,min=30,max=600"`
+	Groups    []string `json:"groups" validate:"required,min=1"`
+	Direction string   `json:"direction,omitempty"` // kana.Direction, kana to romaji by default
+	// Multiple-choice prompts with this many choices; typed answers if 0
+	Choices int `json:"choices,omitempty" validate:"omitempty,min=2,max=6"`
+	// A live quiz the host runs without playing; Duration isn't needed
+	Presenter       bool `json:"presenter,omitempty"`
+	QuestionSeconds int  `json:"question_seconds,omitempty" validate:"omitempty,min=5,max=120"`
+	// A co-op game clearing a shared board of BoardSize tiles
+	Raid      bool `json:"raid,omitempty"`
+	BoardSize int  `json:"board_size,omitempty" validate:"omitempty,min=4,max=60"`
+}
+
+type WSTicketResponse struct {
+	Ticket    string `json:"ticket"`
+	ExpiresIn int    `json:"expires_in"` // seconds
 }
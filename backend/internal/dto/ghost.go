@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BattleRunResponse struct {
+	ID         uuid.UUID `json:"id"`
+	RoomCode   string    `json:"room_code"`
+	Mode       string    `json:"mode"` // only battle runs can be raced
+	Groups     []string  `json:"groups"`
+	Direction  string    `json:"direction"`
+	Choices    int       `json:"choices"` // 0 when answers were typed
+	Duration   int       `json:"duration"`
+	FinalScore int       `json:"final_score"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type CreateGhostChallengeRequest struct {
+	RunID uuid.UUID `json:"run_id" validate:"required"`
+}
+
+type GhostChallengeResponse struct {
+	Code       string    `json:"code"`
+	RunID      uuid.UUID `json:"run_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Username   string    `json:"username"`
+	Groups     []string  `json:"groups"`
+	Direction  string    `json:"direction"`
+	Choices    int       `json:"choices"` // 0 when answers were typed
+	Duration   int       `json:"duration"`
+	FinalScore int       `json:"final_score"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type GhostRoomResponse struct {
+	RoomCode string `json:"room_code"`
+}
+
+type GhostResultResponse struct {
+	ID                 int64     `json:"id"`
+	ChallengeCode      string    `json:"challenge_code"`
+	RunID              uuid.UUID `json:"run_id"`
+	ChallengerID       uuid.UUID `json:"challenger_id"`
+	ChallengerUsername string    `json:"challenger_username"`
+	ChallengerScore    int       `json:"challenger_score"`
+	GhostUserID        uuid.UUID `json:"ghost_user_id"`
+	GhostUsername      string    `json:"ghost_username"`
+	GhostScore         int       `json:"ghost_score"`
+	CreatedAt          time.Time `json:"created_at"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "github.com/Cadimodev/haiji/backend/internal/handwriting"
+
+type RecognizeRequest struct {
+	Strokes  []handwriting.Stroke `json:"strokes" validate:"required,min=1,max=40,dive,max=1000"`
+	Category string               `json:"category,omitempty" validate:"omitempty,oneof=hiragana katakana"`
+	Limit    int                  `json:"limit,omitempty" validate:"min=0,max=20"` // 5 if unset
+}
+
+type RecognizeCandidateResponse struct {
+	Kana       string  `json:"kana"`
+	Romanji    string  `json:"romanji"`
+	Confidence float64 `json:"confidence"` // 0 to 1
+}
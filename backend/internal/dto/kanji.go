@@ -0,0 +1,20 @@
+package dto
+
+type KanjiResponse struct {
+	Literal     string   `json:"literal"`
+	Meanings    []string `json:"meanings"`
+	OnReadings  []string `json:"on_readings"`
+	KunReadings []string `json:"kun_readings"`
+	JLPT        int      `json:"jlpt,omitempty"`
+	Grade       int      `json:"grade,omitempty"`
+	StrokeCount int      `json:"stroke_count"`
+	Frequency   int      `json:"frequency,omitempty"`
+	// SVG path data on a 109x109 box, in stroke order; only on single kanji
+	StrokePaths []string `json:"stroke_paths,omitempty"`
+}
+
+type KanjiLevelResponse struct {
+	Level int    `json:"level"`
+	Group string `json:"group"` // use in groups, e.g. "kanji:jlpt4"
+	Count int    `json:"count"`
+}
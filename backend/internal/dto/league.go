@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LeagueResponse struct {
+	WeekStart time.Time `json:"week_start"`
+	EndsAt    time.Time `json:"ends_at"`
+	Tier      int       `json:"tier"`
+	TierName  string    `json:"tier_name"`
+	// False until the caller earns points this week
+	Enrolled bool `json:"enrolled"`
+	Bucket   int  `json:"bucket,omitempty"`
+	// How many players move up and down when the week ends
+	Promote   int              `json:"promote"`
+	Relegate  int              `json:"relegate"`
+	Standings []LeagueStanding `json:"standings"`
+}
+
+type LeagueStanding struct {
+	Rank     int       `json:"rank"`
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	Points   int64     `json:"points"`
+	// Where the player would end up if the week ended now
+	Outcome string `json:"outcome"`
+	IsMe    bool   `json:"is_me"`
+}
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/handwriting"
+	"github.com/google/uuid"
+)
+
+type PracticeAnswerRequest struct {
+	Kana      string `json:"kana" validate:"required"` // the character asked, whatever the direction
+	Answer    string `json:"answer" validate:"max=32"`
+	LatencyMs int    `json:"latency_ms" validate:"min=0,max=600000"`
+	// When the answer was given, in milliseconds after the session's started_at
+	OffsetMs int64 `json:"offset_ms" validate:"min=0,max=86400000"`
+
+	// A drawn answer, graded instead of Answer when present
+	Strokes []handwriting.Stroke `json:"strokes,omitempty" validate:"max=40,dive,max=1000"`
+}
+
+type PracticeSessionRequest struct {
+	Groups    []string                `json:"groups" validate:"max=100"`
+	Direction string                  `json:"direction,omitempty"` // kana.Direction, kana to romaji by default
+	StartedAt time.Time               `json:"started_at" validate:"required"`
+	Answers   []PracticeAnswerRequest `json:"answers" validate:"required,min=1,max=500,dive"`
+}
+
+type PracticeSessionResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Total    int       `json:"total"`
+	Correct  int       `json:"correct"`
+	Accuracy float64   `json:"accuracy"` // 0 to 1
+	// Curriculum lessons the session unlocked
+	Unlocked []string `json:"unlocked,omitempty"`
+}
+
+type CharacterStat struct {
+	Kana            string  `json:"kana"`
+	Romanji         string  `json:"romanji"`
+	Attempts        int     `json:"attempts"`
+	Correct         int     `json:"correct"`
+	Accuracy        float64 `json:"accuracy"` // 0 to 1
+	MedianLatencyMs int     `json:"median_latency_ms"`
+}
+
+// Confusion counts a wrong answer given for a kana, e.g. シ answered as "tsu".
+type Confusion struct {
+	Kana     string `json:"kana"`
+	Expected string `json:"expected"`
+	Given    string `json:"given"`
+	Count    int    `json:"count"`
+}
+
+type ActivityDay struct {
+	Date    string `json:"date"` // YYYY-MM-DD in the requested time zone
+	Answers int    `json:"answers"`
+	Correct int    `json:"correct"`
+}
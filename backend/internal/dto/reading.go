@@ -0,0 +1,20 @@
+package dto
+
+type ReadingItemResponse struct {
+	ID      int64    `json:"id"`
+	Kind    string   `json:"kind"` // word or sentence
+	Text    string   `json:"text"`
+	Written string   `json:"written,omitempty"`
+	Meaning string   `json:"meaning,omitempty"`
+	Groups  []string `json:"groups"`
+}
+
+type ReadingAnswerRequest struct {
+	ItemID int64  `json:"item_id" validate:"required"`
+	Answer string `json:"answer" validate:"max=500"`
+}
+
+type ReadingAnswerResponse struct {
+	Correct  bool   `json:"correct"`
+	Expected string `json:"expected"` // Hepburn
+}
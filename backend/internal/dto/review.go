@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/srs"
+)
+
+type ReviewCard struct {
+	Kana    string    `json:"kana"`
+	Romanji string    `json:"romanji"`
+	State   srs.State `json:"state"`
+	Due     time.Time `json:"due"`
+	Reps    int       `json:"reps"`
+	Lapses  int       `json:"lapses"`
+}
+
+type ReviewQueueResponse struct {
+	Cards    []ReviewCard `json:"cards"`
+	DueCount int          `json:"due_count"` // all due cards, not only those returned
+	NewCount int          `json:"new_count"` // unseen kana included in cards
+}
+
+type ReviewAnswer struct {
+	Kana       string `json:"kana" validate:"required"`
+	Grade      int    `json:"grade" validate:"required,min=1,max=4"` // 1 again, 2 hard, 3 good, 4 easy
+	ResponseMs int    `json:"response_ms" validate:"min=0,max=600000"`
+}
+
+type SubmitReviewsRequest struct {
+	Reviews []ReviewAnswer `json:"reviews" validate:"required,min=1,max=200,dive"`
+}
+
+type SubmitReviewsResponse struct {
+	Cards []ReviewCard `json:"cards"`
+}
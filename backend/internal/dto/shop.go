@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/shop"
+)
+
+type ShopResponse struct {
+	Balance int64      `json:"balance"`
+	Items   []ShopItem `json:"items"`
+}
+
+type ShopItem struct {
+	shop.Item
+	Owned    bool `json:"owned"`
+	Equipped bool `json:"equipped"`
+}
+
+type PurchaseRequest struct {
+	ItemID string `json:"item_id" validate:"required"`
+}
+
+type PurchaseResponse struct {
+	Item    ShopItem `json:"item"`
+	Balance int64    `json:"balance"`
+}
+
+type EquipRequest struct {
+	ItemID string `json:"item_id" validate:"required"`
+}
+
+// EquippedResponse maps every equipped slot (the item kind) to its item ID.
+type EquippedResponse struct {
+	Equipped map[string]string `json:"equipped"`
+}
+
+type WalletResponse struct {
+	Balance int64         `json:"balance"`
+	Entries []WalletEntry `json:"entries"`
+}
+
+type WalletEntry struct {
+	Amount    int64     `json:"amount"` // negative when spent
+	Kind      string    `json:"kind"`
+	Reference string    `json:"reference"`
+	CreatedAt time.Time `json:"created_at"`
+}
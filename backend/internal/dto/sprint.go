@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+)
+
+type SprintStartRequest struct {
+	Groups   []string `json:"groups" validate:"required,min=1,max=30"`
+	Duration int      `json:"duration" validate:"required,oneof=60 120"` // seconds
+}
+
+type SprintStartResponse struct {
+	// Signed sprint, handed back with the answers
+	Challenge string      `json:"challenge"`
+	Groups    []string    `json:"groups"`
+	Duration  int         `json:"duration"`
+	StartedAt time.Time   `json:"started_at"`
+	EndsAt    time.Time   `json:"ends_at"`
+	Sequence  []kana.Char `json:"sequence"`
+}
+
+type SprintFinishRequest struct {
+	Challenge string `json:"challenge" validate:"required"`
+	// In the order of the sequence
+	Answers []string `json:"answers" validate:"max=480"`
+}
+
+type SprintResultResponse struct {
+	Groups     []string `json:"groups"`
+	Duration   int      `json:"duration"`
+	Correct    int      `json:"correct"`
+	Answered   int      `json:"answered"`
+	Best       int      `json:"best"`
+	NewBest    bool     `json:"new_best"`
+	Percentile float64  `json:"percentile"`
+}
+
+type SprintStatsResponse struct {
+	Groups   []string `json:"groups"`
+	Duration int      `json:"duration"`
+	Best     int      `json:"best"`
+	Runs     int      `json:"runs"`
+	// Share of other players with a lower best; absent before the first run
+	Percentile *float64 `json:"percentile,omitempty"`
+	// Latest runs, oldest first
+	History []SprintRunResponse `json:"history"`
+}
+
+type SprintRunResponse struct {
+	Correct    int       `json:"correct"`
+	Answered   int       `json:"answered"`
+	FinishedAt time.Time `json:"finished_at"`
+}
@@ -41,6 +41,25 @@ type UserResponse struct {
 	UpdatedAt time.Time `json:"updated_at"`
 	Email     string    `json:"email"`
 	Username  string    `json:"username"`
+	TimeZone  string    `json:"time_zone"`
+	Role      string    `json:"role"` // student or teacher
+
+	RomajiSystems []string `json:"romaji_systems"` // accepted when grading typed answers
+
+	XP          int64 `json:"xp"`
+	Level       int   `json:"level"`
+	LevelXP     int64 `json:"level_xp"`      // earned since reaching Level
+	NextLevelXP int64 `json:"next_level_xp"` // needed for the next level, 0 at the max level
+
+	Streak        int `json:"streak"` // 0 once broken
+	LongestStreak int `json:"longest_streak"`
+	StreakFreezes int `json:"streak_freezes"`
+}
+
+// UpdateSettingsRequest changes the settings that are present.
+type UpdateSettingsRequest struct {
+	TimeZone      *string  `json:"time_zone,omitempty"`
+	RomajiSystems []string `json:"romaji_systems,omitempty"` // romaji.System names
 }
 
 type UserWithTokenResponse struct {
@@ -0,0 +1,125 @@
+package game
+
+import (
+	"encoding/json"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/quiz"
+	"github.com/Cadimodev/haiji/backend/internal/romaji"
+)
+
+// In a battle every player answers the same questions in order, and the room
+// scores them: a right answer is a point, and right or wrong the player moves
+// on to the next question, so answers can't be guessed until one fits.
+
+// Questions generated per second of a battle
+const questionsPerSecond = 2
+
+// battleQuestion is one of the game's questions and the character it asks.
+type battleQuestion struct {
+	question quiz.Question
+	char     kana.Char
+}
+
+// prompt is a question as players see it. The answer stays in the room, and
+// so does the character asked, which in some directions is the answer.
+type prompt struct {
+	Prompt  string   `json:"prompt"`
+	Choices []string `json:"choices,omitempty"`
+}
+
+// dealQuestions generates the game's questions, more than the fastest player
+// can answer before time runs out, and returns them as players see them.
+func (r *Room) dealQuestions() []prompt {
+	pool := r.pool()
+	chars := make(map[string]kana.Char, len(pool))
+	for _, c := range pool {
+		chars[c.Kana] = c
+	}
+
+	rng := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	questions := quiz.Generate(pool, quiz.Options{
+		Count:     r.Duration * questionsPerSecond,
+		Choices:   r.Choices,
+		Direction: r.Direction,
+	}, rng)
+
+	r.questions = make([]battleQuestion, len(questions))
+	prompts := make([]prompt, len(questions))
+	for i, q := range questions {
+		r.questions[i] = battleQuestion{question: q, char: chars[q.Kana]}
+		prompts[i] = prompt{Prompt: q.Prompt, Choices: q.Choices}
+	}
+	return prompts
+}
+
+// answerQuestion scores a player's answer to their next question, the
+// 0-based index into the game's questions. The result, with the right
+// answer, only goes to the player. Called from the Run loop.
+func (r *Room) answerQuestion(client *Client, index int, answer string) {
+	if r.State != StatePlaying {
+		return
+	}
+	p, ok := r.Players[client.UserID]
+	if !ok || p.Ghost || index != p.answered || index >= len(r.questions) {
+		return
+	}
+	p.answered++
+
+	q := r.questions[index]
+	correct := r.isCorrect(q.question, q.char, answer, p.systems)
+
+	data, err := json.Marshal(map[string]interface{}{
+		"type":     "ANSWER_RESULT",
+		"question": index,
+		"correct":  correct,
+		"answer":   q.question.Answer,
+	})
+	if err == nil {
+		select {
+		case client.Send <- data:
+		default:
+			slog.Warn("Dropping answer result for slow client", "room", r.Code, "user", client.Username)
+		}
+	}
+
+	if !correct {
+		return
+	}
+	p.Score++
+	r.events[client.UserID] = append(r.events[client.UserID], ScoreEvent{
+		OffsetMs: time.Since(r.StartedAt).Milliseconds(),
+		Score:    p.Score,
+	})
+	r.broadcastScores()
+}
+
+// isCorrect checks an answer to a question on c: a choice must be the
+// answer, and typed answers may be spelled in any of the player's systems.
+func (r *Room) isCorrect(q quiz.Question, c kana.Char, answer string, systems []romaji.System) bool {
+	answer = strings.TrimSpace(answer)
+	if len(q.Choices) > 0 {
+		return answer == q.Answer
+	}
+	return r.Direction.IsCorrect(c, answer, systems...)
+}
+
+// broadcastQuestions sends the game's questions in order, a chunk per
+// message, ahead of GAME_STARTED.
+func (r *Room) broadcastQuestions(prompts []prompt) {
+	for _, part := range chunk(prompts) {
+		data, err := json.Marshal(map[string]interface{}{
+			"type":      "QUESTIONS",
+			"questions": part,
+		})
+		if err != nil {
+			slog.Error("Error marshalling questions", "error", err)
+			return
+		}
+		r.broadcastToClients(data)
+	}
+}
@@ -0,0 +1,31 @@
+package game
+
+import "encoding/json"
+
+// Largest JSON list sent in a single message. Messages for players on other
+// instances travel through Postgres NOTIFY, which caps payloads at 8000
+// bytes, envelope included.
+const maxChunkBytes = 6000
+
+// chunk splits items into runs that each encode to at most maxChunkBytes,
+// so long lists can be sent as several messages. An item too large on its
+// own gets a run of its own.
+func chunk[T any](items []T) [][]T {
+	var chunks [][]T
+	start, size := 0, 0
+	for i, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		if i > start && size+len(data)+1 > maxChunkBytes {
+			chunks = append(chunks, items[start:i])
+			start, size = i, 0
+		}
+		size += len(data) + 1
+	}
+	if start < len(items) {
+		chunks = append(chunks, items[start:])
+	}
+	return chunks
+}
@@ -1,10 +1,15 @@
 package game
 
 import (
+	"encoding/json"
 	"log"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/Cadimodev/haiji/backend/internal/romaji"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
@@ -16,23 +21,66 @@ const (
 	maxMessageSize = 512
 )
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	// IMPORTANT: Allow all origins is mostly for dev.
-	// Production should lock this down to the frontend domain.
-	CheckOrigin: func(r *http.Request) bool {
-		return true
-	},
+func newUpgrader(checkOrigin func(r *http.Request) bool) websocket.Upgrader {
+	return websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     checkOrigin,
+	}
+}
+
+// OriginChecker accepts upgrade requests whose Origin matches one of the
+// allowed origins (scheme and host, case-insensitive). Requests without an
+// Origin header don't come from a browser and are accepted.
+func OriginChecker(allowed []string) func(r *http.Request) bool {
+	set := make(map[string]bool, len(allowed))
+	for _, origin := range allowed {
+		if n := normalizeOrigin(origin); n != "" {
+			set[n] = true
+		}
+	}
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		return set[normalizeOrigin(origin)]
+	}
+}
+
+func normalizeOrigin(origin string) string {
+	u, err := url.Parse(strings.TrimSpace(origin))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ""
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host)
 }
 
 type Client struct {
+	ID       string
 	Hub      *Hub
 	Room     *Room
 	Conn     *websocket.Conn
 	Send     chan []byte
 	UserID   uuid.UUID
 	Username string
+
+	// Equipped shop items, by slot
+	Cosmetics map[string]string
+
+	// Romanization systems typed answers are graded in; all if empty
+	RomajiSystems []romaji.System
+
+	// Set when the client joined a room owned by another instance. Swapped
+	// from both the read pump and the hub's Run loop.
+	remote atomic.Pointer[remoteRoom]
+
+	limiter *clientLimiter
+}
+
+type remoteRoom struct {
+	code  string
+	owner string
 }
 
 func (c *Client) readPump() {
@@ -52,12 +100,38 @@ func (c *Client) readPump() {
 			break
 		}
 
+		switch c.limiter.check(messageType(message), time.Now()) {
+		case floodWarn:
+			select {
+			case c.Send <- []byte(`{"type":"WARNING", "message":"Too many messages, slow down"}`):
+			default:
+			}
+			continue
+		case floodDrop:
+			continue
+		case floodDisconnect:
+			log.Printf("Disconnecting %s for message flood", c.Username)
+			c.Conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "message flood"),
+				time.Now().Add(writeWait))
+			return
+		}
+
 		log.Printf("Reived message from %s: %s", c.Username, message)
 
 		c.Hub.handleMessage(c, message)
 	}
 }
 
+// messageType extracts the type of a client message for rate limiting.
+func messageType(msg []byte) string {
+	var base struct {
+		Type string `json:"type"`
+	}
+	json.Unmarshal(msg, &base)
+	return base.Type
+}
+
 func (c *Client) writePump() {
 	ticker := time.NewTicker(pingPeriod)
 	defer func() {
@@ -74,19 +148,8 @@ func (c *Client) writePump() {
 				return
 			}
 
-			w, err := c.Conn.NextWriter(websocket.TextMessage)
-			if err != nil {
-				return
-			}
-			w.Write(message)
-
-			// Add queued chat messages to the current websocket message.
-			n := len(c.Send)
-			for i := 0; i < n; i++ {
-				w.Write(<-c.Send)
-			}
-
-			if err := w.Close(); err != nil {
+			// One JSON message per frame: the client parses each frame whole
+			if err := c.Conn.WriteMessage(websocket.TextMessage, message); err != nil {
 				return
 			}
 		case <-ticker.C:
@@ -99,18 +162,23 @@ func (c *Client) writePump() {
 }
 
 // ServeWs handles websocket requests from the peer.
-func ServeWs(hub *Hub, w http.ResponseWriter, r *http.Request, userID uuid.UUID, username string) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+func ServeWs(hub *Hub, w http.ResponseWriter, r *http.Request, userID uuid.UUID, username string, cosmetics map[string]string, systems []romaji.System) {
+	conn, err := hub.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Println(err)
 		return
 	}
 	client := &Client{
-		Hub:      hub,
-		Conn:     conn,
-		Send:     make(chan []byte, 256),
-		UserID:   userID,
-		Username: username,
+		ID:        uuid.New().String(),
+		Hub:       hub,
+		Conn:      conn,
+		Send:      make(chan []byte, 256),
+		UserID:    userID,
+		Username:  username,
+		Cosmetics: cosmetics,
+		limiter:   newClientLimiter(),
+
+		RomajiSystems: systems,
 	}
 	client.Hub.register <- client
 
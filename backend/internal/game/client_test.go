@@ -0,0 +1,78 @@
+package game
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestOriginChecker(t *testing.T) {
+	check := OriginChecker([]string{"http://localhost", "https://haiji.example.com/"})
+
+	tests := []struct {
+		name   string
+		origin string
+		want   bool
+	}{
+		{name: "Allowed origin", origin: "http://localhost", want: true},
+		{name: "Case insensitive", origin: "HTTPS://Haiji.Example.com", want: true},
+		{name: "No origin header", origin: "", want: true},
+		{name: "Different port", origin: "http://localhost:3000", want: false},
+		{name: "Different scheme", origin: "https://localhost", want: false},
+		{name: "Unknown origin", origin: "https://evil.example.com", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/api/ws", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if got := check(req); got != tt.want {
+				t.Errorf("CheckOrigin(%q) = %v, want %v", tt.origin, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_WritePumpSendsOneMessagePerFrame(t *testing.T) {
+	upgrader := newUpgrader(nil)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		c := &Client{Conn: conn, Send: make(chan []byte, 3)}
+		// Queued together, as the chunks at the start of a game are
+		c.Send <- []byte(`{"type":"QUESTIONS","n":1}`)
+		c.Send <- []byte(`{"type":"QUESTIONS","n":2}`)
+		c.Send <- []byte(`{"type":"GAME_STARTED"}`)
+		c.writePump()
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+
+	for _, want := range []string{"QUESTIONS", "QUESTIONS", "GAME_STARTED"} {
+		_, frame, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("ReadMessage: %v", err)
+		}
+		var msg struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(frame, &msg); err != nil || msg.Type != want {
+			t.Fatalf("Expected a %s frame, got %s (%v)", want, frame, err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package game
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+)
+
+// ErrUnknownDeck is returned when a room's groups name a deck that doesn't
+// exist.
+var ErrUnknownDeck = errors.New("unknown deck")
+
+// DeckResolver loads the user decks named by "deck:CODE" groups and the
+// imported kanji named by "kanji:jlptN" groups, keyed by group. Missing
+// decks are reported with ErrUnknownDeck.
+type DeckResolver interface {
+	ResolveDecks(ctx context.Context, groups []string) (map[string][]kana.Char, error)
+}
+
+// SetDeckResolver lets rooms use user decks as groups. Must be called before
+// Run.
+func (h *Hub) SetDeckResolver(decks DeckResolver) {
+	h.decks = decks
+}
+
+// resolveDecks snapshots the decks and kanji named in groups when a room is
+// created, so editing a deck doesn't change a game under its players.
+func (h *Hub) resolveDecks(groups []string) (map[string][]kana.Char, error) {
+	hasDeck := false
+	for _, g := range groups {
+		_, isDeck := kana.DeckCode(g)
+		_, isKanji := kana.KanjiLevel(g)
+		if isDeck || isKanji {
+			hasDeck = true
+			break
+		}
+	}
+	if !hasDeck {
+		return nil, nil
+	}
+	if h.decks == nil {
+		return nil, ErrUnknownDeck
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), clusterTimeout)
+	defer cancel()
+	return h.decks.ResolveDecks(ctx, groups)
+}
@@ -0,0 +1,208 @@
+package game
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/google/uuid"
+)
+
+// ScoreEvent is one point of a player's recorded run: the score they had
+// submitted OffsetMs after the game started.
+type ScoreEvent struct {
+	OffsetMs int64 `json:"offsetMs"`
+	Score    int   `json:"score"`
+}
+
+// Mode is the kind of game a run was recorded in.
+type Mode string
+
+const (
+	ModeBattle    Mode = "battle"
+	ModePresenter Mode = "presenter"
+	ModeRaid      Mode = "raid"
+)
+
+// RecordedRun is a finished player's score stream, saved so others can race it.
+type RecordedRun struct {
+	UserID     uuid.UUID
+	RoomCode   string
+	Mode       Mode
+	Groups     []string
+	Direction  kana.Direction
+	Choices    int
+	Duration   int
+	FinalScore int
+	Events     []ScoreEvent
+
+	// 1-based; tied scores share a placement. Ghosts aren't opponents:
+	// racing one alone is a solo game, whatever the scores. Zero outside
+	// battles, where the players weren't racing each other.
+	Placement int
+	Players   int
+}
+
+// Ghost is a recorded run replayed as a participant inside a room.
+type Ghost struct {
+	ChallengeCode string
+	RunID         uuid.UUID
+	UserID        uuid.UUID
+	Username      string
+	Groups        []string
+	Direction     kana.Direction
+	Choices       int
+	Duration      int
+	Events        []ScoreEvent
+}
+
+// GhostResult is the outcome of racing a ghost, stored against both users.
+type GhostResult struct {
+	ChallengeCode   string
+	RunID           uuid.UUID
+	ChallengerID    uuid.UUID
+	ChallengerScore int
+	GhostUserID     uuid.UUID
+	GhostScore      int
+}
+
+// ResultStore receives finished games. The hub works without any.
+type ResultStore interface {
+	SaveRun(ctx context.Context, run RecordedRun) error
+	SaveGhostResult(ctx context.Context, result GhostResult) error
+}
+
+// replayGhost feeds the ghost's recorded scores into the room loop at the
+// same offsets they were originally submitted.
+func (r *Room) replayGhost(ghost *Ghost, startedAt, endTime time.Time) {
+	for _, ev := range ghost.Events {
+		at := startedAt.Add(time.Duration(ev.OffsetMs) * time.Millisecond)
+		if at.After(endTime) {
+			return
+		}
+		time.Sleep(time.Until(at))
+
+		score := ev.Score
+		action := func() {
+			if r.State != StatePlaying {
+				return
+			}
+			if p, ok := r.Players[ghost.UserID]; ok {
+				p.Score = score
+				r.broadcastScores()
+			}
+		}
+
+		select {
+		case r.action <- action:
+		case <-time.After(100 * time.Millisecond):
+			slog.Warn("Timeout replaying ghost, stopping", "room", r.Code)
+			return
+		}
+	}
+}
+
+// saveResults hands the finished game to the result stores. Called from the
+// Run loop; the writes happen in the background.
+func (r *Room) saveResults() {
+	stores := r.Hub.results
+	if len(stores) == 0 {
+		return
+	}
+
+	// Presenter games last as long as the host keeps asking, and raids
+	// end early when the board is cleared or mistakes run the clock down
+	duration := r.Duration
+	if r.Presenter || r.Raid {
+		duration = int(time.Since(r.StartedAt).Seconds())
+	}
+
+	mode := r.mode()
+
+	var runs []RecordedRun
+	for id, p := range r.Players {
+		if p.Ghost {
+			continue
+		}
+		var placement, players int
+		if mode == ModeBattle {
+			placement, players = r.rank(p)
+		}
+		runs = append(runs, RecordedRun{
+			UserID:     id,
+			RoomCode:   r.Code,
+			Mode:       mode,
+			Groups:     r.Groups,
+			Direction:  r.Direction,
+			Choices:    r.Choices,
+			Duration:   duration,
+			FinalScore: p.Score,
+			Events:     append([]ScoreEvent(nil), r.events[id]...),
+			Placement:  placement,
+			Players:    players,
+		})
+	}
+
+	var ghostResult *GhostResult
+	if r.Ghost != nil {
+		challenger, okC := r.Players[r.HostID]
+		ghost, okG := r.Players[r.Ghost.UserID]
+		if okC && okG {
+			ghostResult = &GhostResult{
+				ChallengeCode:   r.Ghost.ChallengeCode,
+				RunID:           r.Ghost.RunID,
+				ChallengerID:    r.HostID,
+				ChallengerScore: challenger.Score,
+				GhostUserID:     r.Ghost.UserID,
+				GhostScore:      ghost.Score,
+			}
+		}
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		for _, store := range stores {
+			for _, run := range runs {
+				if err := store.SaveRun(ctx, run); err != nil {
+					slog.Error("Error saving run", "error", err, "room", run.RoomCode, "user", run.UserID)
+				}
+			}
+			if ghostResult != nil {
+				if err := store.SaveGhostResult(ctx, *ghostResult); err != nil {
+					slog.Error("Error saving ghost result", "error", err, "challenge", ghostResult.ChallengeCode)
+				}
+			}
+		}
+	}()
+}
+
+// mode is the kind of game the room is playing.
+func (r *Room) mode() Mode {
+	switch {
+	case r.Presenter:
+		return ModePresenter
+	case r.Raid:
+		return ModeRaid
+	}
+	return ModeBattle
+}
+
+// rank places the player among the other people in the room. Ghosts are
+// left out so a recorded run, which can be raced again and again, never
+// pays out as a beaten opponent.
+func (r *Room) rank(p *Player) (placement, players int) {
+	placement = 1
+	for _, other := range r.Players {
+		if other.Ghost {
+			continue
+		}
+		players++
+		if other.Score > p.Score {
+			placement++
+		}
+	}
+	return placement, players
+}
@@ -0,0 +1,137 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockResultStore struct {
+	mu      sync.Mutex
+	runs    []RecordedRun
+	results []GhostResult
+	saved   chan struct{}
+}
+
+func (m *mockResultStore) SaveRun(ctx context.Context, run RecordedRun) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs = append(m.runs, run)
+	return nil
+}
+
+func (m *mockResultStore) SaveGhostResult(ctx context.Context, result GhostResult) error {
+	m.mu.Lock()
+	m.results = append(m.results, result)
+	m.mu.Unlock()
+	m.saved <- struct{}{}
+	return nil
+}
+
+// waitForRuns waits until the store has saved n runs and returns them.
+func (m *mockResultStore) waitForRuns(t *testing.T, n int) []RecordedRun {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		m.mu.Lock()
+		runs := append([]RecordedRun(nil), m.runs...)
+		m.mu.Unlock()
+		if len(runs) >= n {
+			return runs
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("Timeout waiting for %d saved runs", n)
+	return nil
+}
+
+func TestRoom_GhostRace(t *testing.T) {
+	hub := NewHub()
+	store := &mockResultStore{saved: make(chan struct{}, 1)}
+	hub.AddResultStore(store)
+
+	challengerID := uuid.New()
+	ghost := Ghost{
+		ChallengeCode: "GHOST123",
+		RunID:         uuid.New(),
+		UserID:        uuid.New(),
+		Username:      "GhostUser",
+		Groups:        []string{"hsingle"},
+		Duration:      1,
+		Events: []ScoreEvent{
+			{OffsetMs: 50, Score: 1},
+			{OffsetMs: 100, Score: 2},
+			{OffsetMs: 5000, Score: 99}, // past the end, never replayed
+		},
+	}
+	room := NewGhostRoom("GHOST1", hub, ghost, challengerID)
+	go room.Run()
+	defer func() { room.stopGame <- true }()
+
+	challenger := newMockClient(hub, challengerID, "Challenger")
+	room.register <- challenger
+	state := waitForType(t, challenger, "ROOM_STATE")
+	players, _ := state["players"].(map[string]interface{})
+	ghostState, _ := players[ghost.UserID.String()].(map[string]interface{})
+	if ghostState["ghost"] != true {
+		t.Fatalf("Expected ghost player in ROOM_STATE, got %v", players)
+	}
+
+	// Nobody else can join a ghost race
+	stranger := newMockClient(hub, uuid.New(), "Stranger")
+	room.register <- stranger
+	if msg := waitForType(t, stranger, "ERROR"); msg == nil {
+		t.Fatal("Expected stranger to be rejected")
+	}
+
+	startMsg, _ := json.Marshal(map[string]interface{}{"type": "START_GAME"})
+	room.handleRoomMessage(challenger, startMsg)
+	questions, _ := receiveQuestions(t, challenger)
+
+	// A one second game has two questions
+	for i, q := range questions {
+		room.handleRoomMessage(challenger, answerMsg(i, rightAnswer(t, q)))
+	}
+
+	// The ghost's recorded scores arrive on their own
+	deadline := time.After(time.Second)
+	for {
+		update := waitForType(t, challenger, "SCORE_UPDATE")
+		players, _ := update["players"].(map[string]interface{})
+		g, _ := players[ghost.UserID.String()].(map[string]interface{})
+		if g["score"] == float64(2) {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("Ghost never reached its recorded score")
+		default:
+		}
+	}
+
+	waitForType(t, challenger, "GAME_OVER")
+
+	select {
+	case <-store.saved:
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for results to be saved")
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	// The ghost isn't an opponent, so the run counts as a solo game
+	if len(store.runs) != 1 || store.runs[0].UserID != challengerID {
+		t.Fatalf("Expected only the challenger's run to be saved, got %+v", store.runs)
+	}
+	if run := store.runs[0]; run.FinalScore != 2 || len(run.Events) != 2 || run.Placement != 1 || run.Players != 1 || run.Mode != ModeBattle {
+		t.Errorf("Unexpected recorded run: %+v", run)
+	}
+	result := store.results[0]
+	if result.ChallengerScore != 2 || result.GhostScore != 2 || result.GhostUserID != ghost.UserID {
+		t.Errorf("Unexpected ghost result: %+v", result)
+	}
+}
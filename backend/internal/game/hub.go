@@ -1,14 +1,33 @@
 package game
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/Cadimodev/haiji/backend/internal/cluster"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/quiz"
+	"github.com/Cadimodev/haiji/backend/internal/romaji"
 	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
 )
 
+// Attempts to find a free room code before giving up.
+const maxCodeAttempts = 5
+
+// Timeout for directory and bus calls made on behalf of a client.
+const clusterTimeout = 2 * time.Second
+
+// How long a remote join waits for the owner instance to answer before the
+// client is told the room isn't responding.
+const remoteJoinTimeout = 5 * time.Second
+
 type Hub struct {
 	// Registered clients
 	clients map[*Client]bool
@@ -27,44 +46,230 @@ type Hub struct {
 
 	// Unregister requests from clients.
 	unregister chan *Client
+
+	// Cluster: rooms may live on another instance
+	instanceID string
+	directory  cluster.Directory
+	bus        cluster.Bus
+	inbound    <-chan cluster.Message
+	relayQueue chan cluster.Message
+
+	// Local clients by ID, for payloads relayed from other instances.
+	// Only touched from the Run loop.
+	clientsByID map[string]*Client
+
+	// Stand-ins for clients connected to other instances: clientID -> remote
+	remoteMu sync.Mutex
+	remotes  map[string]*remoteClient
+
+	// Remote joins waiting for the owner to answer: clientID -> chan struct{}
+	joinAcks    sync.Map
+	joinTimeout time.Duration
+
+	upgrader websocket.Upgrader
+
+	// Where finished games are recorded, if anywhere
+	results []ResultStore
+
+	// Messages for every local client of a user
+	notify chan userMessage
+
+	// Where "deck:CODE" groups are looked up, if anywhere
+	decks DeckResolver
+
+	// Where standings are kept for sharing, if anywhere
+	shares ShareStore
+}
+
+type userMessage struct {
+	userID  uuid.UUID
+	payload []byte
+}
+
+// remoteClient mirrors a client connected to another instance inside one of
+// this instance's rooms. Everything the room sends to it is relayed back.
+type remoteClient struct {
+	client *Client
+	room   *Room
+	origin string
+	done   chan struct{}
 }
 
+// NewHub returns a standalone hub whose rooms are only reachable from this
+// process.
 func NewHub() *Hub {
+	hub, err := NewClusteredHub(uuid.New().String()[:8], cluster.NewMemoryDirectory(), cluster.NewMemoryBus())
+	if err != nil {
+		// The in-memory bus can't fail to subscribe
+		panic(err)
+	}
+	return hub
+}
+
+// NewClusteredHub returns a hub that registers its rooms in the directory and
+// relays room traffic to and from other instances through the bus.
+func NewClusteredHub(instanceID string, directory cluster.Directory, bus cluster.Bus) (*Hub, error) {
+	inbound, err := bus.Subscribe(instanceID)
+	if err != nil {
+		return nil, err
+	}
 	return &Hub{
-		broadcast:  make(chan []byte),
-		register:   make(chan *Client),
-		unregister: make(chan *Client),
-		clients:    make(map[*Client]bool),
-		rooms:      make(map[string]*Room),
+		broadcast:   make(chan []byte),
+		register:    make(chan *Client),
+		unregister:  make(chan *Client),
+		clients:     make(map[*Client]bool),
+		rooms:       make(map[string]*Room),
+		instanceID:  instanceID,
+		directory:   directory,
+		bus:         bus,
+		inbound:     inbound,
+		relayQueue:  make(chan cluster.Message, 256),
+		clientsByID: make(map[string]*Client),
+		remotes:     make(map[string]*remoteClient),
+		notify:      make(chan userMessage, 64),
+		joinTimeout: remoteJoinTimeout,
+		// Same-origin only until AllowOrigins is called
+		upgrader: newUpgrader(nil),
+	}, nil
+}
+
+// AllowOrigins sets the browser origins allowed to open game websockets.
+// Must be called before serving connections.
+func (h *Hub) AllowOrigins(origins ...string) {
+	h.upgrader = newUpgrader(OriginChecker(origins))
+}
+
+// AddResultStore records finished games in store, after any stores added
+// before it. Must be called before Run.
+func (h *Hub) AddResultStore(store ResultStore) {
+	h.results = append(h.results, store)
+}
+
+// NotifyUser sends payload to every websocket the user has open, on this
+// instance and, through the bus, on the others.
+func (h *Hub) NotifyUser(userID uuid.UUID, payload []byte) {
+	select {
+	case h.notify <- userMessage{userID: userID, payload: payload}:
+	default:
+		slog.Warn("Notification queue full, dropping message", "user", userID)
 	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), clusterTimeout)
+		defer cancel()
+		msg := cluster.Message{Type: cluster.TypeNotify, From: h.instanceID, UserID: userID, Payload: payload}
+		if err := h.bus.Broadcast(ctx, msg); err != nil {
+			slog.Error("Error broadcasting notification", "error", err, "user", userID)
+		}
+	}()
+}
+
+func (h *Hub) InstanceID() string {
+	return h.instanceID
+}
+
+// Shutdown releases every room owned by this instance from the directory.
+func (h *Hub) Shutdown(ctx context.Context) error {
+	return h.directory.ReleaseAll(ctx, h.instanceID)
 }
 
 func (h *Hub) Run() {
+	// Relayed room traffic is handled in order, off the hub loop
+	go func() {
+		for msg := range h.relayQueue {
+			h.handleRelay(msg)
+		}
+	}()
+	go h.renewRooms()
+
 	for {
 		select {
 		case client := <-h.register:
 			h.clients[client] = true
+			h.clientsByID[client.ID] = client
 		case client := <-h.unregister:
 			if _, ok := h.clients[client]; ok {
 				delete(h.clients, client)
+				delete(h.clientsByID, client.ID)
 				close(client.Send)
 				if client.Room != nil {
 					client.Room.unregister <- client
 				}
+				if remote := client.remote.Load(); remote != nil {
+					go h.publish(remote.owner, cluster.Message{
+						Type:     cluster.TypeLeave,
+						Room:     remote.code,
+						ClientID: client.ID,
+					})
+				}
+			}
+		case msg, ok := <-h.inbound:
+			if !ok {
+				h.inbound = nil
+				continue
+			}
+			if msg.Type == cluster.TypeDeliver {
+				if ack, ok := h.joinAcks.LoadAndDelete(msg.ClientID); ok {
+					close(ack.(chan struct{}))
+				}
+				// Delivered inline so Send can't be closed concurrently
+				if client, ok := h.clientsByID[msg.ClientID]; ok {
+					select {
+					case client.Send <- msg.Payload:
+					default:
+						slog.Warn("Dropping relayed message for slow client", "user", client.Username)
+					}
+				}
+				continue
+			}
+			if msg.Type == cluster.TypeDetach {
+				// Only if the client hasn't moved on to another room since
+				if client, ok := h.clientsByID[msg.ClientID]; ok {
+					if remote := client.remote.Load(); remote != nil && remote.code == msg.Room {
+						client.remote.CompareAndSwap(remote, nil)
+					}
+				}
+				continue
+			}
+			if msg.Type == cluster.TypeNotify {
+				// Our own broadcasts were already delivered locally
+				if msg.From != h.instanceID {
+					h.notifyLocal(msg.UserID, msg.Payload)
+				}
+				continue
 			}
+			h.relayQueue <- msg
+		case msg := <-h.notify:
+			h.notifyLocal(msg.userID, msg.payload)
 		case message := <-h.broadcast:
 			for client := range h.clients {
 				select {
 				case client.Send <- message:
 				default:
-					close(client.Send)
-					delete(h.clients, client)
+					// Closing Send here would leave it in clientsByID and
+					// its room, which still write to it
+					slog.Warn("Dropping broadcast for slow client", "user", client.Username)
 				}
 			}
 		}
 	}
 }
 
+// notifyLocal writes payload to the user's websockets on this instance.
+// Only called from the Run loop.
+func (h *Hub) notifyLocal(userID uuid.UUID, payload []byte) {
+	for client := range h.clients {
+		if client.UserID != userID {
+			continue
+		}
+		select {
+		case client.Send <- payload:
+		default:
+			slog.Warn("Dropping notification for slow client", "user", client.Username)
+		}
+	}
+}
+
 func (h *Hub) handleMessage(c *Client, msg []byte) {
 	var base struct {
 		Type string `json:"type"`
@@ -84,31 +289,190 @@ func (h *Hub) handleMessage(c *Client, msg []byte) {
 		// Forward to room
 		if c.Room != nil {
 			c.Room.handleRoomMessage(c, msg)
+		} else if remote := c.remote.Load(); remote != nil {
+			h.publish(remote.owner, cluster.Message{
+				Type:     cluster.TypeClientMessage,
+				Room:     remote.code,
+				ClientID: c.ID,
+				Payload:  msg,
+			})
 		}
 	}
 }
 
-func (h *Hub) CreateRoom(duration int, groups []string, hostID uuid.UUID) string {
-	code := uuid.New().String()[:6]
-	code = strings.ToUpper(code)
-	room := NewRoom(code, h, duration, groups, hostID)
-	h.mu.Lock()
-	h.rooms[code] = room
-	h.mu.Unlock()
-	go room.Run()
-	return code
+// RoomOptions configure a new room.
+type RoomOptions struct {
+	Duration  int // seconds
+	Groups    []string
+	Direction kana.Direction
+	// Multiple-choice prompts with this many choices; 0 means typed answers
+	Choices int
+	// If set, only these users and the host may join
+	Members []uuid.UUID
+	// A live quiz the host runs without playing; Duration is unused
+	Presenter       bool
+	QuestionSeconds int
+	// A co-op game clearing a shared board of BoardSize tiles
+	Raid      bool
+	BoardSize int
+}
+
+// Game length in seconds. Presenter games last until the host ends them.
+const (
+	MinDuration = 30
+	MaxDuration = 600
+)
+
+// ErrInvalidChoices is returned for a choice count outside quiz.MinChoices
+// to quiz.MaxChoices.
+var ErrInvalidChoices = fmt.Errorf("choices must be 0 or between %d and %d", quiz.MinChoices, quiz.MaxChoices)
+
+var ErrInvalidDuration = fmt.Errorf("duration must be between %d and %d seconds", MinDuration, MaxDuration)
+
+// CreateRoom validates the options and starts the room. Rooms created over
+// HTTP and over the websocket both come through here.
+func (h *Hub) CreateRoom(opts RoomOptions, hostID uuid.UUID) (string, error) {
+	if !opts.Presenter && (opts.Duration < MinDuration || opts.Duration > MaxDuration) {
+		return "", ErrInvalidDuration
+	}
+	if opts.Choices != 0 && (opts.Choices < quiz.MinChoices || opts.Choices > quiz.MaxChoices) {
+		return "", ErrInvalidChoices
+	}
+	if opts.Presenter && opts.QuestionSeconds == 0 {
+		opts.QuestionSeconds = DefaultQuestionSeconds
+	}
+	if opts.Presenter && (opts.QuestionSeconds < MinQuestionSeconds || opts.QuestionSeconds > MaxQuestionSeconds) {
+		return "", ErrInvalidQuestionSeconds
+	}
+	if opts.Presenter && opts.Raid {
+		return "", ErrConflictingModes
+	}
+	if opts.Raid && opts.BoardSize == 0 {
+		opts.BoardSize = DefaultBoardSize
+	}
+	if opts.Raid && (opts.BoardSize < MinBoardSize || opts.BoardSize > MaxBoardSize) {
+		return "", ErrInvalidBoardSize
+	}
+	decks, err := h.resolveDecks(opts.Groups)
+	if err != nil {
+		return "", err
+	}
+	return h.createRoom(func(code string) *Room {
+		r := NewRoom(code, h, opts.Duration, opts.Groups, hostID)
+		r.Decks = decks
+		r.Direction = opts.Direction
+		r.Choices = opts.Choices
+		r.Presenter = opts.Presenter
+		r.QuestionSeconds = opts.QuestionSeconds
+		r.Raid = opts.Raid
+		r.BoardSize = opts.BoardSize
+		if opts.Members != nil {
+			r.Members = make(map[uuid.UUID]bool, len(opts.Members))
+			for _, id := range opts.Members {
+				r.Members[id] = true
+			}
+		}
+		return r
+	})
+}
+
+// CreateGhostRoom creates a private room where the challenger races a
+// recorded run with the same configuration.
+func (h *Hub) CreateGhostRoom(ghost Ghost, challengerID uuid.UUID) (string, error) {
+	decks, err := h.resolveDecks(ghost.Groups)
+	if err != nil {
+		return "", err
+	}
+	return h.createRoom(func(code string) *Room {
+		r := NewGhostRoom(code, h, ghost, challengerID)
+		r.Decks = decks
+		return r
+	})
+}
+
+// createRoom claims a free code in the directory and starts the room built
+// for it.
+func (h *Hub) createRoom(build func(code string) *Room) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), clusterTimeout)
+	defer cancel()
+
+	for i := 0; i < maxCodeAttempts; i++ {
+		code := uuid.New().String()[:6]
+		code = strings.ToUpper(code)
+
+		err := h.directory.Claim(ctx, code, h.instanceID)
+		if errors.Is(err, cluster.ErrRoomClaimed) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("claim room code: %w", err)
+		}
+
+		room := build(code)
+		h.mu.Lock()
+		h.rooms[code] = room
+		h.mu.Unlock()
+		go room.Run()
+		return code, nil
+	}
+	return "", errors.New("couldn't find a free room code")
 }
 
 func (h *Hub) handleCreateRoom(c *Client, msg []byte) {
 	var payload struct {
-		Duration int      `json:"duration"`
-		Groups   []string `json:"groups"`
+		Duration        int      `json:"duration"`
+		Groups          []string `json:"groups"`
+		Direction       string   `json:"direction"`
+		Choices         int      `json:"choices"`
+		Presenter       bool     `json:"presenter"`
+		QuestionSeconds int      `json:"question_seconds"`
+		Raid            bool     `json:"raid"`
+		BoardSize       int      `json:"board_size"`
 	}
 	if err := json.Unmarshal(msg, &payload); err != nil {
 		return
 	}
+	direction, err := kana.ParseDirection(payload.Direction)
+	if err != nil {
+		c.Send <- []byte(`{"type":"ERROR", "message":"Unknown direction"}`)
+		return
+	}
 
-	code := h.CreateRoom(payload.Duration, payload.Groups, c.UserID)
+	code, err := h.CreateRoom(RoomOptions{
+		Duration:        payload.Duration,
+		Groups:          payload.Groups,
+		Direction:       direction,
+		Choices:         payload.Choices,
+		Presenter:       payload.Presenter,
+		QuestionSeconds: payload.QuestionSeconds,
+		Raid:            payload.Raid,
+		BoardSize:       payload.BoardSize,
+	}, c.UserID)
+	if errors.Is(err, ErrUnknownDeck) {
+		c.Send <- []byte(`{"type":"ERROR", "message":"Unknown deck"}`)
+		return
+	}
+	if errors.Is(err, ErrInvalidDuration) {
+		c.Send <- []byte(`{"type":"ERROR", "message":"Invalid duration"}`)
+		return
+	}
+	if errors.Is(err, ErrInvalidChoices) {
+		c.Send <- []byte(`{"type":"ERROR", "message":"Invalid choices"}`)
+		return
+	}
+	if errors.Is(err, ErrInvalidQuestionSeconds) {
+		c.Send <- []byte(`{"type":"ERROR", "message":"Invalid question seconds"}`)
+		return
+	}
+	if errors.Is(err, ErrInvalidBoardSize) || errors.Is(err, ErrConflictingModes) {
+		c.Send <- []byte(`{"type":"ERROR", "message":"Invalid raid options"}`)
+		return
+	}
+	if err != nil {
+		slog.Error("Error creating room", "error", err, "user", c.Username)
+		c.Send <- []byte(`{"type":"ERROR", "message":"Couldn't create room"}`)
+		return
+	}
 
 	h.mu.RLock()
 	r, ok := h.rooms[code]
@@ -134,6 +498,9 @@ func (h *Hub) handleJoinRoom(c *Client, msg []byte) {
 	h.mu.RUnlock()
 
 	if !ok {
+		if h.joinRemoteRoom(c, payload.Code) {
+			return
+		}
 		slog.Info("Room not found for join request", "room", payload.Code, "user", c.Username)
 		c.Send <- []byte(`{"type":"ERROR", "message":"Room not found"}`)
 		return
@@ -152,4 +519,228 @@ func (h *Hub) closeRoom(code string) {
 	h.mu.Lock()
 	delete(h.rooms, code)
 	h.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), clusterTimeout)
+	defer cancel()
+	if err := h.directory.Release(ctx, code); err != nil {
+		slog.Error("Error releasing room code", "error", err, "room", code)
+	}
+
+	// Drop stand-ins for remote clients that were in this room
+	h.remoteMu.Lock()
+	var detached []*remoteClient
+	for id, rc := range h.remotes {
+		if rc.room.Code == code {
+			close(rc.done)
+			delete(h.remotes, id)
+			detached = append(detached, rc)
+		}
+	}
+	h.remoteMu.Unlock()
+
+	// So their instances stop relaying to a room that's gone
+	for _, rc := range detached {
+		h.publish(rc.origin, cluster.Message{Type: cluster.TypeDetach, Room: code, ClientID: rc.client.ID})
+	}
+}
+
+// joinRemoteRoom looks the code up in the directory and, if another instance
+// owns the room, asks it to mirror this client into the room.
+func (h *Hub) joinRemoteRoom(c *Client, code string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), clusterTimeout)
+	defer cancel()
+
+	owner, err := h.directory.Owner(ctx, code)
+	if err != nil {
+		if !errors.Is(err, cluster.ErrRoomNotFound) {
+			slog.Error("Error looking up room owner", "error", err, "room", code)
+		}
+		return false
+	}
+	if owner == h.instanceID {
+		// Claimed by us but already gone
+		return false
+	}
+
+	slog.Info("Joining client to remote room", "user", c.Username, "room", code, "owner", owner)
+	if previous := c.remote.Swap(&remoteRoom{code: code, owner: owner}); previous != nil {
+		h.publish(previous.owner, cluster.Message{Type: cluster.TypeLeave, Room: previous.code, ClientID: c.ID})
+	}
+	ack := make(chan struct{})
+	h.joinAcks.Store(c.ID, ack)
+	h.publish(owner, cluster.Message{
+		Type:      cluster.TypeJoin,
+		Room:      code,
+		ClientID:  c.ID,
+		UserID:    c.UserID,
+		Username:  c.Username,
+		Cosmetics: c.Cosmetics,
+
+		RomajiSystems: systemNames(c.RomajiSystems),
+	})
+	go h.awaitJoin(c.ID, code, owner, ack)
+	return true
+}
+
+// systemNames lists the systems by name, to send them over the bus.
+func systemNames(systems []romaji.System) []string {
+	names := make([]string, len(systems))
+	for i, s := range systems {
+		names[i] = string(s)
+	}
+	return names
+}
+
+// awaitJoin tells the client the room isn't responding if its owner doesn't
+// answer the join, e.g. because the owner instance died holding the claim.
+func (h *Hub) awaitJoin(clientID, code, owner string, ack chan struct{}) {
+	select {
+	case <-ack:
+		return
+	case <-time.After(h.joinTimeout):
+	}
+	// A later join by the same client replaces the ack; leave that one be
+	if !h.joinAcks.CompareAndDelete(clientID, ack) {
+		return
+	}
+	slog.Warn("Room owner didn't answer join", "room", code, "owner", owner)
+	payload, _ := json.Marshal(map[string]string{"type": "ERROR", "message": "Room is not responding"})
+	// Delivered through our own inbound queue so it reaches the client from
+	// the Run loop like any other relayed payload
+	h.publish(h.instanceID, cluster.Message{Type: cluster.TypeDeliver, Room: code, ClientID: clientID, Payload: payload})
+	h.publish(h.instanceID, cluster.Message{Type: cluster.TypeDetach, Room: code, ClientID: clientID})
+}
+
+// renewRooms keeps this instance's room claims alive in the directory.
+func (h *Hub) renewRooms() {
+	ticker := time.NewTicker(cluster.RoomLease / 3)
+	defer ticker.Stop()
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), clusterTimeout)
+		if err := h.directory.Renew(ctx, h.instanceID); err != nil {
+			slog.Error("Error renewing room claims", "error", err)
+		}
+		cancel()
+	}
+}
+
+func (h *Hub) publish(instanceID string, msg cluster.Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), clusterTimeout)
+	defer cancel()
+
+	msg.From = h.instanceID
+	if err := h.bus.Publish(ctx, instanceID, msg); err != nil {
+		slog.Error("Error publishing cluster message", "error", err, "type", msg.Type, "to", instanceID)
+	}
+}
+
+// handleRelay processes messages sent by other instances about rooms owned
+// by this one.
+func (h *Hub) handleRelay(msg cluster.Message) {
+	switch msg.Type {
+	case cluster.TypeJoin:
+		h.handleRemoteJoin(msg)
+
+	case cluster.TypeClientMessage:
+		h.remoteMu.Lock()
+		rc, ok := h.remotes[msg.ClientID]
+		h.remoteMu.Unlock()
+		if ok {
+			rc.room.handleRoomMessage(rc.client, msg.Payload)
+		}
+
+	case cluster.TypeLeave:
+		h.remoteMu.Lock()
+		rc, ok := h.remotes[msg.ClientID]
+		if ok {
+			delete(h.remotes, msg.ClientID)
+			close(rc.done)
+		}
+		h.remoteMu.Unlock()
+		if ok {
+			select {
+			case rc.room.unregister <- rc.client:
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+	}
+}
+
+func (h *Hub) handleRemoteJoin(msg cluster.Message) {
+	reject := func(reason string) {
+		payload, _ := json.Marshal(map[string]string{"type": "ERROR", "message": reason})
+		h.publish(msg.From, cluster.Message{Type: cluster.TypeDeliver, ClientID: msg.ClientID, Payload: payload})
+		h.publish(msg.From, cluster.Message{Type: cluster.TypeDetach, Room: msg.Room, ClientID: msg.ClientID})
+	}
+
+	h.mu.RLock()
+	room, ok := h.rooms[msg.Room]
+	h.mu.RUnlock()
+	if !ok {
+		reject("Room not found")
+		return
+	}
+	if room.State != StateWaiting {
+		reject("Game already in progress")
+		return
+	}
+
+	// Unknown names were dropped on the other side; grade in any system then
+	systems, _ := romaji.ParseSystems(msg.RomajiSystems)
+	rc := &remoteClient{
+		client: &Client{
+			ID:        msg.ClientID,
+			Hub:       h,
+			Send:      make(chan []byte, 256),
+			UserID:    msg.UserID,
+			Username:  msg.Username,
+			Cosmetics: msg.Cosmetics,
+
+			RomajiSystems: systems,
+		},
+		room:   room,
+		origin: msg.From,
+		done:   make(chan struct{}),
+	}
+
+	h.remoteMu.Lock()
+	h.remotes[msg.ClientID] = rc
+	h.remoteMu.Unlock()
+
+	go h.forwardRemote(rc)
+
+	select {
+	case room.register <- rc.client:
+	case <-time.After(100 * time.Millisecond):
+		slog.Warn("Timeout registering remote client", "room", msg.Room, "user", msg.Username)
+		// The room never took the client in; a leave may have beaten us here
+		h.remoteMu.Lock()
+		if h.remotes[msg.ClientID] == rc {
+			delete(h.remotes, msg.ClientID)
+			close(rc.done)
+		}
+		h.remoteMu.Unlock()
+		reject("Room is not responding")
+	}
+}
+
+// forwardRemote relays everything the room sends to a stand-in client back
+// to the instance holding the real websocket.
+func (h *Hub) forwardRemote(rc *remoteClient) {
+	for {
+		select {
+		case payload, ok := <-rc.client.Send:
+			if !ok {
+				return
+			}
+			h.publish(rc.origin, cluster.Message{
+				Type:     cluster.TypeDeliver,
+				Room:     rc.room.Code,
+				ClientID: rc.client.ID,
+				Payload:  payload,
+			})
+		case <-rc.done:
+			return
+		}
+	}
 }
@@ -0,0 +1,535 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/cluster"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/romaji"
+	"github.com/google/uuid"
+)
+
+// waitForType drains the client's queue until a message of the given type arrives
+func waitForType(t *testing.T, c *Client, msgType string) map[string]interface{} {
+	t.Helper()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case msg := <-c.Send:
+			var parsed map[string]interface{}
+			json.Unmarshal(msg, &parsed)
+			if parsed["type"] == msgType {
+				return parsed
+			}
+		case <-timeout:
+			t.Fatalf("Timeout waiting for %s", msgType)
+			return nil
+		}
+	}
+}
+
+func newClusterPair(t *testing.T) (*Hub, *Hub) {
+	t.Helper()
+	directory := cluster.NewMemoryDirectory()
+	bus := cluster.NewMemoryBus()
+
+	hubA, err := NewClusteredHub("node-a", directory, bus)
+	if err != nil {
+		t.Fatalf("NewClusteredHub: %v", err)
+	}
+	hubB, err := NewClusteredHub("node-b", directory, bus)
+	if err != nil {
+		t.Fatalf("NewClusteredHub: %v", err)
+	}
+	go hubA.Run()
+	go hubB.Run()
+	return hubA, hubB
+}
+
+func TestHub_JoinRoomOnOtherInstance(t *testing.T) {
+	hubA, hubB := newClusterPair(t)
+
+	hostID := uuid.New()
+	host := newMockClient(hubA, hostID, "HostUser")
+	host.ID = "host"
+	hubA.register <- host
+
+	createMsg, _ := json.Marshal(map[string]interface{}{"type": "CREATE_ROOM", "duration": 60, "groups": []string{"hsingle"}})
+	hubA.handleMessage(host, createMsg)
+	waitForType(t, host, "ROOM_STATE")
+
+	hubA.mu.RLock()
+	var code string
+	for c := range hubA.rooms {
+		code = c
+	}
+	hubA.mu.RUnlock()
+
+	// Guest connects to the other instance
+	guest := newMockClient(hubB, uuid.New(), "GuestUser")
+	guest.ID = "guest"
+	guest.RomajiSystems = []romaji.System{romaji.Kunrei}
+	hubB.register <- guest
+
+	joinMsg, _ := json.Marshal(map[string]interface{}{"type": "JOIN_ROOM", "code": code})
+	hubB.handleMessage(guest, joinMsg)
+
+	state := waitForType(t, guest, "ROOM_STATE")
+	players, _ := state["players"].(map[string]interface{})
+	if len(players) != 2 {
+		t.Fatalf("Expected 2 players in relayed ROOM_STATE, got %d", len(players))
+	}
+
+	// The guest's answers are graded in their systems on A too
+	hubA.mu.RLock()
+	room := hubA.rooms[code]
+	hubA.mu.RUnlock()
+	systems := make(chan []romaji.System)
+	room.action <- func() { systems <- room.Players[guest.UserID].systems }
+	if got := <-systems; !reflect.DeepEqual(got, guest.RomajiSystems) {
+		t.Errorf("Expected the guest's systems on the owner, got %v", got)
+	}
+
+	// Host starts on A, guest sees it on B
+	startMsg, _ := json.Marshal(map[string]interface{}{"type": "START_GAME"})
+	hubA.handleMessage(host, startMsg)
+	questions, _ := receiveQuestions(t, guest)
+
+	// Guest answers through B, host sees the score on A
+	hubB.handleMessage(guest, answerMsg(0, rightAnswer(t, questions[0])))
+	update := waitForType(t, host, "SCORE_UPDATE")
+	players, _ = update["players"].(map[string]interface{})
+	guestState, _ := players[guest.UserID.String()].(map[string]interface{})
+	if guestState["score"] != float64(1) {
+		t.Errorf("Expected relayed score 1, got %v", guestState["score"])
+	}
+
+	// Guest disconnects from B, the stand-in leaves the room on A
+	hubB.unregister <- guest
+	time.Sleep(50 * time.Millisecond)
+
+	if vals := room.GetValues(); vals.Clients != 1 {
+		t.Errorf("Expected 1 client after remote leave, got %d", vals.Clients)
+	}
+}
+
+func TestHub_JoinUnknownRoomAcrossInstances(t *testing.T) {
+	_, hubB := newClusterPair(t)
+
+	guest := newMockClient(hubB, uuid.New(), "GuestUser")
+	guest.ID = "guest"
+	hubB.register <- guest
+
+	joinMsg, _ := json.Marshal(map[string]interface{}{"type": "JOIN_ROOM", "code": "NOPE42"})
+	hubB.handleMessage(guest, joinMsg)
+
+	errMsg := waitForType(t, guest, "ERROR")
+	if errMsg["message"] != "Room not found" {
+		t.Errorf("Expected 'Room not found', got %v", errMsg["message"])
+	}
+}
+
+func TestHub_JoinRoomOfDeadInstance(t *testing.T) {
+	_, hubB := newClusterPair(t)
+	hubB.joinTimeout = 100 * time.Millisecond
+
+	// Claimed by an instance that died without releasing it
+	if err := hubB.directory.Claim(context.Background(), "DEAD42", "node-dead"); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+
+	guest := newMockClient(hubB, uuid.New(), "GuestUser")
+	guest.ID = "guest"
+	hubB.register <- guest
+
+	joinMsg, _ := json.Marshal(map[string]interface{}{"type": "JOIN_ROOM", "code": "DEAD42"})
+	hubB.handleMessage(guest, joinMsg)
+
+	errMsg := waitForType(t, guest, "ERROR")
+	if errMsg["message"] != "Room is not responding" {
+		t.Errorf("Expected 'Room is not responding', got %v", errMsg["message"])
+	}
+}
+
+// waitForDetach waits until the client no longer relays to a remote room
+func waitForDetach(t *testing.T, c *Client) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for c.remote.Load() != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the client to be detached from the remote room")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHub_RemoteJoinTimesOutRegistering(t *testing.T) {
+	hubA, hubB := newClusterPair(t)
+
+	// A room on A whose loop never takes anyone in
+	room := NewRoom("STUCK1", hubA, 60, []string{"hsingle"}, uuid.New())
+	hubA.mu.Lock()
+	hubA.rooms[room.Code] = room
+	hubA.mu.Unlock()
+	if err := hubA.directory.Claim(context.Background(), room.Code, hubA.instanceID); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+
+	guest := newMockClient(hubB, uuid.New(), "GuestUser")
+	guest.ID = "guest"
+	hubB.register <- guest
+
+	joinMsg, _ := json.Marshal(map[string]interface{}{"type": "JOIN_ROOM", "code": room.Code})
+	hubB.handleMessage(guest, joinMsg)
+
+	errMsg := waitForType(t, guest, "ERROR")
+	if errMsg["message"] != "Room is not responding" {
+		t.Errorf("Expected 'Room is not responding', got %v", errMsg["message"])
+	}
+	waitForDetach(t, guest)
+
+	hubA.remoteMu.Lock()
+	defer hubA.remoteMu.Unlock()
+	if _, ok := hubA.remotes[guest.ID]; ok {
+		t.Error("Expected the stand-in dropped after the register timeout")
+	}
+}
+
+func TestHub_RemoteRoomClosedDetachesClient(t *testing.T) {
+	hubA, hubB := newClusterPair(t)
+
+	host := newMockClient(hubA, uuid.New(), "HostUser")
+	host.ID = "host"
+	hubA.register <- host
+
+	createMsg, _ := json.Marshal(map[string]interface{}{"type": "CREATE_ROOM", "duration": 60, "groups": []string{"hsingle"}})
+	hubA.handleMessage(host, createMsg)
+	waitForType(t, host, "ROOM_STATE")
+
+	hubA.mu.RLock()
+	var code string
+	for c := range hubA.rooms {
+		code = c
+	}
+	hubA.mu.RUnlock()
+
+	guest := newMockClient(hubB, uuid.New(), "GuestUser")
+	guest.ID = "guest"
+	hubB.register <- guest
+
+	joinMsg, _ := json.Marshal(map[string]interface{}{"type": "JOIN_ROOM", "code": code})
+	hubB.handleMessage(guest, joinMsg)
+	waitForType(t, guest, "ROOM_STATE")
+
+	hubA.closeRoom(code)
+	waitForDetach(t, guest)
+}
+
+func TestHub_CreateRoomClaimsDirectory(t *testing.T) {
+	directory := cluster.NewMemoryDirectory()
+	hub, err := NewClusteredHub("node-a", directory, cluster.NewMemoryBus())
+	if err != nil {
+		t.Fatalf("NewClusteredHub: %v", err)
+	}
+
+	code, err := hub.CreateRoom(RoomOptions{Duration: 60, Groups: []string{"hsingle"}}, uuid.New())
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+
+	owner, err := directory.Owner(t.Context(), code)
+	if err != nil || owner != "node-a" {
+		t.Errorf("Expected room owned by node-a, got %q (%v)", owner, err)
+	}
+
+	hub.closeRoom(code)
+	if _, err := directory.Owner(t.Context(), code); err != cluster.ErrRoomNotFound {
+		t.Errorf("Expected room released after close, got %v", err)
+	}
+}
+
+func TestHub_CreateRoomValidatesDuration(t *testing.T) {
+	hub := NewHub()
+	groups := []string{"hsingle"}
+
+	for _, d := range []int{0, MinDuration - 1, MaxDuration + 1} {
+		if _, err := hub.CreateRoom(RoomOptions{Duration: d, Groups: groups}, uuid.New()); !errors.Is(err, ErrInvalidDuration) {
+			t.Errorf("Duration %d: expected ErrInvalidDuration, got %v", d, err)
+		}
+	}
+	// A presenter game lasts until the host ends it
+	if _, err := hub.CreateRoom(RoomOptions{Groups: groups, Presenter: true}, uuid.New()); err != nil {
+		t.Errorf("Presenter room without a duration: %v", err)
+	}
+
+	// The websocket path gets the same check
+	host := newMockClient(hub, uuid.New(), "Host")
+	msg, _ := json.Marshal(map[string]interface{}{"type": "CREATE_ROOM", "duration": 0, "groups": groups})
+	hub.handleCreateRoom(host, msg)
+	if errMsg := waitForType(t, host, "ERROR"); errMsg["message"] != "Invalid duration" {
+		t.Errorf("Expected 'Invalid duration', got %v", errMsg["message"])
+	}
+}
+
+func TestHub_NotifyUser(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	userID := uuid.New()
+	tab1 := newMockClient(hub, userID, "User")
+	tab2 := newMockClient(hub, userID, "User")
+	other := newMockClient(hub, uuid.New(), "Other")
+	hub.register <- tab1
+	hub.register <- tab2
+	hub.register <- other
+
+	hub.NotifyUser(userID, []byte(`{"type":"ACHIEVEMENT_UNLOCKED"}`))
+
+	waitForType(t, tab1, "ACHIEVEMENT_UNLOCKED")
+	waitForType(t, tab2, "ACHIEVEMENT_UNLOCKED")
+	select {
+	case msg := <-other.Send:
+		t.Errorf("Other user received %s", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHub_NotifyUserOnOtherInstance(t *testing.T) {
+	hubA, hubB := newClusterPair(t)
+
+	userID := uuid.New()
+	local := newMockClient(hubA, userID, "User")
+	remote := newMockClient(hubB, userID, "User")
+	hubA.register <- local
+	hubB.register <- remote
+
+	hubA.NotifyUser(userID, []byte(`{"type":"LEVEL_UP"}`))
+
+	waitForType(t, remote, "LEVEL_UP")
+	waitForType(t, local, "LEVEL_UP")
+	// The sender's own broadcast isn't delivered a second time
+	select {
+	case msg := <-local.Send:
+		t.Errorf("Local client received a duplicate: %s", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+type mockDeckResolver map[string][]kana.Char
+
+func (m mockDeckResolver) ResolveDecks(ctx context.Context, groups []string) (map[string][]kana.Char, error) {
+	decks := make(map[string][]kana.Char)
+	for _, g := range groups {
+		if _, ok := kana.DeckCode(g); !ok {
+			continue
+		}
+		entries, ok := m[g]
+		if !ok {
+			return nil, ErrUnknownDeck
+		}
+		decks[g] = entries
+	}
+	return decks, nil
+}
+
+func TestHub_CreateRoomWithDeck(t *testing.T) {
+	hub := NewHub()
+	groups := []string{"hsingle", "deck:ABC123"}
+
+	if _, err := hub.CreateRoom(RoomOptions{Duration: 60, Groups: groups}, uuid.New()); !errors.Is(err, ErrUnknownDeck) {
+		t.Fatalf("Expected ErrUnknownDeck without a resolver, got %v", err)
+	}
+
+	entries := []kana.Char{{Kana: "ねこ", Romanji: "neko"}}
+	hub.SetDeckResolver(mockDeckResolver{"deck:ABC123": entries})
+
+	code, err := hub.CreateRoom(RoomOptions{Duration: 60, Groups: groups, Direction: kana.DirectionToKana}, uuid.New())
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	hub.mu.RLock()
+	room := hub.rooms[code]
+	hub.mu.RUnlock()
+	if got := room.Decks["deck:ABC123"]; !reflect.DeepEqual(got, entries) {
+		t.Errorf("Expected the deck snapshot in the room, got %v", room.Decks)
+	}
+	if room.Direction != kana.DirectionToKana {
+		t.Errorf("Expected the room direction, got %q", room.Direction)
+	}
+	hub.closeRoom(code)
+
+	if _, err := hub.CreateRoom(RoomOptions{Duration: 60, Groups: []string{"deck:NOPE99"}}, uuid.New()); !errors.Is(err, ErrUnknownDeck) {
+		t.Errorf("Expected ErrUnknownDeck, got %v", err)
+	}
+}
+
+func TestHub_LargeDeckReachesOtherInstance(t *testing.T) {
+	hubA, hubB := newClusterPair(t)
+
+	// Far more than fits in one bus message
+	entries := make([]kana.Char, 500)
+	for i := range entries {
+		entries[i] = kana.Char{Kana: fmt.Sprintf("語%03d", i), Romanji: "go", Readings: []string{"ご", "かたる", "かたらう"}}
+	}
+	hubA.SetDeckResolver(mockDeckResolver{"deck:BIG123": entries})
+
+	host := newMockClient(hubA, uuid.New(), "HostUser")
+	host.ID = "host"
+	hubA.register <- host
+	createMsg, _ := json.Marshal(map[string]interface{}{"type": "CREATE_ROOM", "duration": 60, "groups": []string{"deck:BIG123"}})
+	hubA.handleMessage(host, createMsg)
+	waitForType(t, host, "ROOM_STATE")
+
+	hubA.mu.RLock()
+	var code string
+	for c := range hubA.rooms {
+		code = c
+	}
+	hubA.mu.RUnlock()
+
+	guest := newMockClient(hubB, uuid.New(), "GuestUser")
+	guest.ID = "guest"
+	hubB.register <- guest
+	joinMsg, _ := json.Marshal(map[string]interface{}{"type": "JOIN_ROOM", "code": code})
+	hubB.handleMessage(guest, joinMsg)
+
+	// The room state only counts the entries
+	state := waitForType(t, guest, "ROOM_STATE")
+	config, _ := state["config"].(map[string]interface{})
+	decks, _ := config["decks"].(map[string]interface{})
+	if decks["deck:BIG123"] != float64(500) {
+		t.Fatalf("Expected a count of 500 entries, got %v", config["decks"])
+	}
+
+	startMsg, _ := json.Marshal(map[string]interface{}{"type": "START_GAME"})
+	hubA.handleMessage(host, startMsg)
+
+	// Every question arrives, prompting deck entries without their readings
+	questions, count := receiveQuestions(t, guest)
+	if count != 60*questionsPerSecond || len(questions) != count {
+		t.Fatalf("Expected %d questions on the other instance, got %d (count %d)", 60*questionsPerSecond, len(questions), count)
+	}
+	for _, q := range questions {
+		if !strings.HasPrefix(q.Prompt, "語") {
+			t.Errorf("Unexpected prompt %q", q.Prompt)
+		}
+	}
+}
+
+func TestHub_ChoiceQuestionsReachOtherInstance(t *testing.T) {
+	hubA, hubB := newClusterPair(t)
+
+	host := newMockClient(hubA, uuid.New(), "HostUser")
+	host.ID = "host"
+	hubA.register <- host
+	createMsg, _ := json.Marshal(map[string]interface{}{"type": "CREATE_ROOM", "duration": 120, "groups": []string{"hsingle", "ksingle"}, "choices": 4})
+	hubA.handleMessage(host, createMsg)
+	waitForType(t, host, "ROOM_STATE")
+
+	hubA.mu.RLock()
+	var code string
+	for c := range hubA.rooms {
+		code = c
+	}
+	hubA.mu.RUnlock()
+
+	guest := newMockClient(hubB, uuid.New(), "GuestUser")
+	guest.ID = "guest"
+	hubB.register <- guest
+	joinMsg, _ := json.Marshal(map[string]interface{}{"type": "JOIN_ROOM", "code": code})
+	hubB.handleMessage(guest, joinMsg)
+	waitForType(t, guest, "ROOM_STATE")
+
+	startMsg, _ := json.Marshal(map[string]interface{}{"type": "START_GAME"})
+	hubA.handleMessage(host, startMsg)
+
+	// 240 questions don't fit in one bus message; all of them arrive, in order
+	hostQuestions, _ := receiveQuestions(t, host)
+	guestQuestions, count := receiveQuestions(t, guest)
+	if count != 120*questionsPerSecond || len(guestQuestions) != count {
+		t.Fatalf("Expected %d questions on the other instance, got %d (count %d)", 120*questionsPerSecond, len(guestQuestions), count)
+	}
+	if !reflect.DeepEqual(guestQuestions, hostQuestions) {
+		t.Error("Expected both players to get the same questions")
+	}
+}
+
+func TestHub_CreateRoomForMembers(t *testing.T) {
+	hub := NewHub()
+	hostID, memberID := uuid.New(), uuid.New()
+	code, err := hub.CreateRoom(RoomOptions{Duration: 60, Groups: []string{"hsingle"}, Members: []uuid.UUID{memberID}}, hostID)
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	hub.mu.RLock()
+	room := hub.rooms[code]
+	hub.mu.RUnlock()
+	defer func() { room.stopGame <- true }()
+
+	host := newMockClient(hub, hostID, "Teacher")
+	room.register <- host
+	waitForType(t, host, "ROOM_STATE")
+
+	member := newMockClient(hub, memberID, "Student")
+	room.register <- member
+	waitForType(t, member, "ROOM_STATE")
+
+	// Outsiders are turned away
+	stranger := newMockClient(hub, uuid.New(), "Stranger")
+	room.register <- stranger
+	if msg := waitForType(t, stranger, "ERROR"); msg == nil {
+		t.Fatal("Expected stranger to be rejected")
+	}
+	if values := room.GetValues(); len(values.Players) != 2 {
+		t.Errorf("Expected the host and the member only, got %d players", len(values.Players))
+	}
+}
+
+func TestHub_CreateRaidRoom(t *testing.T) {
+	hub := NewHub()
+	groups := []string{"hsingle"}
+
+	if _, err := hub.CreateRoom(RoomOptions{Duration: 60, Groups: groups, Raid: true, BoardSize: MaxBoardSize + 1}, uuid.New()); !errors.Is(err, ErrInvalidBoardSize) {
+		t.Errorf("Expected ErrInvalidBoardSize, got %v", err)
+	}
+	if _, err := hub.CreateRoom(RoomOptions{Groups: groups, Raid: true, Presenter: true}, uuid.New()); !errors.Is(err, ErrConflictingModes) {
+		t.Errorf("Expected ErrConflictingModes, got %v", err)
+	}
+
+	code, err := hub.CreateRoom(RoomOptions{Duration: 60, Groups: groups, Raid: true}, uuid.New())
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	hub.mu.RLock()
+	room := hub.rooms[code]
+	hub.mu.RUnlock()
+	defer func() { room.stopGame <- true }()
+	if !room.Raid || room.BoardSize != DefaultBoardSize {
+		t.Errorf("Expected a raid with the default board, got %v %d", room.Raid, room.BoardSize)
+	}
+}
+
+func TestHub_CreateRaidRoomOverWebsocket(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	host := newMockClient(hub, uuid.New(), "HostUser")
+	hub.register <- host
+
+	// Same option names as the HTTP request
+	createMsg, _ := json.Marshal(map[string]interface{}{"type": "CREATE_ROOM", "duration": 60, "groups": []string{"hsingle"}, "raid": true, "board_size": 8})
+	hub.handleMessage(host, createMsg)
+
+	state := waitForType(t, host, "ROOM_STATE")
+	config, _ := state["config"].(map[string]interface{})
+	if config["raid"] != true || config["board_size"] != float64(8) {
+		t.Errorf("Expected a raid on a board of 8, got %v", config)
+	}
+}
@@ -0,0 +1,112 @@
+package game
+
+import (
+	"expvar"
+	"time"
+)
+
+// Escalation thresholds for a client sending too many messages. Violations
+// are counted until the client stays within its limits for violationDecay.
+const (
+	warnViolations       = 3  // first violations are dropped with a WARNING
+	disconnectViolations = 10 // past this the connection is closed with 1008
+	violationDecay       = time.Minute
+)
+
+// floodStats exposes violation counters at /debug/vars, served on the
+// internal DEBUG_ADDR listener.
+var floodStats = expvar.NewMap("ws_flood")
+
+type messageLimit struct {
+	capacity   float64 // burst
+	refillRate float64 // messages per second
+}
+
+// Inbound limits per message type. Types not listed use defaultMessageLimit.
+var messageLimits = map[string]messageLimit{
+	"CREATE_ROOM":   {capacity: 3, refillRate: 0.2},
+	"JOIN_ROOM":     {capacity: 5, refillRate: 0.5},
+	"START_GAME":    {capacity: 3, refillRate: 0.5},
+	"SUBMIT_ANSWER": {capacity: 10, refillRate: 5},
+	"REACT":         {capacity: 3, refillRate: 0.5},
+}
+
+var defaultMessageLimit = messageLimit{capacity: 20, refillRate: 10}
+
+type floodAction int
+
+const (
+	floodAllow floodAction = iota
+	floodWarn
+	floodDrop
+	floodDisconnect
+)
+
+// clientLimiter holds one token bucket per message type for a single client.
+// It is only used from the client's readPump, so it needs no locking.
+type clientLimiter struct {
+	buckets       map[string]*tokenBucket
+	violations    int
+	lastViolation time.Time
+}
+
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newClientLimiter() *clientLimiter {
+	return &clientLimiter{
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+func (l *clientLimiter) check(msgType string, now time.Time) floodAction {
+	limit, ok := messageLimits[msgType]
+	if !ok {
+		// Unknown types share a bucket so clients can't mint new ones
+		limit = defaultMessageLimit
+		msgType = "other"
+	}
+
+	bucket, ok := l.buckets[msgType]
+	if !ok {
+		bucket = &tokenBucket{tokens: limit.capacity, lastRefill: now}
+		l.buckets[msgType] = bucket
+	}
+
+	// Fill tokens according to the elapsed time
+	elapsed := now.Sub(bucket.lastRefill).Seconds()
+	if elapsed > 0 {
+		bucket.tokens += elapsed * limit.refillRate
+		if bucket.tokens > limit.capacity {
+			bucket.tokens = limit.capacity
+		}
+	}
+	bucket.lastRefill = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens -= 1
+		return floodAllow
+	}
+
+	if now.Sub(l.lastViolation) > violationDecay {
+		l.violations = 0
+	}
+	l.violations++
+	l.lastViolation = now
+
+	switch {
+	case l.violations > disconnectViolations:
+		floodStats.Add("disconnected", 1)
+		return floodDisconnect
+	case l.violations <= warnViolations:
+		floodStats.Add("warned", 1)
+		floodStats.Add("type_"+msgType, 1)
+		return floodWarn
+	default:
+		floodStats.Add("dropped", 1)
+		floodStats.Add("type_"+msgType, 1)
+		return floodDrop
+	}
+}
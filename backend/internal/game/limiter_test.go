@@ -0,0 +1,69 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClientLimiter_Escalation(t *testing.T) {
+	l := newClientLimiter()
+	now := time.Now()
+
+	// Burst capacity is allowed
+	limit := messageLimits["SUBMIT_ANSWER"]
+	for i := 0; i < int(limit.capacity); i++ {
+		if got := l.check("SUBMIT_ANSWER", now); got != floodAllow {
+			t.Fatalf("Message %d: expected allow, got %v", i, got)
+		}
+	}
+
+	// Then warn, drop and finally disconnect
+	for i := 1; i <= disconnectViolations+1; i++ {
+		got := l.check("SUBMIT_ANSWER", now)
+		want := floodDrop
+		if i <= warnViolations {
+			want = floodWarn
+		} else if i > disconnectViolations {
+			want = floodDisconnect
+		}
+		if got != want {
+			t.Fatalf("Violation %d: expected %v, got %v", i, want, got)
+		}
+	}
+}
+
+func TestClientLimiter_PerTypeAndRefill(t *testing.T) {
+	l := newClientLimiter()
+	now := time.Now()
+
+	for i := 0; i < int(messageLimits["START_GAME"].capacity); i++ {
+		l.check("START_GAME", now)
+	}
+	if got := l.check("START_GAME", now); got == floodAllow {
+		t.Fatal("Expected START_GAME to be limited")
+	}
+
+	// Other types have their own bucket
+	if got := l.check("SUBMIT_ANSWER", now); got != floodAllow {
+		t.Errorf("Expected SUBMIT_ANSWER to be allowed, got %v", got)
+	}
+
+	// Tokens refill over time
+	if got := l.check("START_GAME", now.Add(5*time.Second)); got != floodAllow {
+		t.Errorf("Expected START_GAME allowed after refill, got %v", got)
+	}
+}
+
+func TestClientLimiter_React(t *testing.T) {
+	l := newClientLimiter()
+	now := time.Now()
+
+	for i := 0; i < int(messageLimits["REACT"].capacity); i++ {
+		if got := l.check("REACT", now); got != floodAllow {
+			t.Fatalf("Reaction %d: expected allow, got %v", i, got)
+		}
+	}
+	if got := l.check("REACT", now); got != floodWarn {
+		t.Errorf("Expected a reaction burst to be limited, got %v", got)
+	}
+}
@@ -0,0 +1,253 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/quiz"
+	"github.com/google/uuid"
+)
+
+// In presenter mode the host runs a live quiz from the front of the class:
+// they open each question with NEXT_QUESTION, everyone else answers it
+// within the window, and the room reveals the answers and a leaderboard
+// when it closes. The host isn't a player.
+const (
+	DefaultQuestionSeconds = 20
+	MinQuestionSeconds     = 5
+	MaxQuestionSeconds     = 120
+)
+
+// Points for a right answer: half for being right, the rest for speed.
+const presenterMaxPoints = 1000
+
+var ErrInvalidQuestionSeconds = fmt.Errorf("question seconds must be between %d and %d", MinQuestionSeconds, MaxQuestionSeconds)
+
+type presenterQuestion struct {
+	index    int // 1-based
+	question quiz.Question
+	char     kana.Char
+	openedAt time.Time
+	deadline time.Time
+	answers  map[uuid.UUID]presenterAnswer
+}
+
+type presenterAnswer struct {
+	Answer  string `json:"answer"`
+	Correct bool   `json:"correct"`
+	Points  int    `json:"points"`
+}
+
+// startPresenter begins a presenter game. Unlike a race it has no end time;
+// it runs until the host sends END_GAME.
+func (r *Room) startPresenter() {
+	r.State = StatePlaying
+	r.StartedAt = time.Now()
+	data, err := json.Marshal(map[string]interface{}{
+		"type":      "GAME_STARTED",
+		"presenter": true,
+	})
+	if err == nil {
+		r.broadcastToClients(data)
+	}
+}
+
+// nextQuestion opens a new question for every player. Called from the Run
+// loop.
+func (r *Room) nextQuestion() {
+	if r.State != StatePlaying || r.current != nil {
+		return
+	}
+	pool := r.pool()
+	if len(pool) == 0 {
+		return
+	}
+
+	// Avoid the same prompt twice in a row
+	rng := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	opts := quiz.Options{Count: 1, Choices: r.Choices, Direction: r.Direction}
+	var q quiz.Question
+	for i := 0; i < 10; i++ {
+		q = quiz.Generate(pool, opts, rng)[0]
+		if q.Kana != r.lastKana {
+			break
+		}
+	}
+	if r.Choices == 0 {
+		// Typed answers
+		q.Choices = nil
+	}
+	var c kana.Char
+	for _, c = range pool {
+		if c.Kana == q.Kana {
+			break
+		}
+	}
+
+	r.asked++
+	r.lastKana = c.Kana
+	now := time.Now()
+	r.current = &presenterQuestion{
+		index:    r.asked,
+		question: q,
+		char:     c,
+		openedAt: now,
+		deadline: now.Add(time.Duration(r.QuestionSeconds) * time.Second),
+		answers:  make(map[uuid.UUID]presenterAnswer),
+	}
+
+	msg := map[string]interface{}{
+		"type":     "QUESTION",
+		"question": r.asked,
+		"prompt":   q.Prompt,
+		"deadline": r.current.deadline,
+	}
+	if len(q.Choices) > 0 {
+		msg["choices"] = q.Choices
+	}
+	data, err := json.Marshal(msg)
+	if err == nil {
+		r.broadcastToClients(data)
+	}
+
+	go r.closeQuestionAt(r.asked, r.current.deadline)
+}
+
+// closeQuestionAt closes the question when its window runs out, unless it
+// was closed already.
+func (r *Room) closeQuestionAt(index int, deadline time.Time) {
+	time.Sleep(time.Until(deadline))
+	action := func() {
+		if r.current != nil && r.current.index == index {
+			r.closeQuestion()
+		}
+	}
+	select {
+	case r.action <- action:
+	case <-time.After(100 * time.Millisecond):
+		slog.Warn("Timeout closing presenter question", "room", r.Code, "question", index)
+	}
+}
+
+// submitAnswer records a player's first answer to the open question. Called
+// from the Run loop.
+func (r *Room) submitAnswer(userID uuid.UUID, index int, answer string) {
+	q := r.current
+	if r.State != StatePlaying || q == nil || q.index != index {
+		return
+	}
+	p, ok := r.Players[userID]
+	if !ok || p.Ghost {
+		return
+	}
+	if _, answered := q.answers[userID]; answered {
+		return
+	}
+	now := time.Now()
+	if now.After(q.deadline) {
+		return
+	}
+
+	a := presenterAnswer{
+		Answer:  strings.TrimSpace(answer),
+		Correct: r.isCorrect(q.question, q.char, answer, p.systems),
+	}
+	if a.Correct {
+		window := q.deadline.Sub(q.openedAt)
+		left := float64(q.deadline.Sub(now)) / float64(window)
+		a.Points = presenterMaxPoints/2 + int(left*presenterMaxPoints/2)
+	}
+	q.answers[userID] = a
+
+	data, err := json.Marshal(map[string]interface{}{
+		"type":     "ANSWER_PROGRESS",
+		"question": q.index,
+		"answered": len(q.answers),
+		"players":  r.livePlayers(),
+	})
+	if err == nil {
+		r.broadcastToClients(data)
+	}
+
+	// No need to wait once everyone has answered
+	if len(q.answers) >= r.livePlayers() {
+		r.closeQuestion()
+	}
+}
+
+// closeQuestion scores the open question and reveals the answer, how the
+// answers were spread and the leaderboard. Called from the Run loop.
+func (r *Room) closeQuestion() {
+	q := r.current
+	if q == nil {
+		return
+	}
+	r.current = nil
+
+	distribution := make(map[string]int)
+	for _, choice := range q.question.Choices {
+		distribution[choice] = 0
+	}
+	answers := make(map[string]presenterAnswer, len(q.answers))
+	offset := time.Since(r.StartedAt).Milliseconds()
+	for userID, a := range q.answers {
+		key := a.Answer
+		if len(q.question.Choices) == 0 {
+			key = strings.ToLower(key)
+		}
+		distribution[key]++
+		answers[userID.String()] = a
+		if a.Points > 0 {
+			if p, ok := r.Players[userID]; ok {
+				p.Score += a.Points
+				r.events[userID] = append(r.events[userID], ScoreEvent{OffsetMs: offset, Score: p.Score})
+			}
+		}
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"type":         "QUESTION_RESULTS",
+		"question":     q.index,
+		"answer":       q.question.Answer,
+		"distribution": distribution,
+		"answers":      answers,
+		"leaderboard":  r.leaderboard(),
+	})
+	if err == nil {
+		r.broadcastToClients(data)
+	}
+}
+
+// leaderboard lists the players by score, best first.
+func (r *Room) leaderboard() []Player {
+	board := make([]Player, 0, len(r.Players))
+	for _, p := range r.Players {
+		board = append(board, *p)
+	}
+	sort.Slice(board, func(i, j int) bool {
+		if board[i].Score != board[j].Score {
+			return board[i].Score > board[j].Score
+		}
+		return board[i].Username < board[j].Username
+	})
+	return board
+}
+
+// livePlayers counts the players still connected.
+func (r *Room) livePlayers() int {
+	n := 0
+	seen := make(map[uuid.UUID]bool)
+	for c := range r.Clients {
+		if _, ok := r.Players[c.UserID]; ok && !seen[c.UserID] {
+			seen[c.UserID] = true
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,176 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/quiz"
+	"github.com/google/uuid"
+)
+
+// In raid mode the players clear a shared board together: any player claims
+// a tile by answering it right, every wrong answer takes time off the shared
+// clock, and the team wins if the board is cleared before it runs out.
+const (
+	DefaultBoardSize = 20
+	MinBoardSize     = 4
+	MaxBoardSize     = 60
+)
+
+const (
+	raidPenalty    = 3 * time.Second
+	raidTilePoints = 100
+)
+
+var (
+	ErrInvalidBoardSize = fmt.Errorf("board size must be between %d and %d", MinBoardSize, MaxBoardSize)
+	ErrConflictingModes = errors.New("a room can't be both a raid and a presenter quiz")
+)
+
+type raidTile struct {
+	Tile      int        `json:"tile"`
+	Prompt    string     `json:"prompt"`
+	Choices   []string   `json:"choices,omitempty"`
+	ClaimedBy *uuid.UUID `json:"claimedBy,omitempty"`
+
+	question quiz.Question
+	char     kana.Char
+}
+
+// RaidContribution is what one player did for the team.
+type RaidContribution struct {
+	UserID   uuid.UUID `json:"userId"`
+	Username string    `json:"username"`
+	Claimed  int       `json:"claimed"`
+	Mistakes int       `json:"mistakes"`
+}
+
+// dealBoard draws the board's tiles from the room's groups, each character
+// at most once, so a board is smaller than BoardSize if the groups are.
+func (r *Room) dealBoard() []*raidTile {
+	pool := r.pool()
+	rng := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	opts := quiz.Options{Choices: r.Choices, Direction: r.Direction}
+
+	seen := make(map[string]bool)
+	var board []*raidTile
+	for _, i := range rng.Perm(len(pool)) {
+		if len(board) == r.BoardSize {
+			break
+		}
+		c := pool[i]
+		if seen[c.Kana] {
+			continue
+		}
+		seen[c.Kana] = true
+
+		q := quiz.Ask(c, pool, opts, rng)
+		t := &raidTile{Tile: len(board), Prompt: q.Prompt, question: q, char: c}
+		if r.Choices > 0 {
+			t.Choices = q.Choices
+		}
+		board = append(board, t)
+	}
+
+	r.board = board
+	r.cleared = 0
+	r.contributions = make(map[uuid.UUID]*RaidContribution)
+	return board
+}
+
+// claimTile checks a player's answer to a tile. A right answer claims it for
+// the team; a wrong one takes raidPenalty off the clock. Called from the Run
+// loop.
+func (r *Room) claimTile(userID uuid.UUID, tile int, answer string) {
+	if r.State != StatePlaying || tile < 0 || tile >= len(r.board) {
+		return
+	}
+	p, ok := r.Players[userID]
+	if !ok || p.Ghost {
+		return
+	}
+	t := r.board[tile]
+	if t.ClaimedBy != nil {
+		// A teammate was faster
+		return
+	}
+
+	stats, ok := r.contributions[userID]
+	if !ok {
+		stats = &RaidContribution{UserID: userID, Username: p.Username}
+		r.contributions[userID] = stats
+	}
+
+	if !r.isCorrect(t.question, t.char, answer, p.systems) {
+		stats.Mistakes++
+		r.EndTime = r.EndTime.Add(-raidPenalty)
+		r.broadcastRaid("RAID_PENALTY", map[string]interface{}{
+			"tile":     tile,
+			"userId":   userID,
+			"username": p.Username,
+			"endTime":  r.EndTime,
+		})
+		r.endTimer.Reset(time.Until(r.EndTime))
+		return
+	}
+
+	t.ClaimedBy = &userID
+	stats.Claimed++
+	r.cleared++
+	p.Score += raidTilePoints
+	r.events[userID] = append(r.events[userID], ScoreEvent{
+		OffsetMs: time.Since(r.StartedAt).Milliseconds(),
+		Score:    p.Score,
+	})
+	r.broadcastRaid("TILE_CLAIMED", map[string]interface{}{
+		"tile":      tile,
+		"userId":    userID,
+		"username":  p.Username,
+		"remaining": len(r.board) - r.cleared,
+	})
+
+	if r.cleared == len(r.board) {
+		r.endGame()
+	}
+}
+
+// raidResult is the raid part of GAME_OVER.
+func (r *Room) raidResult() map[string]interface{} {
+	contributions := make([]RaidContribution, 0, len(r.Players))
+	for id, p := range r.Players {
+		if p.Ghost {
+			continue
+		}
+		c := RaidContribution{UserID: id, Username: p.Username}
+		if stats, ok := r.contributions[id]; ok {
+			c = *stats
+		}
+		contributions = append(contributions, c)
+	}
+	sort.Slice(contributions, func(i, j int) bool {
+		if contributions[i].Claimed != contributions[j].Claimed {
+			return contributions[i].Claimed > contributions[j].Claimed
+		}
+		return contributions[i].Username < contributions[j].Username
+	})
+
+	return map[string]interface{}{
+		"won":           len(r.board) > 0 && r.cleared == len(r.board),
+		"cleared":       r.cleared,
+		"tiles":         len(r.board),
+		"contributions": contributions,
+	}
+}
+
+func (r *Room) broadcastRaid(msgType string, fields map[string]interface{}) {
+	fields["type"] = msgType
+	data, err := json.Marshal(fields)
+	if err == nil {
+		r.broadcastToClients(data)
+	}
+}
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"encoding/json"
+	"log/slog"
+	"slices"
+
+	"github.com/Cadimodev/haiji/backend/internal/shop"
+)
+
+// Reactions are the quick reactions every player can send with REACT. Only
+// these and the emote a player has equipped from the shop are relayed, so
+// the channel can't carry arbitrary text.
+var Reactions = []string{"がんばって!", "すごい!", "👏"}
+
+// allowedReaction reports whether the client may send reaction.
+func allowedReaction(client *Client, reaction string) bool {
+	if slices.Contains(Reactions, reaction) {
+		return true
+	}
+	item, ok := shop.GetItem(client.Cosmetics[shop.KindEmote])
+	return ok && item.Kind == shop.KindEmote && item.Value == reaction
+}
+
+// react relays a reaction to everyone in the room while a game is played or
+// just finished. Called from the Run loop.
+func (r *Room) react(client *Client, reaction string) {
+	if r.State != StatePlaying && r.State != StateFinished {
+		return
+	}
+	if !r.Clients[client] {
+		return
+	}
+	if !allowedReaction(client, reaction) {
+		slog.Debug("Dropping reaction not on the allowlist", "room", r.Code, "user", client.UserID)
+		return
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"type":     "REACTION",
+		"userId":   client.UserID,
+		"reaction": reaction,
+	})
+	if err == nil {
+		r.broadcastToClients(data)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/romaji"
 	"github.com/google/uuid"
 )
 
@@ -20,6 +22,15 @@ type Player struct {
 	UserID   uuid.UUID `json:"userId"`
 	Username string    `json:"username"`
 	Score    int       `json:"score"`
+	Ghost    bool      `json:"ghost,omitempty"`
+
+	// Equipped shop items, by slot
+	Cosmetics map[string]string `json:"cosmetics,omitempty"`
+
+	// Battle questions answered so far
+	answered int
+	// Romanization systems typed answers are graded in; all if empty
+	systems []romaji.System
 }
 
 type Room struct {
@@ -31,6 +42,17 @@ type Room struct {
 	// Game Config
 	Duration int      // seconds
 	Groups   []string // kana groups
+	// Entries of the user decks in Groups, by group
+	Decks     map[string][]kana.Char
+	Direction kana.Direction
+	// Multiple-choice prompts with this many choices; 0 means typed answers
+	Choices int
+	// Set for a live quiz the host runs without playing
+	Presenter       bool
+	QuestionSeconds int
+	// Set for a co-op game clearing a shared board of this many tiles
+	Raid      bool
+	BoardSize int
 
 	// State
 	State   GameState
@@ -38,12 +60,38 @@ type Room struct {
 	Players map[uuid.UUID]*Player
 	HostID  uuid.UUID
 
+	// Recorded score submissions, replayable as ghosts
+	StartedAt time.Time
+	events    map[uuid.UUID][]ScoreEvent
+
+	// Set when the host is racing a recorded run
+	Ghost *Ghost
+
+	// Battles: the questions every player answers, in order
+	questions []battleQuestion
+
+	// Set for class rooms: only these users and the host may join
+	Members map[uuid.UUID]bool
+
+	// Presenter mode: the open question, if any, and how many were asked
+	current  *presenterQuestion
+	asked    int
+	lastKana string
+
+	// Raid mode: the board, how much of it is cleared and who did what
+	board         []*raidTile
+	cleared       int
+	contributions map[uuid.UUID]*RaidContribution
+
 	// Lifecycle
-	register     chan *Client
-	unregister   chan *Client
-	stopGame     chan bool
-	timeFinished chan bool
-	action       chan func() // Closure pattern for actions
+	register   chan *Client
+	unregister chan *Client
+	stopGame   chan bool
+	action     chan func() // Closure pattern for actions
+
+	// Fires at EndTime; owned by the Run loop, which resets it whenever
+	// EndTime moves
+	endTimer *time.Timer
 }
 
 type RoomValues struct {
@@ -54,23 +102,41 @@ type RoomValues struct {
 
 func NewRoom(code string, hub *Hub, duration int, groups []string, hostID uuid.UUID) *Room {
 	return &Room{
-		Code:         code,
-		Hub:          hub,
-		Clients:      make(map[*Client]bool),
-		Broadcast:    make(chan []byte),
-		register:     make(chan *Client),
-		unregister:   make(chan *Client),
-		Duration:     duration,
-		Groups:       groups,
-		State:        StateWaiting,
-		Players:      make(map[uuid.UUID]*Player),
-		stopGame:     make(chan bool),
-		timeFinished: make(chan bool),
-		action:       make(chan func()),
-		HostID:       hostID,
+		Code:       code,
+		Hub:        hub,
+		Clients:    make(map[*Client]bool),
+		Broadcast:  make(chan []byte),
+		register:   make(chan *Client),
+		unregister: make(chan *Client),
+		Duration:   duration,
+		Groups:     groups,
+		Direction:  kana.DirectionToRomaji,
+		State:      StateWaiting,
+		Players:    make(map[uuid.UUID]*Player),
+		stopGame:   make(chan bool),
+		action:     make(chan func()),
+		HostID:     hostID,
+		events:     make(map[uuid.UUID][]ScoreEvent),
 	}
 }
 
+// NewGhostRoom returns a room only the challenger can join, with the ghost
+// already seated as a player.
+func NewGhostRoom(code string, hub *Hub, ghost Ghost, challengerID uuid.UUID) *Room {
+	r := NewRoom(code, hub, ghost.Duration, ghost.Groups, challengerID)
+	r.Ghost = &ghost
+	if ghost.Direction != "" {
+		r.Direction = ghost.Direction
+	}
+	r.Choices = ghost.Choices
+	r.Players[ghost.UserID] = &Player{
+		UserID:   ghost.UserID,
+		Username: ghost.Username,
+		Ghost:    true,
+	}
+	return r
+}
+
 func (r *Room) Run() {
 	defer func() {
 		// Cleanup when room dies
@@ -81,6 +147,11 @@ func (r *Room) Run() {
 	// Grace period: allow room to stay alive for 30 seconds if empty
 	shutdownTimer := time.NewTimer(30 * time.Second)
 
+	// Armed when the game starts
+	r.endTimer = time.NewTimer(time.Hour)
+	r.endTimer.Stop()
+	defer r.endTimer.Stop()
+
 	for {
 		select {
 		case client := <-r.register:
@@ -92,14 +163,34 @@ func (r *Room) Run() {
 				}
 			}
 
+			// Ghost races are private to the challenger
+			if r.Ghost != nil && client.UserID != r.HostID {
+				client.Send <- []byte(`{"type":"ERROR", "message":"This room is a private ghost race"}`)
+				if len(r.Clients) == 0 {
+					shutdownTimer.Reset(30 * time.Second)
+				}
+				continue
+			}
+			// Class rooms only admit the class
+			if r.Members != nil && client.UserID != r.HostID && !r.Members[client.UserID] {
+				client.Send <- []byte(`{"type":"ERROR", "message":"This room is for class members only"}`)
+				if len(r.Clients) == 0 {
+					shutdownTimer.Reset(30 * time.Second)
+				}
+				continue
+			}
+
 			r.Clients[client] = true
 			slog.Info("Room registered client", "room", r.Code, "user", client.Username, "total_clients", len(r.Clients))
-			// Add to players list
-			if _, exists := r.Players[client.UserID]; !exists {
+			// Add to players list; a presenter only runs the game
+			_, exists := r.Players[client.UserID]
+			if !exists && !(r.Presenter && client.UserID == r.HostID) {
 				r.Players[client.UserID] = &Player{
-					UserID:   client.UserID,
-					Username: client.Username,
-					Score:    0,
+					UserID:    client.UserID,
+					Username:  client.Username,
+					Score:     0,
+					Cosmetics: client.Cosmetics,
+					systems:   client.RomajiSystems,
 				}
 			}
 			client.Room = r
@@ -127,20 +218,8 @@ func (r *Room) Run() {
 		case message := <-r.Broadcast:
 			r.broadcastToClients(message)
 
-		case <-r.timeFinished:
-			if r.State != StatePlaying {
-				continue
-			}
-			r.State = StateFinished
-			slog.Info("Game finished. Broadcasting results.", "room", r.Code)
-			msg := map[string]interface{}{
-				"type":    "GAME_OVER",
-				"players": r.Players,
-			}
-			data, err := json.Marshal(msg)
-			if err == nil {
-				r.broadcastToClients(data)
-			}
+		case <-r.endTimer.C:
+			r.endGame()
 
 		case <-shutdownTimer.C:
 			slog.Info("Room grace period expired. Shutting down.", "room", r.Code)
@@ -187,39 +266,88 @@ func (r *Room) startGame() {
 	if r.State != StateWaiting {
 		return
 	}
+	if r.Presenter {
+		r.startPresenter()
+		return
+	}
 	r.State = StatePlaying
-	r.EndTime = time.Now().Add(time.Duration(r.Duration) * time.Second)
+	r.StartedAt = time.Now()
+	r.EndTime = r.StartedAt.Add(time.Duration(r.Duration) * time.Second)
 
 	// Notify clients
 	msg := map[string]interface{}{
 		"type":    "GAME_STARTED",
 		"endTime": r.EndTime,
 	}
+	switch {
+	case r.Raid:
+		msg["raid"] = true
+		msg["board"] = r.dealBoard()
+	default:
+		// Everyone gets the same questions, so the race is fair
+		prompts := r.dealQuestions()
+		r.broadcastQuestions(prompts)
+		msg["questionCount"] = len(prompts)
+	}
 	data, err := json.Marshal(msg)
 	if err == nil {
 		r.broadcastToClients(data)
 	}
 
-	// Start timer to end game
-	go func() {
-		time.Sleep(time.Duration(r.Duration) * time.Second)
-		r.finishGame()
-	}()
+	r.endTimer.Reset(time.Until(r.EndTime))
+
+	if r.Ghost != nil {
+		go r.replayGhost(r.Ghost, r.StartedAt, r.EndTime)
+	}
 }
 
-func (r *Room) finishGame() {
-	// Signal Run loop to finish game safely
-	select {
-	case r.timeFinished <- true:
-	default:
+// endGame ends the game and hands the results to the result stores. Called
+// from the Run loop.
+func (r *Room) endGame() {
+	if r.State != StatePlaying {
+		return
+	}
+	r.State = StateFinished
+	slog.Info("Game finished. Broadcasting results.", "room", r.Code)
+	msg := map[string]interface{}{
+		"type":    "GAME_OVER",
+		"players": r.Players,
+	}
+	if r.Raid {
+		msg["raid"] = r.raidResult()
+	}
+	if id, ok := r.shareResults(); ok {
+		msg["shareId"] = id
+	}
+	data, err := json.Marshal(msg)
+	if err == nil {
+		r.broadcastToClients(data)
 	}
+	r.saveResults()
+}
+
+// pool returns the characters of the room's groups.
+func (r *Room) pool() []kana.Char {
+	var pool []kana.Char
+	for _, id := range r.Groups {
+		if g, ok := kana.GetGroup(id); ok {
+			pool = append(pool, g.Chars...)
+		}
+		pool = append(pool, r.Decks[id]...)
+	}
+	return pool
 }
 
 func (r *Room) handleRoomMessage(client *Client, msg []byte) {
 	var payload struct {
 		Type string `json:"type"`
-		// For submit score
-		Score int `json:"score"`
+		// For submit answer
+		Question int    `json:"question"`
+		Answer   string `json:"answer"`
+		// For claim tile, in raid mode
+		Tile int `json:"tile"`
+		// For react
+		Reaction string `json:"reaction"`
 	}
 	if err := json.Unmarshal(msg, &payload); err != nil {
 		return
@@ -235,19 +363,36 @@ func (r *Room) handleRoomMessage(client *Client, msg []byte) {
 			}
 			r.startGame()
 
-		case "SUBMIT_SCORE":
-			if r.State == StatePlaying {
-				// Sanity Check: Prevent negative or unrealistic scores
-				if payload.Score < 0 || payload.Score > 9999 {
-					slog.Warn("Potential hack attempt: invalid score", "room", r.Code, "user", client.UserID, "score", payload.Score)
-					return
-				}
+		case "NEXT_QUESTION", "CLOSE_QUESTION", "END_GAME":
+			// The presenter paces the game
+			if !r.Presenter || client.UserID != r.HostID {
+				return
+			}
+			switch payload.Type {
+			case "NEXT_QUESTION":
+				r.nextQuestion()
+			case "CLOSE_QUESTION":
+				r.closeQuestion()
+			case "END_GAME":
+				r.closeQuestion()
+				r.endGame()
+			}
 
-				if p, ok := r.Players[client.UserID]; ok {
-					p.Score = payload.Score // Trusting client for now.
-					r.broadcastScores()
-				}
+		case "SUBMIT_ANSWER":
+			switch {
+			case r.Presenter:
+				r.submitAnswer(client.UserID, payload.Question, payload.Answer)
+			case !r.Raid:
+				r.answerQuestion(client, payload.Question, payload.Answer)
+			}
+
+		case "CLAIM_TILE":
+			if r.Raid {
+				r.claimTile(client.UserID, payload.Tile, payload.Answer)
 			}
+
+		case "REACT":
+			r.react(client, payload.Reaction)
 		}
 	}
 
@@ -266,8 +411,16 @@ func (r *Room) broadcastRoomState() {
 		"players": r.Players,
 		"hostId":  r.HostID,
 		"config": map[string]interface{}{
-			"duration": r.Duration,
-			"groups":   r.Groups,
+			"duration":  r.Duration,
+			"groups":    r.Groups,
+			"decks":     r.deckSizes(),
+			"direction": r.Direction,
+			"choices":   r.Choices,
+			"presenter": r.Presenter,
+			// Answer window of presenter questions
+			"question_seconds": r.QuestionSeconds,
+			"raid":             r.Raid,
+			"board_size":       r.BoardSize,
 		},
 	}
 	data, err := json.Marshal(msg)
@@ -278,6 +431,16 @@ func (r *Room) broadcastRoomState() {
 	r.broadcastToClients(data)
 }
 
+// deckSizes counts the entries of each deck. Players only see the entries
+// the questions prompt.
+func (r *Room) deckSizes() map[string]int {
+	sizes := make(map[string]int, len(r.Decks))
+	for id, entries := range r.Decks {
+		sizes[id] = len(entries)
+	}
+	return sizes
+}
+
 func (r *Room) broadcastScores() {
 	msg := map[string]interface{}{
 		"type":    "SCORE_UPDATE",
@@ -291,13 +454,14 @@ func (r *Room) broadcastScores() {
 	r.broadcastToClients(data)
 }
 
+// broadcastToClients queues message for every client in the room. A client
+// whose queue is full misses it; Send is only ever closed by the hub.
 func (r *Room) broadcastToClients(message []byte) {
 	for client := range r.Clients {
 		select {
 		case client.Send <- message:
 		default:
-			close(client.Send)
-			delete(r.Clients, client)
+			slog.Warn("Dropping room message for slow client", "room", r.Code, "user", client.Username)
 		}
 	}
 }
@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/romaji"
 	"github.com/google/uuid"
 )
 
@@ -126,96 +128,524 @@ func TestRoom_StartGame(t *testing.T) {
 	}
 }
 
-func TestRoom_ScoreUpdate(t *testing.T) {
+func TestRoom_StartGameWithChoices(t *testing.T) {
 	hub := NewHub()
 	hostID := uuid.New()
-	// Short duration to test finishGame quickly if needed, but here we just test scoring
-	room := NewRoom("TEST03", hub, 10, []string{"group1"}, hostID)
-	room.State = StatePlaying // Force playing state manually for setup (unsafe if concurrent, but we haven't started Run yet or just started)
+	room := NewRoom("TEST05", hub, 30, []string{"ksingle", "deck:ABC123"}, hostID)
+	room.Decks = map[string][]kana.Char{"deck:ABC123": {{Kana: "ねこ", Romanji: "neko"}}}
+	room.Choices = 3
+	go room.Run()
+	defer func() { room.stopGame <- true }()
+
+	c1 := newMockClient(hub, hostID, "HostUser")
+	room.register <- c1
+	time.Sleep(10 * time.Millisecond)
+	<-c1.Send // ROOM_STATE
+
+	startMsg, _ := json.Marshal(map[string]interface{}{"type": "START_GAME"})
+	room.handleRoomMessage(c1, startMsg)
+
+	questions, count := receiveQuestions(t, c1)
+	if len(questions) != 30*questionsPerSecond || count != len(questions) {
+		t.Fatalf("Expected %d questions, got %d (count %d)", 30*questionsPerSecond, len(questions), count)
+	}
+	for _, q := range questions {
+		if len(q.Choices) != 3 {
+			t.Errorf("Expected 3 choices, got %v", q.Choices)
+		}
+	}
+}
+
+// receiveQuestions collects the QUESTIONS sent ahead of GAME_STARTED and
+// the question count GAME_STARTED announces.
+func receiveQuestions(t *testing.T, c *Client) ([]prompt, int) {
+	t.Helper()
+	var questions []prompt
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case msg := <-c.Send:
+			var parsed struct {
+				Type          string   `json:"type"`
+				Questions     []prompt `json:"questions"`
+				QuestionCount int      `json:"questionCount"`
+			}
+			json.Unmarshal(msg, &parsed)
+			switch parsed.Type {
+			case "QUESTIONS":
+				questions = append(questions, parsed.Questions...)
+			case "GAME_STARTED":
+				return questions, parsed.QuestionCount
+			}
+		case <-timeout:
+			t.Fatalf("Timeout waiting for GAME_STARTED, got %d questions", len(questions))
+			return nil, 0
+		}
+	}
+}
+
+func TestRoom_AnswerQuestions(t *testing.T) {
+	hub := NewHub()
+	hostID := uuid.New()
+	room := NewRoom("TEST03", hub, 30, []string{"hsingle"}, hostID)
+	go room.Run()
+	defer func() { room.stopGame <- true }()
+
+	host := newMockClient(hub, hostID, "P1")
+	room.register <- host
+	waitForType(t, host, "ROOM_STATE")
+
+	startMsg, _ := json.Marshal(map[string]interface{}{"type": "START_GAME"})
+	room.handleRoomMessage(host, startMsg)
+	questions, _ := receiveQuestions(t, host)
+	if len(questions) != 30*questionsPerSecond {
+		t.Fatalf("Expected %d questions, got %d", 30*questionsPerSecond, len(questions))
+	}
+
+	// Typed questions only show the prompt
+	for _, q := range questions {
+		if q.Prompt == "" || q.Choices != nil {
+			t.Fatalf("Unexpected typed question %+v", q)
+		}
+	}
+
+	room.handleRoomMessage(host, answerMsg(0, " "+rightAnswer(t, questions[0])+" "))
+	result := waitForType(t, host, "ANSWER_RESULT")
+	if result["correct"] != true || result["question"] != float64(0) {
+		t.Errorf("Expected question 0 right, got %v", result)
+	}
+	update := waitForType(t, host, "SCORE_UPDATE")
+	players, _ := update["players"].(map[string]interface{})
+	if p, _ := players[hostID.String()].(map[string]interface{}); p["score"] != float64(1) {
+		t.Errorf("Expected score 1, got %v", players)
+	}
+
+	// A wrong answer moves on without a point, and reveals the right one
+	room.handleRoomMessage(host, answerMsg(1, "wrong"))
+	result = waitForType(t, host, "ANSWER_RESULT")
+	if result["correct"] != false || result["answer"] != rightAnswer(t, questions[1]) {
+		t.Errorf("Expected question 1 wrong with its answer, got %v", result)
+	}
+
+	if vals := room.GetValues(); vals.Players[hostID].Score != 1 {
+		t.Errorf("Expected score 1, got %d", vals.Players[hostID].Score)
+	}
+}
+
+func TestRoom_AnswersUsePlayersRomajiSystems(t *testing.T) {
+	hub := NewHub()
+	hostID := uuid.New()
+	room := NewRoom("TEST04", hub, 30, []string{"deck:SHI123"}, hostID)
+	room.Decks = map[string][]kana.Char{"deck:SHI123": {{Kana: "し", Romanji: "shi"}}}
+	go room.Run()
+	defer func() { room.stopGame <- true }()
 
-	pID := uuid.New()
-	room.Players[pID] = &Player{UserID: pID, Username: "P1", Score: 0}
+	hepburn := newMockClient(hub, hostID, "Hepburn")
+	hepburn.RomajiSystems = []romaji.System{romaji.Hepburn}
+	kunrei := newMockClient(hub, uuid.New(), "Kunrei")
+	kunrei.RomajiSystems = []romaji.System{romaji.Kunrei}
+	room.register <- hepburn
+	room.register <- kunrei
+	waitForType(t, kunrei, "ROOM_STATE")
 
-	// Create mock client associated with player
-	c1 := newMockClient(hub, pID, "P1")
-	room.Clients[c1] = true
-	c1.Room = room
+	startMsg, _ := json.Marshal(map[string]interface{}{"type": "START_GAME"})
+	room.handleRoomMessage(hepburn, startMsg)
+	receiveQuestions(t, hepburn)
+	receiveQuestions(t, kunrei)
+
+	// "si" spells し in Kunrei-shiki only, as in practice
+	room.handleRoomMessage(hepburn, answerMsg(0, "si"))
+	if result := waitForType(t, hepburn, "ANSWER_RESULT"); result["correct"] != false {
+		t.Errorf("Expected si wrong for a Hepburn player, got %v", result)
+	}
+	room.handleRoomMessage(kunrei, answerMsg(0, "si"))
+	if result := waitForType(t, kunrei, "ANSWER_RESULT"); result["correct"] != true {
+		t.Errorf("Expected si right for a Kunrei player, got %v", result)
+	}
+}
 
-	// Run loop to handle broadcast channel
+func TestRoom_AnswersAreScoredByTheRoom(t *testing.T) {
+	hub := NewHub()
+	hostID := uuid.New()
+	room := NewRoom("TEST_SEC", hub, 30, []string{"hsingle"}, hostID)
 	go room.Run()
 	defer func() { room.stopGame <- true }()
 
-	// Send Score Update
-	scoreMsg, _ := json.Marshal(map[string]interface{}{
-		"type":  "SUBMIT_SCORE",
-		"score": 100,
-	})
+	c1 := newMockClient(hub, hostID, "Hacker")
+	room.register <- c1
+	waitForType(t, c1, "ROOM_STATE")
 
-	// Simulate the Hub routing the message to the room
+	startMsg, _ := json.Marshal(map[string]interface{}{"type": "START_GAME"})
+	room.handleRoomMessage(c1, startMsg)
+	questions, _ := receiveQuestions(t, c1)
+
+	// Scores can't be submitted
+	scoreMsg, _ := json.Marshal(map[string]interface{}{"type": "SUBMIT_SCORE", "score": 9999})
 	room.handleRoomMessage(c1, scoreMsg)
 
+	// Nor can questions be skipped, or retried once answered
+	room.handleRoomMessage(c1, answerMsg(1, rightAnswer(t, questions[1])))
+	room.handleRoomMessage(c1, answerMsg(0, "wrong"))
+	room.handleRoomMessage(c1, answerMsg(0, rightAnswer(t, questions[0])))
+
+	if vals := room.GetValues(); vals.Players[hostID].Score != 0 {
+		t.Errorf("Expected score 0, got %d", vals.Players[hostID].Score)
+	}
+}
+
+// rightAnswer returns the typed answer to a prompt on a catalog kana.
+func rightAnswer(t *testing.T, q prompt) string {
+	t.Helper()
+	c, ok := kana.Lookup(q.Prompt)
+	if !ok {
+		t.Fatalf("Prompt %q isn't a catalog kana", q.Prompt)
+	}
+	return kana.DirectionToRomaji.Expected(c)
+}
+
+func answerMsg(question int, answer string) []byte {
+	msg, _ := json.Marshal(map[string]interface{}{"type": "SUBMIT_ANSWER", "question": question, "answer": answer})
+	return msg
+}
+
+func TestRoom_Presenter(t *testing.T) {
+	hub := NewHub()
+	store := &mockResultStore{}
+	hub.AddResultStore(store)
+	hostID := uuid.New()
+	room := NewRoom("TEST06", hub, 0, []string{"deck:ABC123"}, hostID)
+	room.Decks = map[string][]kana.Char{"deck:ABC123": {{Kana: "ねこ", Romanji: "neko"}}}
+	room.Presenter = true
+	room.QuestionSeconds = 20
+	go room.Run()
+	defer func() { room.stopGame <- true }()
+
+	host := newMockClient(hub, hostID, "Teacher")
+	student := newMockClient(hub, uuid.New(), "Student")
+	room.register <- host
+	room.register <- student
 	time.Sleep(10 * time.Millisecond)
 
-	// Check State
+	// The host runs the quiz without playing
 	vals := room.GetValues()
-	if p, ok := vals.Players[pID]; ok {
-		if p.Score != 100 {
-			t.Errorf("Expected score 100, got %d", p.Score)
+	if _, ok := vals.Players[hostID]; ok || len(vals.Players) != 1 {
+		t.Fatalf("Expected only the student to play, got %v", vals.Players)
+	}
+
+	// Students can't pace the game
+	send := func(c *Client, msg map[string]interface{}) {
+		data, _ := json.Marshal(msg)
+		room.handleRoomMessage(c, data)
+	}
+	send(host, map[string]interface{}{"type": "START_GAME"})
+	send(student, map[string]interface{}{"type": "NEXT_QUESTION"})
+	send(student, map[string]interface{}{"type": "SUBMIT_ANSWER", "question": 1, "answer": "neko"})
+	send(host, map[string]interface{}{"type": "NEXT_QUESTION"})
+
+	next := func(want string) map[string]interface{} {
+		t.Helper()
+		timeout := time.After(200 * time.Millisecond)
+		for {
+			select {
+			case msg := <-student.Send:
+				var parsed map[string]interface{}
+				json.Unmarshal(msg, &parsed)
+				if parsed["type"] == want {
+					return parsed
+				}
+			case <-timeout:
+				t.Fatalf("Timeout waiting for %s", want)
+			}
 		}
-	} else {
-		t.Errorf("Player P1 not found in room")
+	}
+	q := next("QUESTION")
+	if q["question"] != float64(1) || q["prompt"] != "ねこ" {
+		t.Fatalf("Unexpected question %v", q)
 	}
 
-	// Check Broadcast
-	select {
-	case msg := <-c1.Send:
-		var parsed map[string]interface{}
-		json.Unmarshal(msg, &parsed)
-		if parsed["type"] != "SCORE_UPDATE" {
-			t.Errorf("Expected SCORE_UPDATE, got %v", parsed["type"])
+	// The only student answering closes the question early
+	send(student, map[string]interface{}{"type": "SUBMIT_ANSWER", "question": 1, "answer": "neko"})
+	res := next("QUESTION_RESULTS")
+	if res["answer"] != "neko" {
+		t.Errorf("Expected answer neko, got %v", res["answer"])
+	}
+	if dist := res["distribution"].(map[string]interface{}); dist["neko"] != float64(1) {
+		t.Errorf("Expected one neko answer, got %v", dist)
+	}
+	board := res["leaderboard"].([]interface{})
+	if len(board) != 1 || board[0].(map[string]interface{})["score"].(float64) < presenterMaxPoints/2 {
+		t.Errorf("Expected the student to score, got %v", board)
+	}
+
+	// Scores come from the room, not the client
+	send(student, map[string]interface{}{"type": "SUBMIT_SCORE", "score": 99999})
+	send(host, map[string]interface{}{"type": "END_GAME"})
+	next("GAME_OVER")
+	if vals := room.GetValues(); vals.State != StateFinished || vals.Players[student.UserID].Score >= presenterMaxPoints {
+		t.Errorf("Unexpected end state %v %v", vals.State, vals.Players[student.UserID])
+	}
+
+	// The quiz isn't a race, so the top scorer isn't paid as a winner
+	run := store.waitForRuns(t, 1)[0]
+	if run.Mode != ModePresenter || run.Placement != 0 || run.Players != 0 {
+		t.Errorf("Expected an unplaced presenter run, got %+v", run)
+	}
+}
+
+func TestRoom_Raid(t *testing.T) {
+	hub := NewHub()
+	store := &mockResultStore{}
+	hub.AddResultStore(store)
+	hostID := uuid.New()
+	room := NewRoom("TEST07", hub, 60, []string{"deck:ABC123"}, hostID)
+	entries := []kana.Char{
+		{Kana: "ねこ", Romanji: "neko"},
+		{Kana: "いぬ", Romanji: "inu"},
+		{Kana: "とり", Romanji: "tori"},
+		{Kana: "うし", Romanji: "ushi"},
+	}
+	room.Decks = map[string][]kana.Char{"deck:ABC123": entries}
+	room.Raid = true
+	room.BoardSize = 4
+	go room.Run()
+	defer func() { room.stopGame <- true }()
+
+	host := newMockClient(hub, hostID, "Alice")
+	guest := newMockClient(hub, uuid.New(), "Bob")
+	room.register <- host
+	room.register <- guest
+	time.Sleep(10 * time.Millisecond)
+
+	send := func(c *Client, msg map[string]interface{}) {
+		data, _ := json.Marshal(msg)
+		room.handleRoomMessage(c, data)
+	}
+	next := func(want string) []byte {
+		t.Helper()
+		timeout := time.After(200 * time.Millisecond)
+		for {
+			select {
+			case msg := <-guest.Send:
+				var parsed struct {
+					Type string `json:"type"`
+				}
+				json.Unmarshal(msg, &parsed)
+				if parsed.Type == want {
+					return msg
+				}
+			case <-timeout:
+				t.Fatalf("Timeout waiting for %s", want)
+			}
+		}
+	}
+
+	send(host, map[string]interface{}{"type": "START_GAME"})
+	var started struct {
+		EndTime time.Time `json:"endTime"`
+		Board   []struct {
+			Tile   int    `json:"tile"`
+			Prompt string `json:"prompt"`
+		} `json:"board"`
+	}
+	json.Unmarshal(next("GAME_STARTED"), &started)
+	if len(started.Board) != 4 {
+		t.Fatalf("Expected 4 tiles, got %d", len(started.Board))
+	}
+	answers := make(map[string]string)
+	for _, c := range entries {
+		answers[c.Kana] = c.Romanji
+	}
+
+	// A wrong answer costs the team time
+	send(guest, map[string]interface{}{"type": "CLAIM_TILE", "tile": 0, "answer": "wrong"})
+	var penalty struct {
+		EndTime time.Time `json:"endTime"`
+	}
+	json.Unmarshal(next("RAID_PENALTY"), &penalty)
+	if got := started.EndTime.Sub(penalty.EndTime); got != raidPenalty {
+		t.Errorf("Expected the clock to lose %v, lost %v", raidPenalty, got)
+	}
+
+	// Anyone can claim a tile, but only once
+	for _, tile := range started.Board {
+		c := host
+		if tile.Tile%2 == 0 {
+			c = guest
+		}
+		send(c, map[string]interface{}{"type": "CLAIM_TILE", "tile": tile.Tile, "answer": answers[tile.Prompt]})
+		if tile.Tile == 0 {
+			send(host, map[string]interface{}{"type": "CLAIM_TILE", "tile": 0, "answer": answers[tile.Prompt]})
+		}
+	}
+
+	var over struct {
+		Raid struct {
+			Won           bool               `json:"won"`
+			Cleared       int                `json:"cleared"`
+			Contributions []RaidContribution `json:"contributions"`
+		} `json:"raid"`
+	}
+	json.Unmarshal(next("GAME_OVER"), &over)
+	if !over.Raid.Won || over.Raid.Cleared != 4 {
+		t.Fatalf("Expected the board cleared, got %+v", over.Raid)
+	}
+	for _, c := range over.Raid.Contributions {
+		if c.Claimed != 2 {
+			t.Errorf("Expected 2 tiles each, got %+v", c)
+		}
+		if c.Username == "Bob" && c.Mistakes != 1 {
+			t.Errorf("Expected Bob's mistake counted, got %+v", c)
+		}
+	}
+
+	// The team played together, so nobody is paid as the winner
+	for _, run := range store.waitForRuns(t, 2) {
+		if run.Mode != ModeRaid || run.Placement != 0 || run.Players != 0 {
+			t.Errorf("Expected an unplaced raid run, got %+v", run)
 		}
-	case <-time.After(50 * time.Millisecond):
-		t.Error("Did not receive SCORE_UPDATE")
 	}
 }
 
-func TestRoom_SubmitScore_Validation(t *testing.T) {
+func TestRoom_RaidClockRunsOut(t *testing.T) {
 	hub := NewHub()
 	hostID := uuid.New()
-	room := NewRoom("TEST_SEC", hub, 60, []string{"group1"}, hostID)
-	room.State = StatePlaying
+	room := NewRoom("TEST08", hub, 3, []string{"deck:ABC123"}, hostID)
+	room.Decks = map[string][]kana.Char{"deck:ABC123": {
+		{Kana: "ねこ", Romanji: "neko"},
+		{Kana: "いぬ", Romanji: "inu"},
+		{Kana: "とり", Romanji: "tori"},
+		{Kana: "うし", Romanji: "ushi"},
+	}}
+	room.Raid = true
+	room.BoardSize = 4
+	go room.Run()
+	defer func() { room.stopGame <- true }()
+
+	host := newMockClient(hub, hostID, "Alice")
+	room.register <- host
+
+	send := func(msg map[string]interface{}) {
+		data, _ := json.Marshal(msg)
+		room.handleRoomMessage(host, data)
+	}
+	send(map[string]interface{}{"type": "START_GAME"})
+	waitForType(t, host, "GAME_STARTED")
+
+	// The penalty takes the whole 3 second clock, so the raid ends now
+	// rather than when the original clock would have run out
+	send(map[string]interface{}{"type": "CLAIM_TILE", "tile": 0, "answer": "wrong"})
+	over := waitForType(t, host, "GAME_OVER")
+	if raid, _ := over["raid"].(map[string]interface{}); raid["won"] != false {
+		t.Errorf("Expected the raid lost, got %v", over["raid"])
+	}
+}
 
-	pID := uuid.New()
-	room.Players[pID] = &Player{UserID: pID, Username: "Hacker", Score: 0}
+func TestRoom_SlowClientKeepsItsQueue(t *testing.T) {
+	hub := NewHub()
+	room := NewRoom("TEST09", hub, 60, []string{"hiragana"}, uuid.New())
+	slow := &Client{Hub: hub, UserID: uuid.New(), Username: "Slow", Send: make(chan []byte, 1)}
+	room.Clients[slow] = true
+
+	room.broadcastToClients([]byte(`{"type":"FIRST"}`))
+	room.broadcastToClients([]byte(`{"type":"DROPPED"}`))
 
-	c1 := newMockClient(hub, pID, "Hacker")
-	room.Clients[c1] = true
-	c1.Room = room
+	// The hub closes Send when the client leaves; the room mustn't have
+	if msg := <-slow.Send; string(msg) != `{"type":"FIRST"}` {
+		t.Errorf("Unexpected message %s", msg)
+	}
+	select {
+	case _, ok := <-slow.Send:
+		if !ok {
+			t.Fatal("Room closed the client's Send")
+		}
+	default:
+	}
+	if !room.Clients[slow] {
+		t.Error("Expected the slow client to stay in the room")
+	}
+}
 
+func TestRoom_Cosmetics(t *testing.T) {
+	hub := NewHub()
+	hostID, guestID := uuid.New(), uuid.New()
+	room := NewRoom("TEST08", hub, 60, []string{"hiragana"}, hostID)
 	go room.Run()
 	defer func() { room.stopGame <- true }()
 
-	// 1. Try Negative Score
-	badMsg1, _ := json.Marshal(map[string]interface{}{
-		"type":  "SUBMIT_SCORE",
-		"score": -500,
-	})
-	room.handleRoomMessage(c1, badMsg1)
-	time.Sleep(10 * time.Millisecond)
+	host := newMockClient(hub, hostID, "Host")
+	host.Cosmetics = map[string]string{"name_color": "color_gold", "frame": "frame_fuji"}
+	room.register <- host
+	room.register <- newMockClient(hub, guestID, "Guest")
+	time.Sleep(50 * time.Millisecond)
 
-	if room.Players[pID].Score != 0 {
-		t.Errorf("Score should remain 0 after negative submission, got %d", room.Players[pID].Score)
+	// The last ROOM_STATE lists both players
+	var state struct {
+		Type    string             `json:"type"`
+		Players map[string]*Player `json:"players"`
+	}
+	for len(host.Send) > 0 {
+		json.Unmarshal(<-host.Send, &state)
+	}
+	if state.Type != "ROOM_STATE" || len(state.Players) != 2 {
+		t.Fatalf("Unexpected room state: %+v", state)
+	}
+	if got := state.Players[hostID.String()].Cosmetics; got["name_color"] != "color_gold" || got["frame"] != "frame_fuji" {
+		t.Errorf("Expected the host's cosmetics, got %v", got)
+	}
+	if got := state.Players[guestID.String()].Cosmetics; len(got) != 0 {
+		t.Errorf("Expected no cosmetics for the guest, got %v", got)
 	}
+}
 
-	// 2. Try Huge Score
-	badMsg2, _ := json.Marshal(map[string]interface{}{
-		"type":  "SUBMIT_SCORE",
-		"score": 999999,
-	})
-	room.handleRoomMessage(c1, badMsg2)
+func TestRoom_React(t *testing.T) {
+	hub := NewHub()
+	hostID := uuid.New()
+	room := NewRoom("TEST09", hub, 60, []string{"hiragana"}, hostID)
+	go room.Run()
+	defer func() { room.stopGame <- true }()
+
+	host := newMockClient(hub, hostID, "Host")
+	host.Cosmetics = map[string]string{"emote": "emote_cat"}
+	guest := newMockClient(hub, uuid.New(), "Guest")
+	room.register <- host
+	room.register <- guest
 	time.Sleep(10 * time.Millisecond)
 
-	if room.Players[pID].Score != 0 {
-		t.Errorf("Score should remain 0 after huge submission, got %d", room.Players[pID].Score)
+	react := func(c *Client, reaction string) {
+		data, _ := json.Marshal(map[string]string{"type": "REACT", "reaction": reaction})
+		room.handleRoomMessage(c, data)
+	}
+	reactions := func() []string {
+		time.Sleep(20 * time.Millisecond)
+		var got []string
+		for len(guest.Send) > 0 {
+			var msg struct {
+				Type     string    `json:"type"`
+				UserID   uuid.UUID `json:"userId"`
+				Reaction string    `json:"reaction"`
+			}
+			json.Unmarshal(<-guest.Send, &msg)
+			if msg.Type == "REACTION" {
+				got = append(got, msg.UserID.String()+" "+msg.Reaction)
+			}
+		}
+		return got
+	}
+
+	// Not before the game
+	react(host, "すごい!")
+	if got := reactions(); len(got) != 0 {
+		t.Fatalf("Expected no reactions in the lobby, got %v", got)
+	}
+
+	room.action <- func() { room.State = StatePlaying }
+	react(host, "すごい!")
+	react(host, "🐱")                                // the host's equipped emote
+	react(guest, "🐱")                               // not equipped by the guest
+	react(host, "free text")                        // not on the allowlist
+	react(newMockClient(hub, uuid.New(), "X"), "👏") // not in the room
+	want := []string{hostID.String() + " すごい!", hostID.String() + " 🐱"}
+	if got := reactions(); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Expected %v, got %v", want, got)
 	}
 }
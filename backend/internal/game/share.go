@@ -0,0 +1,87 @@
+package game
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Standings are the final results of a game, kept for a while so players
+// can share them.
+type Standings struct {
+	ID         uuid.UUID
+	RoomCode   string
+	Groups     []string
+	Players    []Standing // best first
+	FinishedAt time.Time
+}
+
+type Standing struct {
+	UserID   uuid.UUID `json:"userId"`
+	Username string    `json:"username"`
+	Score    int       `json:"score"`
+	// 1-based; tied scores share a placement
+	Placement int  `json:"placement"`
+	Ghost     bool `json:"ghost,omitempty"`
+}
+
+// ShareStore keeps the standings of finished games.
+type ShareStore interface {
+	SaveStandings(ctx context.Context, standings Standings) error
+}
+
+// SetShareStore records the standings of every finished game, whose ID is
+// sent with GAME_OVER. Must be called before Run.
+func (h *Hub) SetShareStore(store ShareStore) {
+	h.shares = store
+}
+
+// shareResults hands the standings to the share store and returns their ID.
+// Called from the Run loop; the write happens in the background.
+func (r *Room) shareResults() (uuid.UUID, bool) {
+	store := r.Hub.shares
+	if store == nil || len(r.Players) == 0 {
+		return uuid.Nil, false
+	}
+
+	standings := Standings{
+		ID:         uuid.New(),
+		RoomCode:   r.Code,
+		Groups:     r.Groups,
+		FinishedAt: time.Now(),
+	}
+	// Placed as in the results, so a ghost beating the challenger doesn't
+	// push them off the top of the card
+	for id, p := range r.Players {
+		placement, _ := r.rank(p)
+		standings.Players = append(standings.Players, Standing{
+			UserID:    id,
+			Username:  p.Username,
+			Score:     p.Score,
+			Placement: placement,
+			Ghost:     p.Ghost,
+		})
+	}
+	sort.Slice(standings.Players, func(i, j int) bool {
+		a, b := standings.Players[i], standings.Players[j]
+		if a.Placement != b.Placement {
+			return a.Placement < b.Placement
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Username < b.Username
+	})
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := store.SaveStandings(ctx, standings); err != nil {
+			slog.Error("Error saving standings", "error", err, "room", r.Code)
+		}
+	}()
+	return standings.ID, true
+}
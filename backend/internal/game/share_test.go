@@ -0,0 +1,91 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockShareStore struct {
+	saved chan Standings
+}
+
+func (m *mockShareStore) SaveStandings(ctx context.Context, standings Standings) error {
+	m.saved <- standings
+	return nil
+}
+
+func TestRoom_ShareStandings(t *testing.T) {
+	hub := NewHub()
+	store := &mockShareStore{saved: make(chan Standings, 1)}
+	hub.SetShareStore(store)
+
+	hostID := uuid.New()
+	room := NewRoom("SHARE1", hub, 60, []string{"hsingle"}, hostID)
+	go room.Run()
+	defer func() { room.stopGame <- true }()
+
+	host := newMockClient(hub, hostID, "Host")
+	guest := newMockClient(hub, uuid.New(), "Guest")
+	room.register <- host
+	room.register <- guest
+	waitForType(t, host, "ROOM_STATE")
+
+	start, _ := json.Marshal(map[string]interface{}{"type": "START_GAME"})
+	room.handleRoomMessage(host, start)
+	questions, _ := receiveQuestions(t, guest)
+	room.handleRoomMessage(guest, answerMsg(0, rightAnswer(t, questions[0])))
+	waitForType(t, host, "SCORE_UPDATE")
+
+	room.action <- room.endGame
+	over := waitForType(t, host, "GAME_OVER")
+
+	select {
+	case standings := <-store.saved:
+		if over["shareId"] != standings.ID.String() {
+			t.Errorf("Expected GAME_OVER to carry share ID %s, got %v", standings.ID, over["shareId"])
+		}
+		if standings.RoomCode != "SHARE1" || len(standings.Players) != 2 {
+			t.Fatalf("Unexpected standings: %+v", standings)
+		}
+		if first := standings.Players[0]; first.Username != "Guest" || first.Score != 1 || first.Placement != 1 {
+			t.Errorf("Expected the guest first, got %+v", standings.Players)
+		}
+		if standings.Players[1].Placement != 2 {
+			t.Errorf("Expected the host second, got %+v", standings.Players[1])
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for standings to be saved")
+	}
+}
+
+func TestRoom_ShareStandingsOfGhostRace(t *testing.T) {
+	hub := NewHub()
+	store := &mockShareStore{saved: make(chan Standings, 1)}
+	hub.SetShareStore(store)
+
+	challengerID := uuid.New()
+	ghost := Ghost{UserID: uuid.New(), Username: "GhostUser", Groups: []string{"hsingle"}, Duration: 60}
+	room := NewGhostRoom("SHARE2", hub, ghost, challengerID)
+	room.Players[challengerID] = &Player{UserID: challengerID, Username: "Challenger", Score: 3}
+	room.Players[ghost.UserID].Score = 5
+
+	if _, ok := room.shareResults(); !ok {
+		t.Fatal("Expected the standings to be shared")
+	}
+	standings := <-store.saved
+
+	// The card places the challenger as the results do: a ghost isn't an opponent
+	placement, _ := room.rank(room.Players[challengerID])
+	for _, p := range standings.Players {
+		if p.UserID == challengerID && p.Placement != placement {
+			t.Errorf("Expected placement %d as in the results, got %+v", placement, p)
+		}
+	}
+	if standings.Players[0].UserID != ghost.UserID {
+		t.Errorf("Expected the higher scoring ghost listed first, got %+v", standings.Players)
+	}
+}
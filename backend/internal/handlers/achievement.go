@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+)
+
+type AchievementHandler struct {
+	achievementService service.AchievementService
+}
+
+func NewAchievementHandler(achievementService service.AchievementService) *AchievementHandler {
+	return &AchievementHandler{
+		achievementService: achievementService,
+	}
+}
+
+func (h *AchievementHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	achievements, err := h.achievementService.List(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load achievements", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, achievements)
+}
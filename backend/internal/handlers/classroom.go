@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+type ClassroomHandler struct {
+	classroomService service.ClassroomService
+}
+
+func NewClassroomHandler(classroomService service.ClassroomService) *ClassroomHandler {
+	return &ClassroomHandler{
+		classroomService: classroomService,
+	}
+}
+
+func (h *ClassroomHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	var params dto.CreateClassRequest
+	if !decodeClassRequest(w, r, &params) {
+		return
+	}
+
+	response, err := h.classroomService.CreateClass(r.Context(), userID, params)
+	if err != nil {
+		respondWithClassError(w, err, "Couldn't create class")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, response)
+}
+
+func (h *ClassroomHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	classes, err := h.classroomService.ListClasses(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load classes", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, classes)
+}
+
+func (h *ClassroomHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	classID, ok := classIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.classroomService.GetClass(r.Context(), userID, classID)
+	if err != nil {
+		respondWithClassError(w, err, "Couldn't load class")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *ClassroomHandler) Join(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	var params dto.JoinClassRequest
+	if !decodeClassRequest(w, r, &params) {
+		return
+	}
+
+	response, err := h.classroomService.JoinClass(r.Context(), userID, params.Code)
+	if err != nil {
+		respondWithClassError(w, err, "Couldn't join class")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+// RemoveMember removes a student; students may remove themselves to leave.
+func (h *ClassroomHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	classID, ok := classIDParam(w, r)
+	if !ok {
+		return
+	}
+	memberID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid user ID", nil)
+		return
+	}
+
+	if err := h.classroomService.RemoveMember(r.Context(), userID, classID, memberID); err != nil {
+		respondWithClassError(w, err, "Couldn't remove member")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ClassroomHandler) CreateAssignment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	classID, ok := classIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	var params dto.CreateAssignmentRequest
+	if !decodeClassRequest(w, r, &params) {
+		return
+	}
+
+	response, err := h.classroomService.CreateAssignment(r.Context(), userID, classID, params)
+	if err != nil {
+		respondWithClassError(w, err, "Couldn't create assignment")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, response)
+}
+
+// Report returns every student's progress as JSON, or as a CSV download
+// with ?format=csv.
+func (h *ClassroomHandler) Report(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	classID, ok := classIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+		report, err := h.classroomService.Report(r.Context(), userID, classID)
+		if err != nil {
+			respondWithClassError(w, err, "Couldn't build report")
+			return
+		}
+		utils.RespondWithJSON(w, http.StatusOK, report)
+
+	case "csv":
+		// Buffered so errors can still be reported as JSON
+		var buf bytes.Buffer
+		if err := h.classroomService.WriteReportCSV(r.Context(), userID, classID, &buf); err != nil {
+			respondWithClassError(w, err, "Couldn't build report")
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="class-report-`+classID.String()+`.csv"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write(buf.Bytes())
+
+	default:
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "format must be json or csv", nil)
+	}
+}
+
+// CreateBattle opens a battle room only the class's members can join.
+func (h *ClassroomHandler) CreateBattle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	classID, ok := classIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	var params dto.CreateRoomRequest
+	if !decodeClassRequest(w, r, &params) {
+		return
+	}
+	direction, err := kana.ParseDirection(params.Direction)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	response, err := h.classroomService.CreateBattle(r.Context(), userID, classID, game.RoomOptions{
+		Duration:        params.Duration,
+		Groups:          params.Groups,
+		Direction:       direction,
+		Choices:         params.Choices,
+		Presenter:       params.Presenter,
+		QuestionSeconds: params.QuestionSeconds,
+		Raid:            params.Raid,
+		BoardSize:       params.BoardSize,
+	})
+	if err != nil {
+		respondWithClassError(w, err, "Couldn't create room")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, response)
+}
+
+func classIDParam(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	classID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid class ID", nil)
+		return uuid.Nil, false
+	}
+	return classID, true
+}
+
+func decodeClassRequest(w http.ResponseWriter, r *http.Request, params any) bool {
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid JSON", err)
+		return false
+	}
+	if err := utils.ValidateStruct(params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return false
+	}
+	return true
+}
+
+func respondWithClassError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrClassNotFound), errors.Is(err, service.ErrClassMemberAbsent):
+		utils.RespondWithErrorJSON(w, http.StatusNotFound, err.Error(), nil)
+	case errors.Is(err, service.ErrNotTeacher), errors.Is(err, service.ErrNotClassTeacher):
+		utils.RespondWithErrorJSON(w, http.StatusForbidden, err.Error(), nil)
+	case errors.Is(err, service.ErrOwnClass),
+		errors.Is(err, service.ErrDueInPast),
+		errors.Is(err, service.ErrEmptyAssignment),
+		errors.Is(err, kana.ErrUnknownGroup),
+		errors.Is(err, game.ErrUnknownDeck),
+		errors.Is(err, game.ErrInvalidDuration),
+		errors.Is(err, game.ErrInvalidChoices),
+		errors.Is(err, game.ErrInvalidQuestionSeconds),
+		errors.Is(err, game.ErrInvalidBoardSize),
+		errors.Is(err, game.ErrConflictingModes):
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+	default:
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, msg, err)
+	}
+}
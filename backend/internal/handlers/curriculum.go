@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+)
+
+type CurriculumHandler struct {
+	curriculumService service.CurriculumService
+}
+
+func NewCurriculumHandler(curriculumService service.CurriculumService) *CurriculumHandler {
+	return &CurriculumHandler{
+		curriculumService: curriculumService,
+	}
+}
+
+// Get returns the lesson plan with the user's progress through it.
+func (h *CurriculumHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	curriculum, err := h.curriculumService.Get(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load curriculum", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, curriculum)
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+)
+
+const (
+	defaultLeaderboardLimit = 50
+	maxLeaderboardLimit     = 100
+)
+
+type DailyHandler struct {
+	dailyService service.DailyService
+}
+
+func NewDailyHandler(dailyService service.DailyService) *DailyHandler {
+	return &DailyHandler{
+		dailyService: dailyService,
+	}
+}
+
+func (h *DailyHandler) GetToday(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	response, err := h.dailyService.GetToday(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load daily challenge", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *DailyHandler) Start(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	response, err := h.dailyService.Start(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrDailyAlreadyPlayed) {
+			utils.RespondWithErrorJSON(w, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't start daily challenge", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *DailyHandler) Finish(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	params := dto.DailyFinishRequest{}
+	if err := decoder.Decode(&params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid JSON", err)
+		return
+	}
+
+	if err := utils.ValidateStruct(params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	response, err := h.dailyService.Finish(r.Context(), userID, params)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrDailyNotStarted):
+			utils.RespondWithErrorJSON(w, http.StatusNotFound, err.Error(), nil)
+		case errors.Is(err, service.ErrDailyAlreadyPlayed):
+			utils.RespondWithErrorJSON(w, http.StatusConflict, err.Error(), nil)
+		default:
+			utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't finish daily challenge", err)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *DailyHandler) Leaderboard(w http.ResponseWriter, r *http.Request) {
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	if d := r.URL.Query().Get("date"); d != "" {
+		parsed, err := time.Parse("2006-01-02", d)
+		if err != nil {
+			utils.RespondWithErrorJSON(w, http.StatusBadRequest, "date must be YYYY-MM-DD", nil)
+			return
+		}
+		date = parsed
+	}
+
+	limit, err := parseLimit(r, defaultLeaderboardLimit, maxLeaderboardLimit)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	entries, err := h.dailyService.Leaderboard(r.Context(), date, limit)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load leaderboard", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, entries)
+}
+
+// parseLimit reads the optional ?limit= query parameter.
+func parseLimit(r *http.Request, def, max int) (int, error) {
+	return parseIntParam(r, "limit", def, 1, max)
+}
+
+// parseIntParam reads an optional integer query parameter within min and max.
+func parseIntParam(r *http.Request, name string, def, min, max int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%s must be between %d and %d", name, min, max)
+	}
+	return n, nil
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+)
+
+type DeckHandler struct {
+	deckService service.DeckService
+}
+
+func NewDeckHandler(deckService service.DeckService) *DeckHandler {
+	return &DeckHandler{
+		deckService: deckService,
+	}
+}
+
+func (h *DeckHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	params, ok := decodeDeckRequest(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.deckService.Create(r.Context(), userID, params)
+	if err != nil {
+		respondWithDeckError(w, err, "Couldn't create deck")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, response)
+}
+
+func (h *DeckHandler) ListMine(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	decks, err := h.deckService.ListMine(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load decks", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, decks)
+}
+
+func (h *DeckHandler) Get(w http.ResponseWriter, r *http.Request) {
+	code := strings.ToUpper(r.PathValue("code"))
+
+	response, err := h.deckService.Get(r.Context(), code)
+	if err != nil {
+		respondWithDeckError(w, err, "Couldn't load deck")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *DeckHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	code := strings.ToUpper(r.PathValue("code"))
+
+	params, ok := decodeDeckRequest(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.deckService.Update(r.Context(), userID, code, params)
+	if err != nil {
+		respondWithDeckError(w, err, "Couldn't update deck")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *DeckHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	code := strings.ToUpper(r.PathValue("code"))
+
+	if err := h.deckService.Delete(r.Context(), userID, code); err != nil {
+		respondWithDeckError(w, err, "Couldn't delete deck")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeDeckRequest(w http.ResponseWriter, r *http.Request) (dto.DeckRequest, bool) {
+	decoder := json.NewDecoder(r.Body)
+	params := dto.DeckRequest{}
+	if err := decoder.Decode(&params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid JSON", err)
+		return params, false
+	}
+
+	if err := utils.ValidateStruct(params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return params, false
+	}
+	return params, true
+}
+
+func respondWithDeckError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrDeckNotFound):
+		utils.RespondWithErrorJSON(w, http.StatusNotFound, err.Error(), nil)
+	case errors.Is(err, service.ErrNotDeckOwner):
+		utils.RespondWithErrorJSON(w, http.StatusForbidden, err.Error(), nil)
+	case errors.Is(err, service.ErrInvalidDeckName), errors.Is(err, service.ErrInvalidDeckEntry):
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+	default:
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, msg, err)
+	}
+}
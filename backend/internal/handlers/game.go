@@ -2,28 +2,34 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
-	"github.com/Cadimodev/haiji/backend/internal/auth"
 	"github.com/Cadimodev/haiji/backend/internal/config"
 	"github.com/Cadimodev/haiji/backend/internal/database"
 	"github.com/Cadimodev/haiji/backend/internal/dto"
 	"github.com/Cadimodev/haiji/backend/internal/game"
 	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
 	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/romaji"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+	"github.com/Cadimodev/haiji/backend/internal/tickets"
 )
 
 type GameHandler struct {
-	db     database.Querier
-	config *config.ApiConfig
-	hub    *game.Hub
+	db      database.Querier
+	config  *config.ApiConfig
+	hub     *game.Hub
+	tickets tickets.Store
 }
 
-func NewGameHandler(db database.Querier, cfg *config.ApiConfig, hub *game.Hub) *GameHandler {
+func NewGameHandler(db database.Querier, cfg *config.ApiConfig, hub *game.Hub, ticketStore tickets.Store) *GameHandler {
 	return &GameHandler{
-		db:     db,
-		config: cfg,
-		hub:    hub,
+		db:      db,
+		config:  cfg,
+		hub:     hub,
+		tickets: ticketStore,
 	}
 }
 
@@ -46,22 +52,75 @@ func (h *GameHandler) CreateRoom(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	code := h.hub.CreateRoom(params.Duration, params.Groups, userID)
+	direction, err := kana.ParseDirection(params.Direction)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	code, err := h.hub.CreateRoom(game.RoomOptions{
+		Duration:        params.Duration,
+		Groups:          params.Groups,
+		Direction:       direction,
+		Choices:         params.Choices,
+		Presenter:       params.Presenter,
+		QuestionSeconds: params.QuestionSeconds,
+		Raid:            params.Raid,
+		BoardSize:       params.BoardSize,
+	}, userID)
+	switch {
+	case errors.Is(err, game.ErrUnknownDeck),
+		errors.Is(err, game.ErrInvalidDuration),
+		errors.Is(err, game.ErrInvalidChoices),
+		errors.Is(err, game.ErrInvalidQuestionSeconds),
+		errors.Is(err, game.ErrInvalidBoardSize),
+		errors.Is(err, game.ErrConflictingModes):
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	case err != nil:
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't create room", err)
+		return
+	}
 
 	// Return code
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{"code": code})
 }
 
+// IssueWSTicket returns a short-lived, single-use ticket to open /api/ws.
+func (h *GameHandler) IssueWSTicket(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	ip := ""
+	if h.config.WSTicketBindIP {
+		ip = clientIPString(r)
+	}
+
+	ticket, err := tickets.Issue(r.Context(), h.tickets, userID, ip, tickets.DefaultTTL)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't issue ticket", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, dto.WSTicketResponse{
+		Ticket:    ticket,
+		ExpiresIn: int(tickets.DefaultTTL.Seconds()),
+	})
+}
+
 func (h *GameHandler) HandleWS(w http.ResponseWriter, r *http.Request) {
-	// Auth via Query Param
-	tokenString := r.URL.Query().Get("token")
-	if tokenString == "" {
+	// Auth via one-time ticket (see IssueWSTicket)
+	ticket := r.URL.Query().Get("ticket")
+	if ticket == "" {
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
-	userID, err := auth.ValidateJWT(tokenString, h.config.JWTSecret)
+	userID, err := tickets.Redeem(r.Context(), h.tickets, ticket, clientIPString(r))
 	if err != nil {
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
@@ -70,9 +129,24 @@ func (h *GameHandler) HandleWS(w http.ResponseWriter, r *http.Request) {
 	// Retrieve username
 	user, err := h.db.GetUserByID(r.Context(), userID)
 	username := "Guest"
+	// Typed answers are graded in the user's romanization systems, as in
+	// practice; in any system if they can't be loaded
+	var systems []romaji.System
 	if err == nil {
 		username = user.Username
+		systems, _ = romaji.ParseSystems(user.RomajiSystems)
 	}
 
-	game.ServeWs(h.hub, w, r, userID, username)
+	// Cosmetics are only for show; play without them if they can't be loaded
+	cosmetics, _ := service.EquippedCosmetics(r.Context(), h.db, userID)
+
+	game.ServeWs(h.hub, w, r, userID, username, cosmetics, systems)
+}
+
+func clientIPString(r *http.Request) string {
+	ip, _ := utils.GetClientInfo(r)
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
 }
@@ -8,9 +8,11 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/Cadimodev/haiji/backend/internal/config"
 	"github.com/Cadimodev/haiji/backend/internal/dto"
 	"github.com/Cadimodev/haiji/backend/internal/game"
 	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/tickets"
 	"github.com/google/uuid"
 )
 
@@ -19,7 +21,7 @@ func TestGameHandler_CreateRoom_Validation(t *testing.T) {
 	// We don't need a real DB for validation tests bc it fails before DB calls
 	hub := game.NewHub()
 	go hub.Run() // Start hub to avoid blocking if we accidentally pass validation
-	handler := NewGameHandler(nil, nil, hub)
+	handler := NewGameHandler(nil, nil, hub, tickets.NewMemoryStore())
 
 	tests := []struct {
 		name           string
@@ -54,6 +56,16 @@ func TestGameHandler_CreateRoom_Validation(t *testing.T) {
 			userInContext:  true,
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name: "Invalid Choices (Too Many)",
+			body: dto.CreateRoomRequest{
+				Duration: 60,
+				Groups:   []string{"hiragana"},
+				Choices:  9, // Max is 6
+			},
+			userInContext:  true,
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name: "Unauthorized (No User in Context)",
 			body: dto.CreateRoomRequest{
@@ -88,3 +100,49 @@ func TestGameHandler_CreateRoom_Validation(t *testing.T) {
 		})
 	}
 }
+
+func TestGameHandler_WSTicket(t *testing.T) {
+	hub := game.NewHub()
+	go hub.Run()
+	store := tickets.NewMemoryStore()
+	handler := NewGameHandler(nil, &config.ApiConfig{}, hub, store)
+
+	// 1. Issue ticket
+	req, _ := http.NewRequest("POST", "/api/ws-ticket", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, uuid.New()))
+	rr := httptest.NewRecorder()
+	handler.IssueWSTicket(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("IssueWSTicket() status = %v, want %v", rr.Code, http.StatusOK)
+	}
+	var resp dto.WSTicketResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if resp.Ticket == "" {
+		t.Fatal("Expected a ticket in response")
+	}
+
+	// 2. Redeem it out of band, the websocket must reject the replay
+	if _, err := tickets.Redeem(context.Background(), store, resp.Ticket, ""); err != nil {
+		t.Fatalf("Redeem: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{name: "Missing ticket", url: "/api/ws"},
+		{name: "Replayed ticket", url: "/api/ws?ticket=" + resp.Ticket},
+		{name: "JWT no longer accepted", url: "/api/ws?token=some.jwt.value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			rr := httptest.NewRecorder()
+			handler.HandleWS(rr, req)
+			if rr.Code != http.StatusUnauthorized {
+				t.Errorf("HandleWS() status = %v, want %v", rr.Code, http.StatusUnauthorized)
+			}
+		})
+	}
+}
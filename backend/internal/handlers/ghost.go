@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+)
+
+const (
+	defaultHistoryLimit = 20
+	maxHistoryLimit     = 100
+)
+
+type GhostHandler struct {
+	ghostService service.GhostService
+}
+
+func NewGhostHandler(ghostService service.GhostService) *GhostHandler {
+	return &GhostHandler{
+		ghostService: ghostService,
+	}
+}
+
+func (h *GhostHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	limit, err := parseLimit(r, defaultHistoryLimit, maxHistoryLimit)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	runs, err := h.ghostService.ListRuns(r.Context(), userID, limit)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load runs", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, runs)
+}
+
+func (h *GhostHandler) CreateChallenge(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	params := dto.CreateGhostChallengeRequest{}
+	if err := decoder.Decode(&params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid JSON", err)
+		return
+	}
+
+	if err := utils.ValidateStruct(params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	response, err := h.ghostService.CreateChallenge(r.Context(), userID, params.RunID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrRunNotFound):
+			utils.RespondWithErrorJSON(w, http.StatusNotFound, err.Error(), nil)
+		case errors.Is(err, service.ErrNotRunOwner):
+			utils.RespondWithErrorJSON(w, http.StatusForbidden, err.Error(), nil)
+		case errors.Is(err, service.ErrRunNotRaceable):
+			utils.RespondWithErrorJSON(w, http.StatusConflict, err.Error(), nil)
+		default:
+			utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't create challenge", err)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, response)
+}
+
+func (h *GhostHandler) GetChallenge(w http.ResponseWriter, r *http.Request) {
+	code := strings.ToUpper(r.PathValue("code"))
+
+	response, err := h.ghostService.GetChallenge(r.Context(), code)
+	if err != nil {
+		if errors.Is(err, service.ErrChallengeNotFound) {
+			utils.RespondWithErrorJSON(w, http.StatusNotFound, err.Error(), nil)
+			return
+		}
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load challenge", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *GhostHandler) PlayChallenge(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	code := strings.ToUpper(r.PathValue("code"))
+
+	response, err := h.ghostService.PlayChallenge(r.Context(), userID, code)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrChallengeNotFound):
+			utils.RespondWithErrorJSON(w, http.StatusNotFound, err.Error(), nil)
+		case errors.Is(err, service.ErrOwnGhost), errors.Is(err, service.ErrRunNotRaceable):
+			utils.RespondWithErrorJSON(w, http.StatusConflict, err.Error(), nil)
+		case errors.Is(err, game.ErrUnknownDeck):
+			utils.RespondWithErrorJSON(w, http.StatusGone, "The deck this run used was deleted", nil)
+		default:
+			utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't start ghost race", err)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, response)
+}
+
+func (h *GhostHandler) ListResults(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	limit, err := parseLimit(r, defaultHistoryLimit, maxHistoryLimit)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	results, err := h.ghostService.ListResults(r.Context(), userID, limit)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load ghost results", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, results)
+}
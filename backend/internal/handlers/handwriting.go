@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/handwriting"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+)
+
+const defaultRecognizeLimit = 5
+
+type HandwritingHandler struct{}
+
+func NewHandwritingHandler() *HandwritingHandler {
+	return &HandwritingHandler{}
+}
+
+func (h *HandwritingHandler) Recognize(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	params := dto.RecognizeRequest{}
+	if err := decoder.Decode(&params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid JSON", err)
+		return
+	}
+
+	if err := utils.ValidateStruct(params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	var filter func(string) bool
+	if params.Category != "" {
+		filter = func(k string) bool {
+			g, _ := kana.GroupOf(k)
+			return g.Category == kana.Category(params.Category)
+		}
+	}
+	limit := params.Limit
+	if limit == 0 {
+		limit = defaultRecognizeLimit
+	}
+
+	candidates, err := handwriting.Recognize(params.Strokes, filter, limit)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	response := make([]dto.RecognizeCandidateResponse, len(candidates))
+	for i, c := range candidates {
+		char, _ := kana.Lookup(c.Kana)
+		response[i] = dto.RecognizeCandidateResponse{
+			Kana:       c.Kana,
+			Romanji:    char.Romanji,
+			Confidence: c.Confidence,
+		}
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+)
+
+const (
+	defaultKanjiPage = 100
+	maxKanjiPage     = 500
+)
+
+type KanjiHandler struct {
+	kanjiService service.KanjiService
+}
+
+func NewKanjiHandler(kanjiService service.KanjiService) *KanjiHandler {
+	return &KanjiHandler{
+		kanjiService: kanjiService,
+	}
+}
+
+func (h *KanjiHandler) Levels(w http.ResponseWriter, r *http.Request) {
+	levels, err := h.kanjiService.Levels(r.Context())
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load kanji levels", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, levels)
+}
+
+// List pages through the kanji of ?jlpt=N, most frequent first, with the
+// optional ?limit= and ?offset= parameters.
+func (h *KanjiHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	level, err := strconv.Atoi(query.Get("jlpt"))
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, service.ErrInvalidJLPTLevel.Error(), nil)
+		return
+	}
+	limit, offset := defaultKanjiPage, 0
+	if raw := query.Get("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxKanjiPage {
+			utils.RespondWithErrorJSON(w, http.StatusBadRequest, "limit must be between 1 and "+strconv.Itoa(maxKanjiPage), nil)
+			return
+		}
+	}
+	if raw := query.Get("offset"); raw != "" {
+		offset, err = strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			utils.RespondWithErrorJSON(w, http.StatusBadRequest, "offset can't be negative", nil)
+			return
+		}
+	}
+
+	kanji, err := h.kanjiService.ListByJLPT(r.Context(), level, limit, offset)
+	if errors.Is(err, service.ErrInvalidJLPTLevel) {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load kanji", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, kanji)
+}
+
+func (h *KanjiHandler) Get(w http.ResponseWriter, r *http.Request) {
+	kanji, err := h.kanjiService.Get(r.Context(), r.PathValue("literal"))
+	if errors.Is(err, service.ErrKanjiNotFound) {
+		utils.RespondWithErrorJSON(w, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load kanji", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, kanji)
+}
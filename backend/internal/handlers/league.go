@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+)
+
+type LeagueHandler struct {
+	leagueService service.LeagueService
+}
+
+func NewLeagueHandler(leagueService service.LeagueService) *LeagueHandler {
+	return &LeagueHandler{
+		leagueService: leagueService,
+	}
+}
+
+// Get returns the standings of the caller's bucket this week.
+func (h *LeagueHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	league, err := h.leagueService.Get(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load league", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, league)
+}
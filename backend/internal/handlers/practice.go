@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/handwriting"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+)
+
+const (
+	defaultStatsDays    = 90
+	defaultActivityDays = 365
+	maxStatsDays        = 730
+
+	defaultConfusionLimit = 20
+	maxConfusionLimit     = 200
+)
+
+// Errors caused by the submitted session rather than the server.
+var invalidSessionErrors = []error{
+	service.ErrUnknownKana,
+	service.ErrInvalidSessionTime,
+	service.ErrInvalidAnswerTime,
+	service.ErrHandwritingDirection,
+	game.ErrUnknownDeck,
+	kana.ErrUnknownDirection,
+	handwriting.ErrNoTemplate,
+	handwriting.ErrNoInk,
+}
+
+type PracticeHandler struct {
+	practiceService service.PracticeService
+}
+
+func NewPracticeHandler(practiceService service.PracticeService) *PracticeHandler {
+	return &PracticeHandler{
+		practiceService: practiceService,
+	}
+}
+
+func (h *PracticeHandler) RecordSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	params := dto.PracticeSessionRequest{}
+	if err := decoder.Decode(&params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid JSON", err)
+		return
+	}
+
+	if err := utils.ValidateStruct(params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	response, err := h.practiceService.RecordSession(r.Context(), userID, params)
+	if err != nil {
+		for _, target := range invalidSessionErrors {
+			if errors.Is(err, target) {
+				utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+				return
+			}
+		}
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't save practice session", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, response)
+}
+
+func (h *PracticeHandler) CharacterStats(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	since, err := parseSince(r, defaultStatsDays)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	stats, err := h.practiceService.CharacterStats(r.Context(), userID, since)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load statistics", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, stats)
+}
+
+func (h *PracticeHandler) Confusions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	since, err := parseSince(r, defaultStatsDays)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	limit, err := parseLimit(r, defaultConfusionLimit, maxConfusionLimit)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	confusions, err := h.practiceService.Confusions(r.Context(), userID, since, limit)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load statistics", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, confusions)
+}
+
+func (h *PracticeHandler) Activity(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	since, err := parseSince(r, defaultActivityDays)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	// Days are bucketed in the caller's time zone (IANA name, default UTC)
+	tz := r.URL.Query().Get("tz")
+	loc, err := time.LoadLocation(tz)
+	if err != nil || tz == "Local" {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "tz must be an IANA time zone such as Europe/Madrid", nil)
+		return
+	}
+
+	days, err := h.practiceService.Activity(r.Context(), userID, since, loc)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load activity", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, days)
+}
+
+// parseSince reads the optional ?days= query parameter as a time window
+// ending now.
+func parseSince(r *http.Request, def int) (time.Time, error) {
+	days := def
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		var err error
+		days, err = strconv.Atoi(raw)
+		if err != nil || days < 1 || days > maxStatsDays {
+			return time.Time{}, errors.New("days must be between 1 and " + strconv.Itoa(maxStatsDays))
+		}
+	}
+	return time.Now().AddDate(0, 0, -days), nil
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+)
+
+type ProgressionHandler struct {
+	progressionService service.ProgressionService
+}
+
+func NewProgressionHandler(progressionService service.ProgressionService) *ProgressionHandler {
+	return &ProgressionHandler{
+		progressionService: progressionService,
+	}
+}
+
+func (h *ProgressionHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	params := dto.UpdateSettingsRequest{}
+	if err := decoder.Decode(&params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid JSON", err)
+		return
+	}
+
+	if err := utils.ValidateStruct(params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	user, err := h.progressionService.UpdateSettings(r.Context(), userID, params)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTimeZone) || errors.Is(err, service.ErrInvalidRomajiSystem) {
+			utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't update settings", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, user)
+}
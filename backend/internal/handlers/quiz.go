@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/quiz"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+)
+
+const (
+	defaultQuizQuestions = 20
+	maxQuizQuestions     = 100
+)
+
+type QuizHandler struct {
+	quizService service.QuizService
+}
+
+func NewQuizHandler(quizService service.QuizService) *QuizHandler {
+	return &QuizHandler{
+		quizService: quizService,
+	}
+}
+
+// Generate returns multiple-choice questions on ?groups= (comma separated),
+// with the optional ?n=, ?choices= and ?direction= parameters.
+func (h *QuizHandler) Generate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	raw := r.URL.Query().Get("groups")
+	if raw == "" {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "groups is required", nil)
+		return
+	}
+	groups := strings.Split(raw, ",")
+	n, err := parseIntParam(r, "n", defaultQuizQuestions, 1, maxQuizQuestions)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	choices, err := parseIntParam(r, "choices", quiz.DefaultChoices, quiz.MinChoices, quiz.MaxChoices)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	direction, err := kana.ParseDirection(r.URL.Query().Get("direction"))
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	questions, err := h.quizService.Generate(r.Context(), userID, groups, n, choices, direction)
+	if errors.Is(err, kana.ErrUnknownGroup) || errors.Is(err, game.ErrUnknownDeck) || errors.Is(err, service.ErrEmptyQuiz) {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't generate quiz", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, questions)
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+)
+
+const (
+	defaultReadingCount = 10
+	maxReadingCount     = 50
+)
+
+type ReadingHandler struct {
+	readingService service.ReadingService
+}
+
+func NewReadingHandler(readingService service.ReadingService) *ReadingHandler {
+	return &ReadingHandler{
+		readingService: readingService,
+	}
+}
+
+// Next returns words or sentences readable with the kana the user has
+// mastered. ?kind= picks word or sentence and ?count= how many.
+func (h *ReadingHandler) Next(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	count := defaultReadingCount
+	if raw := r.URL.Query().Get("count"); raw != "" {
+		var err error
+		count, err = strconv.Atoi(raw)
+		if err != nil || count < 1 || count > maxReadingCount {
+			utils.RespondWithErrorJSON(w, http.StatusBadRequest, "count must be between 1 and "+strconv.Itoa(maxReadingCount), nil)
+			return
+		}
+	}
+
+	items, err := h.readingService.Next(r.Context(), userID, r.URL.Query().Get("kind"), count)
+	if errors.Is(err, service.ErrInvalidReadingKind) {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load reading practice", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, items)
+}
+
+func (h *ReadingHandler) Check(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	params := dto.ReadingAnswerRequest{}
+	if err := decoder.Decode(&params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid JSON", err)
+		return
+	}
+	if err := utils.ValidateStruct(params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	response, err := h.readingService.Check(r.Context(), userID, params)
+	if errors.Is(err, service.ErrReadingItemNotFound) {
+		utils.RespondWithErrorJSON(w, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't check answer", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
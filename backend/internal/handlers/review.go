@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+)
+
+const (
+	defaultReviewLimit = 20
+	maxReviewLimit     = 100
+)
+
+type ReviewHandler struct {
+	reviewService service.ReviewService
+}
+
+func NewReviewHandler(reviewService service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+func (h *ReviewHandler) Due(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	limit, err := parseLimit(r, defaultReviewLimit, maxReviewLimit)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	var groups []string
+	if raw := r.URL.Query().Get("groups"); raw != "" {
+		for _, id := range strings.Split(raw, ",") {
+			if _, ok := kana.GetGroup(id); !ok {
+				utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Unknown kana group: "+id, nil)
+				return
+			}
+			groups = append(groups, id)
+		}
+	}
+
+	response, err := h.reviewService.Due(r.Context(), userID, groups, limit)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load reviews", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *ReviewHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	params := dto.SubmitReviewsRequest{}
+	if err := decoder.Decode(&params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid JSON", err)
+		return
+	}
+
+	if err := utils.ValidateStruct(params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	response, err := h.reviewService.Submit(r.Context(), userID, params)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownKana) {
+			utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't save reviews", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+type ShareHandler struct {
+	shareService service.ShareService
+}
+
+func NewShareHandler(shareService service.ShareService) *ShareHandler {
+	return &ShareHandler{
+		shareService: shareService,
+	}
+}
+
+// Card serves the results card of a finished game. Cards are public so they
+// can be posted anywhere.
+func (h *ShareHandler) Card(w http.ResponseWriter, r *http.Request) {
+	shareID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid share ID", nil)
+		return
+	}
+
+	path, err := h.shareService.CardPath(r.Context(), shareID)
+	if errors.Is(err, service.ErrShareNotFound) {
+		utils.RespondWithErrorJSON(w, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't render card", err)
+		return
+	}
+
+	// A share's card never changes
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	http.ServeFile(w, r, path)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+)
+
+type ShopHandler struct {
+	shopService service.ShopService
+}
+
+func NewShopHandler(shopService service.ShopService) *ShopHandler {
+	return &ShopHandler{
+		shopService: shopService,
+	}
+}
+
+// Catalog lists every item with whether the caller owns and wears it.
+func (h *ShopHandler) Catalog(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	catalog, err := h.shopService.Catalog(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load shop", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, catalog)
+}
+
+func (h *ShopHandler) Purchase(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	var params dto.PurchaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid JSON", err)
+		return
+	}
+	if err := utils.ValidateStruct(params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	response, err := h.shopService.Purchase(r.Context(), userID, params.ItemID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnknownItem):
+			utils.RespondWithErrorJSON(w, http.StatusNotFound, err.Error(), nil)
+		case errors.Is(err, service.ErrItemOwned), errors.Is(err, service.ErrInsufficientCoins):
+			utils.RespondWithErrorJSON(w, http.StatusConflict, err.Error(), nil)
+		default:
+			utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't complete purchase", err)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, response)
+}
+
+func (h *ShopHandler) Equip(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	var params dto.EquipRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid JSON", err)
+		return
+	}
+	if err := utils.ValidateStruct(params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	response, err := h.shopService.Equip(r.Context(), userID, params.ItemID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnknownItem):
+			utils.RespondWithErrorJSON(w, http.StatusNotFound, err.Error(), nil)
+		case errors.Is(err, service.ErrItemNotOwned):
+			utils.RespondWithErrorJSON(w, http.StatusForbidden, err.Error(), nil)
+		default:
+			utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't equip item", err)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *ShopHandler) Unequip(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	response, err := h.shopService.Unequip(r.Context(), userID, r.PathValue("slot"))
+	if errors.Is(err, service.ErrUnknownSlot) {
+		utils.RespondWithErrorJSON(w, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't unequip item", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+// Wallet returns the caller's balance and latest coin movements.
+func (h *ShopHandler) Wallet(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	limit, err := parseLimit(r, defaultHistoryLimit, maxHistoryLimit)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	wallet, err := h.shopService.Wallet(r.Context(), userID, limit)
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load wallet", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, wallet)
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/handlers/utils"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/middleware"
+	"github.com/Cadimodev/haiji/backend/internal/service"
+	"github.com/Cadimodev/haiji/backend/internal/sprint"
+)
+
+type SprintHandler struct {
+	sprintService service.SprintService
+}
+
+func NewSprintHandler(sprintService service.SprintService) *SprintHandler {
+	return &SprintHandler{
+		sprintService: sprintService,
+	}
+}
+
+func (h *SprintHandler) Start(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	var params dto.SprintStartRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid JSON", err)
+		return
+	}
+	if err := utils.ValidateStruct(params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	response, err := h.sprintService.Start(r.Context(), userID, params)
+	if errors.Is(err, kana.ErrUnknownGroup) || errors.Is(err, sprint.ErrInvalidDuration) {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't start sprint", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *SprintHandler) Finish(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	var params dto.SprintFinishRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "Invalid JSON", err)
+		return
+	}
+	if err := utils.ValidateStruct(params); err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	response, err := h.sprintService.Finish(r.Context(), userID, params)
+	if err != nil {
+		switch {
+		case errors.Is(err, sprint.ErrInvalidChallenge):
+			utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		case errors.Is(err, sprint.ErrExpiredChallenge):
+			utils.RespondWithErrorJSON(w, http.StatusGone, err.Error(), nil)
+		case errors.Is(err, service.ErrSprintAlreadyFinished):
+			utils.RespondWithErrorJSON(w, http.StatusConflict, err.Error(), nil)
+		default:
+			utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't finish sprint", err)
+		}
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+// Stats returns the caller's best, history and percentile on ?groups= (comma
+// separated) and ?duration=, 60 seconds by default.
+func (h *SprintHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		utils.RespondWithErrorJSON(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	raw := r.URL.Query().Get("groups")
+	if raw == "" {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, "groups is required", nil)
+		return
+	}
+	duration, err := parseIntParam(r, "duration", sprint.Durations[0], sprint.Durations[0], sprint.Durations[len(sprint.Durations)-1])
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	response, err := h.sprintService.Stats(r.Context(), userID, strings.Split(raw, ","), duration)
+	if errors.Is(err, kana.ErrUnknownGroup) || errors.Is(err, sprint.ErrInvalidDuration) {
+		utils.RespondWithErrorJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	if err != nil {
+		utils.RespondWithErrorJSON(w, http.StatusInternalServerError, "Couldn't load sprint stats", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,71 @@
+package handwriting
+
+import "math"
+
+// Marks drawn at the top right of a voiced kana.
+var (
+	dakuten    = []Stroke{{{78, 2}, {84, 22}}, {{90, 0}, {96, 20}}}
+	handakuten = circle(88, 12, 10)
+)
+
+// composeStrokes returns the template strokes of a catalog character: a base
+// kana, a base kana with a voicing mark, or a kana followed by a small one
+// (きゃ).
+func composeStrokes(k string) ([]Stroke, bool) {
+	runes := []rune(k)
+	switch len(runes) {
+	case 1:
+		return voicedStrokes(runes[0])
+	case 2:
+		base, ok := voicedStrokes(runes[0])
+		if !ok {
+			return nil, false
+		}
+		// Small ゃゅょ sit one code point below their full-size kana
+		small, ok := baseStrokes[runes[1]+1]
+		if !ok {
+			return nil, false
+		}
+		// The full-size kana takes the left of the box, the small one
+		// the bottom right
+		strokes := transform(base, 0.6, 0, 20)
+		return append(strokes, transform(small, 0.45, 55, 50)...), true
+	}
+	return nil, false
+}
+
+func voicedStrokes(r rune) ([]Stroke, bool) {
+	if strokes, ok := baseStrokes[r]; ok {
+		return strokes, true
+	}
+	// Voiced kana follow their base kana in Unicode: か が, は ば ぱ
+	if strokes, ok := baseStrokes[r-1]; ok {
+		return append(transform(strokes, 0.85, 0, 12), dakuten...), true
+	}
+	if strokes, ok := baseStrokes[r-2]; ok {
+		return append(transform(strokes, 0.85, 0, 12), handakuten), true
+	}
+	return nil, false
+}
+
+// transform scales strokes around the grid origin and moves them by dx, dy.
+func transform(strokes []Stroke, factor, dx, dy float64) []Stroke {
+	out := make([]Stroke, len(strokes))
+	for i, s := range strokes {
+		out[i] = make(Stroke, len(s))
+		for j, p := range s {
+			out[i][j] = Point{X: p.X*factor + dx, Y: p.Y*factor + dy}
+		}
+	}
+	return out
+}
+
+func circle(cx, cy, r float64) Stroke {
+	const segments = 8
+	s := make(Stroke, segments+1)
+	for i := range s {
+		angle := 2 * math.Pi * float64(i) / segments
+		s[i] = Point{X: cx + r*math.Cos(angle), Y: cy + r*math.Sin(angle)}
+	}
+	return s
+}
@@ -0,0 +1,298 @@
+// Package handwriting recognizes drawn kana by matching the strokes against
+// a bundled template set with the $P point-cloud recognizer (Vatavu, Anthony
+// and Wobbrock, 2012). Point clouds ignore stroke order and direction, so
+// they forgive the usual beginner mistakes.
+package handwriting
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+)
+
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Stroke is one pen-down to pen-up movement.
+type Stroke []Point
+
+type Candidate struct {
+	Kana       string  `json:"kana"`
+	Confidence float64 `json:"confidence"` // 0 to 1
+}
+
+var (
+	ErrNoInk      = errors.New("the drawing has no points")
+	ErrNoTemplate = errors.New("no handwriting template for this kana")
+)
+
+// AcceptConfidence is the confidence a drawing needs to count as its top
+// candidate when graded.
+const AcceptConfidence = 0.5
+
+// Points every drawing and template is resampled to.
+const samplePoints = 48
+
+// A mean point distance this large, in units of the drawing's size, scores
+// zero confidence.
+const maxMeanDistance = 0.15
+
+type template struct {
+	kana  string
+	cloud cloud
+}
+
+// templates covers every catalog character, in catalog order.
+var templates = func() []template {
+	var ts []template
+	for _, g := range kana.Groups() {
+		for _, c := range g.Chars {
+			strokes, ok := composeStrokes(c.Kana)
+			if !ok {
+				continue
+			}
+			cl, _ := newCloud(strokes)
+			ts = append(ts, template{kana: c.Kana, cloud: cl})
+		}
+	}
+	return ts
+}()
+
+// HasTemplate reports whether k can be recognized.
+func HasTemplate(k string) bool {
+	for _, t := range templates {
+		if t.kana == k {
+			return true
+		}
+	}
+	return false
+}
+
+// Recognize ranks the templates accepted by filter, or all of them if filter
+// is nil, by how closely they match the drawing, best first. It returns at
+// most limit candidates, or all of them if limit is 0.
+func Recognize(strokes []Stroke, filter func(kana string) bool, limit int) ([]Candidate, error) {
+	drawing, ok := newCloud(strokes)
+	if !ok {
+		return nil, ErrNoInk
+	}
+
+	type match struct {
+		kana     string
+		distance float64
+	}
+	var matches []match
+	for _, t := range templates {
+		if filter != nil && !filter(t.kana) {
+			continue
+		}
+		// Templates that can't make the cut are abandoned early
+		bound := math.Inf(1)
+		if limit > 0 && len(matches) == limit {
+			bound = matches[limit-1].distance
+		}
+		d := greedyCloudMatch(drawing, t.cloud, bound)
+		if d >= bound {
+			continue
+		}
+
+		// Insert in order; equal distances keep catalog order
+		i := sort.Search(len(matches), func(i int) bool { return matches[i].distance > d })
+		matches = append(matches, match{})
+		copy(matches[i+1:], matches[i:])
+		matches[i] = match{t.kana, d}
+		if limit > 0 && len(matches) > limit {
+			matches = matches[:limit]
+		}
+	}
+
+	candidates := make([]Candidate, len(matches))
+	for i, m := range matches {
+		candidates[i] = Candidate{Kana: m.kana, Confidence: confidence(m.distance)}
+	}
+	return candidates, nil
+}
+
+// Best returns the top candidate among the templates in expected's script,
+// for grading a drawing of expected.
+func Best(strokes []Stroke, expected string) (Candidate, error) {
+	group, ok := kana.GroupOf(expected)
+	if !ok || !HasTemplate(expected) {
+		return Candidate{}, ErrNoTemplate
+	}
+	candidates, err := Recognize(strokes, func(k string) bool {
+		g, _ := kana.GroupOf(k)
+		return g.Category == group.Category
+	}, 1)
+	if err != nil {
+		return Candidate{}, err
+	}
+	return candidates[0], nil
+}
+
+func confidence(distance float64) float64 {
+	// Match weights run from 1 down to 1/n, so they sum to (n+1)/2
+	mean := distance / (float64(samplePoints+1) / 2)
+	return math.Max(0, 1-mean/maxMeanDistance)
+}
+
+type cloudPoint struct {
+	x, y   float64
+	stroke int
+}
+
+type cloud []cloudPoint
+
+// newCloud resamples the strokes to samplePoints points, scales them to a
+// unit box keeping the aspect ratio and centers them on the origin.
+func newCloud(strokes []Stroke) (cloud, bool) {
+	var points cloud
+	for i, s := range strokes {
+		for _, p := range s {
+			points = append(points, cloudPoint{p.X, p.Y, i})
+		}
+	}
+	if len(points) == 0 {
+		return nil, false
+	}
+
+	points = resample(points, samplePoints)
+	scale(points)
+	translateToOrigin(points)
+	return points, true
+}
+
+func resample(points cloud, n int) cloud {
+	interval := pathLength(points) / float64(n-1)
+	if interval == 0 {
+		// A dot: every sample is the same point
+		out := make(cloud, n)
+		for i := range out {
+			out[i] = points[0]
+		}
+		return out
+	}
+
+	out := cloud{points[0]}
+	pts := append(cloud(nil), points...)
+	traveled := 0.0
+	for i := 1; i < len(pts) && len(out) < n; i++ {
+		if pts[i].stroke != pts[i-1].stroke {
+			continue
+		}
+		d := distance(pts[i-1], pts[i])
+		if traveled+d < interval {
+			traveled += d
+			continue
+		}
+		t := (interval - traveled) / d
+		q := cloudPoint{
+			x:      pts[i-1].x + t*(pts[i].x-pts[i-1].x),
+			y:      pts[i-1].y + t*(pts[i].y-pts[i-1].y),
+			stroke: pts[i].stroke,
+		}
+		out = append(out, q)
+		// q starts the next interval
+		pts = append(pts[:i], append(cloud{q}, pts[i:]...)...)
+		traveled = 0
+	}
+	// Rounding can leave the last sample out
+	for len(out) < n {
+		out = append(out, points[len(points)-1])
+	}
+	return out
+}
+
+func pathLength(points cloud) float64 {
+	length := 0.0
+	for i := 1; i < len(points); i++ {
+		if points[i].stroke == points[i-1].stroke {
+			length += distance(points[i-1], points[i])
+		}
+	}
+	return length
+}
+
+func scale(points cloud) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range points {
+		minX, maxX = math.Min(minX, p.x), math.Max(maxX, p.x)
+		minY, maxY = math.Min(minY, p.y), math.Max(maxY, p.y)
+	}
+	size := math.Max(maxX-minX, maxY-minY)
+	if size == 0 {
+		size = 1
+	}
+	for i := range points {
+		points[i].x = (points[i].x - minX) / size
+		points[i].y = (points[i].y - minY) / size
+	}
+}
+
+func translateToOrigin(points cloud) {
+	var cx, cy float64
+	for _, p := range points {
+		cx += p.x
+		cy += p.y
+	}
+	cx /= float64(len(points))
+	cy /= float64(len(points))
+	for i := range points {
+		points[i].x -= cx
+		points[i].y -= cy
+	}
+}
+
+// greedyCloudMatch is $P's matcher: greedy point pairings tried from several
+// starting points, in both directions. Pairings stop once they reach bound,
+// as in $Q, so the result is only exact below it.
+func greedyCloudMatch(a, b cloud, bound float64) float64 {
+	n := len(a)
+	step := int(math.Floor(math.Sqrt(float64(n))))
+	best := bound
+	for i := 0; i < n; i += step {
+		best = math.Min(best, cloudDistance(a, b, i, best))
+		best = math.Min(best, cloudDistance(b, a, i, best))
+	}
+	return best
+}
+
+// cloudDistance pairs each point of a, from start on, with the nearest
+// unpaired point of b. Earlier pairings weigh more.
+func cloudDistance(a, b cloud, start int, bound float64) float64 {
+	n := len(a)
+	matched := make([]bool, n)
+	sum := 0.0
+	i := start
+	for {
+		index, min := -1, math.Inf(1)
+		for j := range b {
+			if matched[j] {
+				continue
+			}
+			if d := distance(a[i], b[j]); d < min {
+				index, min = j, d
+			}
+		}
+		matched[index] = true
+		weight := 1 - float64((i-start+n)%n)/float64(n)
+		sum += weight * min
+		if sum >= bound {
+			return sum
+		}
+		i = (i + 1) % n
+		if i == start {
+			return sum
+		}
+	}
+}
+
+func distance(a, b cloudPoint) float64 {
+	dx, dy := a.x-b.x, a.y-b.y
+	return math.Sqrt(dx*dx + dy*dy)
+}
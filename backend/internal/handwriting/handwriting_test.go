@@ -0,0 +1,101 @@
+package handwriting
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+)
+
+// drawn returns the strokes as a learner might draw them: bigger, stretched,
+// somewhere else on the canvas and a little shaky.
+func drawn(strokes []Stroke, rng *rand.Rand, stretch, shake float64) []Stroke {
+	out := make([]Stroke, len(strokes))
+	for i, s := range strokes {
+		for _, p := range s {
+			out[i] = append(out[i], Point{
+				X: p.X*1.3*stretch + 200 + rng.NormFloat64()*shake,
+				Y: p.Y*1.3 + 50 + rng.NormFloat64()*shake,
+			})
+		}
+	}
+	return out
+}
+
+func TestTemplatesCoverCatalog(t *testing.T) {
+	for _, g := range kana.Groups() {
+		for _, c := range g.Chars {
+			if !HasTemplate(c.Kana) {
+				t.Errorf("No template for %s", c.Kana)
+			}
+		}
+	}
+}
+
+func TestRecognize(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	found, total := 0, 0
+	for _, g := range kana.Groups() {
+		for _, c := range g.Chars {
+			strokes, _ := composeStrokes(c.Kana)
+
+			exact, err := Recognize(drawn(strokes, rng, 1, 0), nil, 3)
+			if err != nil {
+				t.Fatalf("Recognize: %v", err)
+			}
+			if exact[0].Confidence < 0.99 {
+				t.Errorf("%s: expected a perfect match for its own template, got %+v", c.Kana, exact)
+			}
+
+			shaky, _ := Recognize(drawn(strokes, rng, 0.8+rng.Float64()*0.4, 3), nil, 3)
+			for _, candidate := range shaky {
+				if candidate.Kana == c.Kana {
+					found++
+				}
+			}
+			total++
+		}
+	}
+	// Voiced and small kana differ by a few points, so some drift to
+	// second or third
+	if found < total*95/100 {
+		t.Errorf("Only %d of %d shaky drawings ranked in the top 3", found, total)
+	}
+}
+
+func TestBest(t *testing.T) {
+	// へ and ヘ are drawn the same; the expected script decides
+	strokes, _ := composeStrokes("ヘ")
+	for _, want := range []string{"へ", "ヘ"} {
+		best, err := Best(strokes, want)
+		if err != nil || best.Kana != want || best.Confidence < AcceptConfidence {
+			t.Errorf("Best(%s) = %+v, %v", want, best, err)
+		}
+	}
+
+	scribble := []Stroke{{{0, 0}, {100, 100}, {0, 100}, {100, 0}}}
+	if best, _ := Best(scribble, "あ"); best.Confidence >= AcceptConfidence {
+		t.Errorf("A scribble shouldn't be accepted, got %+v", best)
+	}
+
+	if _, err := Best(strokes, "ねこ"); !errors.Is(err, ErrNoTemplate) {
+		t.Errorf("Expected ErrNoTemplate, got %v", err)
+	}
+	if _, err := Recognize([]Stroke{{}}, nil, 0); !errors.Is(err, ErrNoInk) {
+		t.Errorf("Expected ErrNoInk, got %v", err)
+	}
+}
+
+func TestComposeStrokes(t *testing.T) {
+	ka, _ := composeStrokes("か")
+	ga, _ := composeStrokes("が")
+	pa, _ := composeStrokes("ぱ")
+	if len(ga) != len(ka)+2 || len(pa) != len(baseStrokes['は'])+1 {
+		t.Errorf("Expected voicing marks as extra strokes, got %d and %d", len(ga), len(pa))
+	}
+	kya, _ := composeStrokes("きゃ")
+	if len(kya) != len(baseStrokes['き'])+len(baseStrokes['や']) {
+		t.Errorf("Expected き and a small や, got %d strokes", len(kya))
+	}
+}
@@ -0,0 +1,99 @@
+package handwriting
+
+// baseStrokes are centerline traces of each full-size kana on a 100x100
+// grid, y down, in stroke order. Voiced, small and combination kana are
+// composed from these.
+var baseStrokes = map[rune][]Stroke{
+	'あ': {{{20, 25}, {75, 22}}, {{45, 10}, {48, 50}, {55, 85}}, {{65, 40}, {55, 60}, {40, 80}, {25, 82}, {20, 70}, {35, 55}, {60, 50}, {80, 58}, {82, 75}, {65, 88}}},
+	'い': {{{25, 25}, {22, 55}, {28, 78}, {35, 70}}, {{70, 30}, {78, 45}, {80, 60}}},
+	'う': {{{40, 12}, {60, 18}}, {{30, 40}, {55, 35}, {70, 45}, {65, 65}, {45, 90}}},
+	'え': {{{40, 12}, {60, 18}}, {{28, 40}, {68, 38}, {30, 80}, {45, 65}, {55, 80}, {78, 82}}},
+	'お': {{{20, 30}, {70, 28}}, {{42, 10}, {44, 60}, {40, 82}, {28, 80}, {25, 70}, {45, 58}, {68, 58}, {78, 72}, {60, 88}}, {{72, 15}, {82, 25}}},
+	'か': {{{20, 35}, {65, 32}, {62, 60}, {52, 80}, {45, 75}}, {{40, 12}, {35, 45}, {22, 85}}, {{75, 30}, {85, 55}}},
+	'き': {{{25, 25}, {70, 20}}, {{25, 45}, {75, 40}}, {{45, 8}, {70, 65}}, {{60, 60}, {35, 70}, {40, 85}, {70, 88}}},
+	'く': {{{65, 10}, {30, 48}, {68, 90}}},
+	'け': {{{20, 15}, {18, 55}, {25, 80}}, {{45, 35}, {85, 32}}, {{65, 10}, {68, 60}, {55, 90}}},
+	'こ': {{{30, 25}, {65, 25}, {55, 35}}, {{28, 70}, {45, 80}, {75, 78}}},
+	'さ': {{{25, 35}, {72, 28}}, {{45, 10}, {68, 62}}, {{60, 55}, {35, 68}, {42, 85}, {70, 88}}},
+	'し': {{{35, 10}, {35, 70}, {48, 88}, {75, 75}, {82, 62}}},
+	'す': {{{15, 30}, {85, 28}}, {{55, 10}, {55, 55}, {45, 62}, {42, 50}, {55, 45}, {58, 60}, {45, 90}}},
+	'せ': {{{12, 40}, {88, 35}}, {{65, 12}, {66, 60}, {60, 62}}, {{35, 15}, {35, 80}, {45, 88}, {75, 88}}},
+	'そ': {{{30, 15}, {65, 12}, {25, 45}, {80, 40}, {50, 55}, {45, 75}, {62, 90}}},
+	'た': {{{15, 30}, {50, 28}}, {{38, 10}, {25, 85}}, {{55, 48}, {80, 45}}, {{55, 70}, {60, 82}, {82, 85}}},
+	'ち': {{{20, 28}, {75, 25}}, {{45, 10}, {35, 60}, {60, 48}, {75, 58}, {70, 78}, {45, 90}}},
+	'つ': {{{15, 40}, {55, 30}, {80, 40}, {75, 62}, {45, 80}}},
+	'て': {{{15, 25}, {85, 20}, {50, 40}, {40, 62}, {50, 85}, {70, 88}}},
+	'と': {{{35, 12}, {42, 45}}, {{75, 30}, {35, 55}, {30, 75}, {45, 88}, {80, 85}}},
+	'な': {{{15, 30}, {45, 28}}, {{32, 10}, {18, 60}}, {{60, 35}, {75, 45}}, {{65, 50}, {62, 85}, {50, 88}, {45, 78}, {60, 72}, {85, 85}}},
+	'に': {{{20, 15}, {18, 55}, {25, 85}}, {{45, 30}, {75, 28}}, {{45, 65}, {55, 80}, {80, 80}}},
+	'ぬ': {{{25, 25}, {35, 80}}, {{60, 20}, {45, 60}, {30, 85}, {20, 75}, {40, 45}, {70, 40}, {85, 60}, {75, 85}, {60, 82}, {65, 70}, {85, 90}}},
+	'ね': {{{30, 10}, {30, 90}}, {{15, 35}, {45, 35}, {25, 75}, {50, 50}, {75, 45}, {85, 60}, {80, 85}, {65, 80}, {70, 70}, {88, 88}}},
+	'の': {{{50, 30}, {38, 70}, {25, 80}, {18, 60}, {35, 30}, {60, 25}, {80, 40}, {82, 65}, {65, 85}}},
+	'は': {{{20, 15}, {18, 55}, {25, 85}}, {{45, 35}, {85, 32}}, {{65, 10}, {66, 75}, {55, 88}, {45, 80}, {60, 70}, {85, 88}}},
+	'ひ': {{{15, 25}, {40, 30}, {25, 65}, {45, 85}, {70, 80}, {75, 35}, {88, 55}}},
+	'ふ': {{{45, 15}, {55, 25}}, {{50, 35}, {40, 60}, {55, 85}, {45, 90}}, {{20, 70}, {10, 85}}, {{75, 65}, {90, 80}}},
+	'へ': {{{10, 55}, {35, 30}, {90, 75}}},
+	'ほ': {{{20, 15}, {18, 55}, {25, 85}}, {{45, 20}, {80, 20}}, {{45, 45}, {82, 45}}, {{62, 20}, {64, 78}, {55, 88}, {45, 80}, {60, 70}, {85, 88}}},
+	'ま': {{{20, 25}, {80, 25}}, {{25, 50}, {75, 50}}, {{50, 8}, {52, 78}, {40, 90}, {28, 80}, {45, 70}, {80, 88}}},
+	'み': {{{20, 20}, {45, 18}, {25, 60}, {20, 80}, {35, 80}, {55, 65}, {85, 60}}, {{70, 35}, {65, 70}, {55, 90}}},
+	'む': {{{15, 30}, {55, 28}}, {{35, 10}, {35, 75}, {25, 72}, {35, 65}, {40, 85}, {75, 85}, {80, 60}}, {{75, 25}, {85, 38}}},
+	'め': {{{25, 25}, {38, 75}}, {{65, 15}, {45, 60}, {30, 85}, {20, 75}, {40, 45}, {70, 40}, {85, 60}, {70, 88}}},
+	'も': {{{45, 10}, {35, 70}, {50, 88}, {75, 80}, {80, 55}}, {{20, 35}, {65, 33}}, {{20, 55}, {65, 53}}},
+	'や': {{{20, 45}, {60, 30}, {80, 40}, {75, 58}, {60, 62}}, {{45, 15}, {52, 25}}, {{30, 10}, {55, 90}}},
+	'ゆ': {{{25, 25}, {15, 60}, {30, 75}, {55, 40}, {80, 45}, {80, 65}, {55, 75}}, {{55, 12}, {60, 60}, {45, 92}}},
+	'よ': {{{50, 10}, {52, 75}, {40, 88}, {28, 80}, {45, 70}, {80, 88}}, {{52, 40}, {78, 38}}},
+	'ら': {{{35, 10}, {50, 20}}, {{25, 30}, {22, 65}, {45, 50}, {70, 55}, {70, 78}, {40, 90}}},
+	'り': {{{25, 20}, {22, 50}, {30, 60}}, {{70, 15}, {72, 60}, {50, 90}}},
+	'る': {{{25, 15}, {70, 15}, {20, 70}, {50, 50}, {78, 60}, {75, 85}, {50, 88}, {45, 75}, {58, 72}, {65, 82}}},
+	'れ': {{{30, 10}, {30, 90}}, {{15, 35}, {45, 35}, {25, 75}, {50, 50}, {70, 40}, {65, 80}, {85, 85}}},
+	'ろ': {{{25, 15}, {70, 15}, {20, 70}, {50, 50}, {78, 60}, {75, 85}, {45, 88}}},
+	'わ': {{{30, 10}, {30, 90}}, {{15, 35}, {45, 35}, {25, 75}, {50, 50}, {75, 45}, {85, 65}, {75, 85}, {55, 88}}},
+	'を': {{{20, 25}, {70, 22}}, {{40, 10}, {25, 50}, {45, 42}, {55, 55}, {65, 40}, {80, 35}}, {{70, 55}, {40, 70}, {40, 85}, {70, 90}}},
+	'ん': {{{55, 10}, {20, 88}, {40, 55}, {55, 55}, {55, 75}, {65, 85}, {85, 60}}},
+	'ア': {{{15, 20}, {80, 20}, {65, 40}, {55, 45}}, {{48, 30}, {45, 65}, {25, 88}}},
+	'イ': {{{70, 10}, {45, 40}, {15, 60}}, {{50, 35}, {50, 90}}},
+	'ウ': {{{50, 8}, {50, 22}}, {{20, 25}, {20, 45}}, {{20, 25}, {80, 25}, {75, 55}, {45, 90}}},
+	'エ': {{{25, 20}, {75, 20}}, {{50, 20}, {50, 80}}, {{15, 80}, {85, 80}}},
+	'オ': {{{15, 35}, {85, 35}}, {{60, 10}, {60, 90}, {52, 85}}, {{55, 40}, {20, 80}}},
+	'カ': {{{20, 35}, {75, 35}, {72, 70}, {62, 88}}, {{48, 10}, {40, 60}, {18, 90}}},
+	'キ': {{{20, 30}, {80, 25}}, {{15, 55}, {85, 50}}, {{45, 10}, {55, 90}}},
+	'ク': {{{45, 10}, {20, 45}}, {{40, 22}, {80, 22}, {65, 60}, {30, 90}}},
+	'ケ': {{{35, 10}, {15, 45}}, {{30, 30}, {85, 30}}, {{65, 30}, {55, 70}, {35, 90}}},
+	'コ': {{{20, 25}, {80, 25}, {80, 80}}, {{20, 80}, {80, 80}}},
+	'サ': {{{10, 35}, {90, 35}}, {{30, 15}, {30, 60}}, {{70, 10}, {70, 55}, {45, 90}}},
+	'シ': {{{20, 20}, {35, 30}}, {{15, 45}, {30, 55}}, {{20, 85}, {55, 70}, {85, 25}}},
+	'ス': {{{20, 20}, {75, 20}, {55, 55}, {20, 88}}, {{50, 55}, {85, 88}}},
+	'セ': {{{10, 40}, {85, 30}, {65, 55}}, {{35, 10}, {35, 80}, {45, 88}, {80, 88}}},
+	'ソ': {{{20, 25}, {35, 50}}, {{80, 20}, {65, 60}, {30, 90}}},
+	'タ': {{{40, 10}, {15, 45}}, {{35, 22}, {80, 22}, {65, 60}, {30, 90}}, {{35, 45}, {65, 60}}},
+	'チ': {{{70, 10}, {30, 22}}, {{12, 45}, {88, 45}}, {{50, 22}, {50, 70}, {30, 90}}},
+	'ツ': {{{15, 25}, {25, 45}}, {{40, 20}, {50, 40}}, {{85, 20}, {65, 65}, {30, 90}}},
+	'テ': {{{25, 15}, {75, 15}}, {{12, 40}, {88, 40}}, {{50, 40}, {50, 65}, {30, 90}}},
+	'ト': {{{35, 10}, {35, 90}}, {{35, 45}, {70, 60}}},
+	'ナ': {{{10, 35}, {90, 35}}, {{55, 10}, {55, 60}, {30, 90}}},
+	'ニ': {{{25, 30}, {75, 30}}, {{15, 75}, {85, 75}}},
+	'ヌ': {{{20, 20}, {75, 20}, {55, 60}, {20, 90}}, {{35, 45}, {80, 85}}},
+	'ネ': {{{50, 10}, {50, 25}}, {{20, 30}, {80, 30}, {20, 70}}, {{50, 50}, {50, 90}}, {{60, 55}, {85, 75}}},
+	'ノ': {{{75, 15}, {55, 60}, {20, 88}}},
+	'ハ': {{{40, 25}, {15, 75}}, {{60, 25}, {88, 75}}},
+	'ヒ': {{{25, 15}, {25, 80}, {40, 85}, {85, 85}}, {{25, 45}, {75, 35}}},
+	'フ': {{{15, 20}, {80, 20}, {65, 60}, {30, 90}}},
+	'ヘ': {{{10, 55}, {35, 30}, {90, 75}}},
+	'ホ': {{{15, 35}, {85, 35}}, {{50, 10}, {50, 90}, {42, 85}}, {{35, 50}, {18, 75}}, {{65, 50}, {85, 75}}},
+	'マ': {{{15, 25}, {85, 25}, {50, 65}}, {{40, 50}, {70, 85}}},
+	'ミ': {{{25, 15}, {70, 25}}, {{30, 40}, {65, 50}}, {{20, 70}, {75, 85}}},
+	'ム': {{{45, 10}, {20, 80}, {80, 72}}, {{65, 50}, {85, 88}}},
+	'メ': {{{75, 10}, {55, 55}, {20, 88}}, {{30, 35}, {80, 80}}},
+	'モ': {{{20, 20}, {80, 20}}, {{15, 48}, {85, 48}}, {{45, 20}, {45, 80}, {55, 88}, {85, 88}}},
+	'ヤ': {{{10, 40}, {85, 30}, {70, 55}}, {{30, 10}, {50, 90}}},
+	'ユ': {{{20, 35}, {70, 35}, {70, 80}}, {{10, 80}, {90, 80}}},
+	'ヨ': {{{20, 20}, {80, 20}, {80, 85}}, {{25, 50}, {80, 50}}, {{20, 85}, {80, 85}}},
+	'ラ': {{{25, 15}, {75, 15}}, {{20, 35}, {80, 35}, {65, 65}, {35, 90}}},
+	'リ': {{{25, 15}, {25, 60}}, {{70, 10}, {70, 55}, {45, 90}}},
+	'ル': {{{35, 15}, {35, 50}, {15, 85}}, {{55, 10}, {55, 85}, {85, 60}}},
+	'レ': {{{25, 10}, {25, 85}, {85, 50}}},
+	'ロ': {{{20, 20}, {20, 85}}, {{20, 20}, {80, 20}, {80, 85}}, {{20, 85}, {80, 85}}},
+	'ワ': {{{20, 20}, {20, 45}}, {{20, 20}, {80, 20}, {70, 55}, {35, 90}}},
+	'ヲ': {{{20, 20}, {80, 20}, {65, 60}, {30, 90}}, {{20, 45}, {75, 45}}},
+	'ン': {{{20, 20}, {35, 35}}, {{20, 85}, {55, 70}, {85, 25}}},
+}
@@ -0,0 +1,651 @@
+package kana
+
+// Code below mirrors KANA_GROUPS in frontend/src/utils/kanaData.js, which is
+// the source of truth for group IDs. Keep both in sync.
+
+var groups = []Group{
+	// --- BASIC HIRAGANA ---
+	{
+		ID:       "hsingle",
+		Label:    "あ-row",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "あ", Romanji: "a"},
+			{Kana: "い", Romanji: "i"},
+			{Kana: "う", Romanji: "u"},
+			{Kana: "え", Romanji: "e"},
+			{Kana: "お", Romanji: "o"},
+		},
+	},
+	{
+		ID:       "hk",
+		Label:    "か-row",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "か", Romanji: "ka"},
+			{Kana: "き", Romanji: "ki"},
+			{Kana: "く", Romanji: "ku"},
+			{Kana: "け", Romanji: "ke"},
+			{Kana: "こ", Romanji: "ko"},
+		},
+	},
+	{
+		ID:       "hs",
+		Label:    "さ-row",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "さ", Romanji: "sa"},
+			{Kana: "し", Romanji: "shi"},
+			{Kana: "す", Romanji: "su"},
+			{Kana: "せ", Romanji: "se"},
+			{Kana: "そ", Romanji: "so"},
+		},
+	},
+	{
+		ID:       "ht",
+		Label:    "た-row",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "た", Romanji: "ta"},
+			{Kana: "ち", Romanji: "chi"},
+			{Kana: "つ", Romanji: "tsu"},
+			{Kana: "て", Romanji: "te"},
+			{Kana: "と", Romanji: "to"},
+		},
+	},
+	{
+		ID:       "hn",
+		Label:    "な-row",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "な", Romanji: "na"},
+			{Kana: "に", Romanji: "ni"},
+			{Kana: "ぬ", Romanji: "nu"},
+			{Kana: "ね", Romanji: "ne"},
+			{Kana: "の", Romanji: "no"},
+		},
+	},
+	{
+		ID:       "hh",
+		Label:    "は-row",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "は", Romanji: "ha"},
+			{Kana: "ひ", Romanji: "hi"},
+			{Kana: "ふ", Romanji: "fu"},
+			{Kana: "へ", Romanji: "he"},
+			{Kana: "ほ", Romanji: "ho"},
+		},
+	},
+	{
+		ID:       "hm",
+		Label:    "ま-row",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ま", Romanji: "ma"},
+			{Kana: "み", Romanji: "mi"},
+			{Kana: "む", Romanji: "mu"},
+			{Kana: "め", Romanji: "me"},
+			{Kana: "も", Romanji: "mo"},
+		},
+	},
+	{
+		ID:       "hy",
+		Label:    "や-row",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "や", Romanji: "ya"},
+			{Kana: "ゆ", Romanji: "yu"},
+			{Kana: "よ", Romanji: "yo"},
+		},
+	},
+	{
+		ID:       "hr",
+		Label:    "ら-row",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ら", Romanji: "ra"},
+			{Kana: "り", Romanji: "ri"},
+			{Kana: "る", Romanji: "ru"},
+			{Kana: "れ", Romanji: "re"},
+			{Kana: "ろ", Romanji: "ro"},
+		},
+	},
+	{
+		ID:       "hw",
+		Label:    "わ-row",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "わ", Romanji: "wa"},
+			{Kana: "を", Romanji: "o"},
+		},
+	},
+	{
+		ID:       "hn1",
+		Label:    "ん",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ん", Romanji: "n"},
+		},
+	},
+	{
+		ID:       "hg",
+		Label:    "が-row",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "が", Romanji: "ga"},
+			{Kana: "ぎ", Romanji: "gi"},
+			{Kana: "ぐ", Romanji: "gu"},
+			{Kana: "げ", Romanji: "ge"},
+			{Kana: "ご", Romanji: "go"},
+		},
+	},
+	{
+		ID:       "hz",
+		Label:    "ざ-row",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ざ", Romanji: "za"},
+			{Kana: "じ", Romanji: "ji"},
+			{Kana: "ず", Romanji: "zu"},
+			{Kana: "ぜ", Romanji: "ze"},
+			{Kana: "ぞ", Romanji: "zo"},
+		},
+	},
+	{
+		ID:       "hd",
+		Label:    "だ-row",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "だ", Romanji: "da"},
+			{Kana: "ぢ", Romanji: "ji"},
+			{Kana: "づ", Romanji: "zu"},
+			{Kana: "で", Romanji: "de"},
+			{Kana: "ど", Romanji: "do"},
+		},
+	},
+	{
+		ID:       "hb",
+		Label:    "ば-row",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ば", Romanji: "ba"},
+			{Kana: "び", Romanji: "bi"},
+			{Kana: "ぶ", Romanji: "bu"},
+			{Kana: "べ", Romanji: "be"},
+			{Kana: "ぼ", Romanji: "bo"},
+		},
+	},
+	{
+		ID:       "hp",
+		Label:    "ぱ-row",
+		Category: CategoryHiragana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ぱ", Romanji: "pa"},
+			{Kana: "ぴ", Romanji: "pi"},
+			{Kana: "ぷ", Romanji: "pu"},
+			{Kana: "ぺ", Romanji: "pe"},
+			{Kana: "ぽ", Romanji: "po"},
+		},
+	},
+	// --- BASIC KATAKANA ---
+	{
+		ID:       "ksingle",
+		Label:    "ア-row",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ア", Romanji: "a"},
+			{Kana: "イ", Romanji: "i"},
+			{Kana: "ウ", Romanji: "u"},
+			{Kana: "エ", Romanji: "e"},
+			{Kana: "オ", Romanji: "o"},
+		},
+	},
+	{
+		ID:       "kk",
+		Label:    "カ-row",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "カ", Romanji: "ka"},
+			{Kana: "キ", Romanji: "ki"},
+			{Kana: "ク", Romanji: "ku"},
+			{Kana: "ケ", Romanji: "ke"},
+			{Kana: "コ", Romanji: "ko"},
+		},
+	},
+	{
+		ID:       "ks",
+		Label:    "サ-row",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "サ", Romanji: "sa"},
+			{Kana: "シ", Romanji: "shi"},
+			{Kana: "ス", Romanji: "su"},
+			{Kana: "セ", Romanji: "se"},
+			{Kana: "ソ", Romanji: "so"},
+		},
+	},
+	{
+		ID:       "kt",
+		Label:    "タ-row",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "タ", Romanji: "ta"},
+			{Kana: "チ", Romanji: "chi"},
+			{Kana: "ツ", Romanji: "tsu"},
+			{Kana: "テ", Romanji: "te"},
+			{Kana: "ト", Romanji: "to"},
+		},
+	},
+	{
+		ID:       "kn",
+		Label:    "ナ-row",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ナ", Romanji: "na"},
+			{Kana: "ニ", Romanji: "ni"},
+			{Kana: "ヌ", Romanji: "nu"},
+			{Kana: "ネ", Romanji: "ne"},
+			{Kana: "ノ", Romanji: "no"},
+		},
+	},
+	{
+		ID:       "kh",
+		Label:    "ハ-row",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ハ", Romanji: "ha"},
+			{Kana: "ヒ", Romanji: "hi"},
+			{Kana: "フ", Romanji: "fu"},
+			{Kana: "ヘ", Romanji: "he"},
+			{Kana: "ホ", Romanji: "ho"},
+		},
+	},
+	{
+		ID:       "km",
+		Label:    "マ-row",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "マ", Romanji: "ma"},
+			{Kana: "ミ", Romanji: "mi"},
+			{Kana: "ム", Romanji: "mu"},
+			{Kana: "メ", Romanji: "me"},
+			{Kana: "モ", Romanji: "mo"},
+		},
+	},
+	{
+		ID:       "ky",
+		Label:    "ヤ-row",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ヤ", Romanji: "ya"},
+			{Kana: "ユ", Romanji: "yu"},
+			{Kana: "ヨ", Romanji: "yo"},
+		},
+	},
+	{
+		ID:       "kr",
+		Label:    "ラ-row",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ラ", Romanji: "ra"},
+			{Kana: "リ", Romanji: "ri"},
+			{Kana: "ル", Romanji: "ru"},
+			{Kana: "レ", Romanji: "re"},
+			{Kana: "ロ", Romanji: "ro"},
+		},
+	},
+	{
+		ID:       "kw",
+		Label:    "ワ-row",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ワ", Romanji: "wa"},
+			{Kana: "ヲ", Romanji: "o"},
+		},
+	},
+	{
+		ID:       "kn1",
+		Label:    "ン",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ン", Romanji: "n"},
+		},
+	},
+	{
+		ID:       "kg",
+		Label:    "ガ-row",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ガ", Romanji: "ga"},
+			{Kana: "ギ", Romanji: "gi"},
+			{Kana: "グ", Romanji: "gu"},
+			{Kana: "ゲ", Romanji: "ge"},
+			{Kana: "ゴ", Romanji: "go"},
+		},
+	},
+	{
+		ID:       "kz",
+		Label:    "ザ-row",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ザ", Romanji: "za"},
+			{Kana: "ジ", Romanji: "ji"},
+			{Kana: "ズ", Romanji: "zu"},
+			{Kana: "ゼ", Romanji: "ze"},
+			{Kana: "ゾ", Romanji: "zo"},
+		},
+	},
+	{
+		ID:       "kd",
+		Label:    "ダ-row",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "ダ", Romanji: "da"},
+			{Kana: "ヂ", Romanji: "ji"},
+			{Kana: "ヅ", Romanji: "zu"},
+			{Kana: "デ", Romanji: "de"},
+			{Kana: "ド", Romanji: "do"},
+		},
+	},
+	{
+		ID:       "kb",
+		Label:    "バ-row",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "バ", Romanji: "ba"},
+			{Kana: "ビ", Romanji: "bi"},
+			{Kana: "ブ", Romanji: "bu"},
+			{Kana: "ベ", Romanji: "be"},
+			{Kana: "ボ", Romanji: "bo"},
+		},
+	},
+	{
+		ID:       "kp",
+		Label:    "パ-row",
+		Category: CategoryKatakana,
+		Section:  SectionBasic,
+		Chars: []Char{
+			{Kana: "パ", Romanji: "pa"},
+			{Kana: "ピ", Romanji: "pi"},
+			{Kana: "プ", Romanji: "pu"},
+			{Kana: "ペ", Romanji: "pe"},
+			{Kana: "ポ", Romanji: "po"},
+		},
+	},
+	// --- HIRAGANA COMBINATIONS ---
+	{
+		ID:       "hkya",
+		Label:    "kya-row",
+		Category: CategoryHiragana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "きゃ", Romanji: "kya"},
+			{Kana: "きゅ", Romanji: "kyu"},
+			{Kana: "きょ", Romanji: "kyo"},
+		},
+	},
+	{
+		ID:       "hsha",
+		Label:    "sha-row",
+		Category: CategoryHiragana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "しゃ", Romanji: "sha"},
+			{Kana: "しゅ", Romanji: "shu"},
+			{Kana: "しょ", Romanji: "sho"},
+		},
+	},
+	{
+		ID:       "hcha",
+		Label:    "cha-row",
+		Category: CategoryHiragana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "ちゃ", Romanji: "cha"},
+			{Kana: "ちゅ", Romanji: "chu"},
+			{Kana: "ちょ", Romanji: "cho"},
+		},
+	},
+	{
+		ID:       "hnya",
+		Label:    "nya-row",
+		Category: CategoryHiragana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "にゃ", Romanji: "nya"},
+			{Kana: "にゅ", Romanji: "nyu"},
+			{Kana: "にょ", Romanji: "nyo"},
+		},
+	},
+	{
+		ID:       "hhya",
+		Label:    "hya-row",
+		Category: CategoryHiragana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "ひゃ", Romanji: "hya"},
+			{Kana: "ひゅ", Romanji: "hyu"},
+			{Kana: "ひょ", Romanji: "hyo"},
+		},
+	},
+	{
+		ID:       "hmya",
+		Label:    "mya-row",
+		Category: CategoryHiragana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "みゃ", Romanji: "mya"},
+			{Kana: "みゅ", Romanji: "myu"},
+			{Kana: "みょ", Romanji: "myo"},
+		},
+	},
+	{
+		ID:       "hrya",
+		Label:    "rya-row",
+		Category: CategoryHiragana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "りゃ", Romanji: "rya"},
+			{Kana: "りゅ", Romanji: "ryu"},
+			{Kana: "りょ", Romanji: "ryo"},
+		},
+	},
+	{
+		ID:       "hgya",
+		Label:    "gya-row",
+		Category: CategoryHiragana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "ぎゃ", Romanji: "gya"},
+			{Kana: "ぎゅ", Romanji: "gyu"},
+			{Kana: "ぎょ", Romanji: "gyo"},
+		},
+	},
+	{
+		ID:       "hja",
+		Label:    "ja-row",
+		Category: CategoryHiragana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "じゃ", Romanji: "ja"},
+			{Kana: "じゅ", Romanji: "ju"},
+			{Kana: "じょ", Romanji: "jo"},
+		},
+	},
+	{
+		ID:       "hbya",
+		Label:    "bya-row",
+		Category: CategoryHiragana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "びゃ", Romanji: "bya"},
+			{Kana: "びゅ", Romanji: "byu"},
+			{Kana: "びょ", Romanji: "byo"},
+		},
+	},
+	{
+		ID:       "hpya",
+		Label:    "pya-row",
+		Category: CategoryHiragana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "ぴゃ", Romanji: "pya"},
+			{Kana: "ぴゅ", Romanji: "pyu"},
+			{Kana: "ぴょ", Romanji: "pyo"},
+		},
+	},
+	// --- KATAKANA COMBINATIONS ---
+	{
+		ID:       "kkya",
+		Label:    "kya-row",
+		Category: CategoryKatakana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "キャ", Romanji: "kya"},
+			{Kana: "キュ", Romanji: "kyu"},
+			{Kana: "キョ", Romanji: "kyo"},
+		},
+	},
+	{
+		ID:       "ksha",
+		Label:    "sha-row",
+		Category: CategoryKatakana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "シャ", Romanji: "sha"},
+			{Kana: "シュ", Romanji: "shu"},
+			{Kana: "ショ", Romanji: "sho"},
+		},
+	},
+	{
+		ID:       "kcha",
+		Label:    "cha-row",
+		Category: CategoryKatakana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "チャ", Romanji: "cha"},
+			{Kana: "チュ", Romanji: "chu"},
+			{Kana: "チョ", Romanji: "cho"},
+		},
+	},
+	{
+		ID:       "knya",
+		Label:    "nya-row",
+		Category: CategoryKatakana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "ニャ", Romanji: "nya"},
+			{Kana: "ニュ", Romanji: "nyu"},
+			{Kana: "ニョ", Romanji: "nyo"},
+		},
+	},
+	{
+		ID:       "khya",
+		Label:    "hya-row",
+		Category: CategoryKatakana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "ヒャ", Romanji: "hya"},
+			{Kana: "ヒュ", Romanji: "hyu"},
+			{Kana: "ヒョ", Romanji: "hyo"},
+		},
+	},
+	{
+		ID:       "kmya",
+		Label:    "mya-row",
+		Category: CategoryKatakana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "ミャ", Romanji: "mya"},
+			{Kana: "ミュ", Romanji: "myu"},
+			{Kana: "ミョ", Romanji: "myo"},
+		},
+	},
+	{
+		ID:       "krya",
+		Label:    "rya-row",
+		Category: CategoryKatakana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "リャ", Romanji: "rya"},
+			{Kana: "リュ", Romanji: "ryu"},
+			{Kana: "リョ", Romanji: "ryo"},
+		},
+	},
+	{
+		ID:       "kgya",
+		Label:    "gya-row",
+		Category: CategoryKatakana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "ギャ", Romanji: "gya"},
+			{Kana: "ギュ", Romanji: "gyu"},
+			{Kana: "ギョ", Romanji: "gyo"},
+		},
+	},
+	{
+		ID:       "kja",
+		Label:    "ja-row",
+		Category: CategoryKatakana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "ジャ", Romanji: "ja"},
+			{Kana: "ジュ", Romanji: "ju"},
+			{Kana: "ジョ", Romanji: "jo"},
+		},
+	},
+	{
+		ID:       "kbya",
+		Label:    "bya-row",
+		Category: CategoryKatakana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "ビャ", Romanji: "bya"},
+			{Kana: "ビュ", Romanji: "byu"},
+			{Kana: "ビョ", Romanji: "byo"},
+		},
+	},
+	{
+		ID:       "kpya",
+		Label:    "pya-row",
+		Category: CategoryKatakana,
+		Section:  SectionCombination,
+		Chars: []Char{
+			{Kana: "ピャ", Romanji: "pya"},
+			{Kana: "ピュ", Romanji: "pyu"},
+			{Kana: "ピョ", Romanji: "pyo"},
+		},
+	},
+}
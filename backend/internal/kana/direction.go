@@ -0,0 +1,100 @@
+package kana
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Cadimodev/haiji/backend/internal/romaji"
+)
+
+// Direction is what a prompt shows and what the player answers with.
+type Direction string
+
+const (
+	DirectionToRomaji Direction = "kana_to_romaji"
+	DirectionToKana   Direction = "romaji_to_kana"
+	// The prompt is in the other script and the answer in the group's own
+	DirectionSwapScript Direction = "hiragana_katakana"
+)
+
+var Directions = []Direction{DirectionToRomaji, DirectionToKana, DirectionSwapScript}
+
+var ErrUnknownDirection = errors.New("unknown answer direction")
+
+// ParseDirection parses a direction name. The empty name is kana to romaji.
+func ParseDirection(name string) (Direction, error) {
+	if name == "" {
+		return DirectionToRomaji, nil
+	}
+	for _, d := range Directions {
+		if string(d) == name {
+			return d, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownDirection, name)
+}
+
+// Prompt returns what the player is shown for c.
+func (d Direction) Prompt(c Char) string {
+	switch d {
+	case DirectionToKana:
+		return c.Romanji
+	case DirectionSwapScript:
+		return OtherScript(c.Kana)
+	}
+	return c.Kana
+}
+
+// AnswersInKana reports whether answers are kana rather than romaji.
+func (d Direction) AnswersInKana() bool {
+	return d == DirectionToKana || d == DirectionSwapScript
+}
+
+// Expected returns the answer for c.
+func (d Direction) Expected(c Char) string {
+	if d.AnswersInKana() {
+		return c.Kana
+	}
+	return c.Romanji
+}
+
+// IsCorrect grades an answer to c. Kana answers may be typed as romaji and
+// are converted IME style into c's script. Answers to a romaji prompt may be
+// any kana read that way, so じ and ぢ both answer "ji".
+func (d Direction) IsCorrect(c Char, answer string, systems ...romaji.System) bool {
+	if d.AnswersInKana() {
+		katakana := hasKatakana(c.Kana)
+		typed := strings.Join(strings.Fields(answer), "")
+		if katakana {
+			typed = romaji.ToKatakana(typed)
+		} else {
+			typed = romaji.ToHiragana(typed)
+		}
+		if typed == c.Kana {
+			return true
+		}
+		return d == DirectionToKana && IsKanaText(typed) &&
+			hasKatakana(typed) == katakana && romaji.Matches(typed, c.Romanji)
+	}
+	return IsCorrect(c, answer, systems...)
+}
+
+// OtherScript turns hiragana into katakana and katakana into hiragana.
+func OtherScript(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'ぁ' && r <= 'ゖ':
+			return r + ('ァ' - 'ぁ')
+		case r >= 'ァ' && r <= 'ヶ':
+			return r - ('ァ' - 'ぁ')
+		}
+		return r
+	}, s)
+}
+
+func hasKatakana(s string) bool {
+	return strings.ContainsFunc(s, func(r rune) bool {
+		return r >= 'ァ' && r <= 'ヺ'
+	})
+}
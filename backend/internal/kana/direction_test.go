@@ -0,0 +1,57 @@
+package kana
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseDirection(t *testing.T) {
+	if d, err := ParseDirection(""); err != nil || d != DirectionToRomaji {
+		t.Errorf("Expected the default direction, got %q, %v", d, err)
+	}
+	if _, err := ParseDirection("kanji_to_kana"); !errors.Is(err, ErrUnknownDirection) {
+		t.Errorf("Expected ErrUnknownDirection, got %v", err)
+	}
+}
+
+func TestDirection_IsCorrect(t *testing.T) {
+	shi, _ := Lookup("し")
+	shiKata, _ := Lookup("シ")
+	ji, _ := Lookup("じ")
+
+	tests := []struct {
+		d      Direction
+		c      Char
+		answer string
+		want   bool
+	}{
+		{DirectionToRomaji, shi, "shi", true},
+		{DirectionToRomaji, shi, "し", false},
+		{DirectionToKana, shi, "し", true},
+		{DirectionToKana, shi, "shi", true},
+		{DirectionToKana, shi, "si", true},
+		{DirectionToKana, shiKata, "shi", true},
+		{DirectionToKana, shiKata, "し", false},
+		{DirectionToKana, ji, "ぢ", true},
+		{DirectionToKana, ji, "ち", false},
+		{DirectionSwapScript, shiKata, "シ", true},
+		{DirectionSwapScript, shiKata, "shi", true},
+		{DirectionSwapScript, shiKata, "し", false},
+		{DirectionSwapScript, ji, "ぢ", false},
+	}
+	for _, tt := range tests {
+		if got := tt.d.IsCorrect(tt.c, tt.answer); got != tt.want {
+			t.Errorf("%s: IsCorrect(%s, %q) = %v, want %v", tt.d, tt.c.Kana, tt.answer, got, tt.want)
+		}
+	}
+}
+
+func TestDirection_Prompt(t *testing.T) {
+	c := Char{Kana: "コーヒー", Romanji: "koohii"}
+	if got := DirectionSwapScript.Prompt(c); got != "こーひー" {
+		t.Errorf("Got %q", got)
+	}
+	if got := DirectionToKana.Prompt(c); got != "koohii" || DirectionToKana.Expected(c) != c.Kana {
+		t.Errorf("Got %q", got)
+	}
+}
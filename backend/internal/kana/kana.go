@@ -0,0 +1,220 @@
+package kana
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Cadimodev/haiji/backend/internal/romaji"
+)
+
+type Category string
+
+const (
+	CategoryHiragana Category = "hiragana"
+	CategoryKatakana Category = "katakana"
+)
+
+type Section string
+
+const (
+	SectionBasic       Section = "basic"
+	SectionCombination Section = "combination"
+)
+
+// Char is a single prompt: the kana and its expected romaji answer. Kanji
+// prompts also accept any of their readings.
+type Char struct {
+	Kana     string   `json:"kana"`
+	Romanji  string   `json:"romanji"`
+	Readings []string `json:"readings,omitempty"`
+}
+
+type Group struct {
+	ID       string   `json:"id"`
+	Label    string   `json:"label"`
+	Category Category `json:"category"`
+	Section  Section  `json:"section"`
+	Chars    []Char   `json:"chars"`
+}
+
+var ErrUnknownGroup = errors.New("unknown kana group")
+
+// Groups with this prefix name a user deck by its share code, e.g. "deck:ABC123".
+const DeckGroupPrefix = "deck:"
+
+// DeckCode returns the share code of a deck group.
+func DeckCode(group string) (string, bool) {
+	code, ok := strings.CutPrefix(group, DeckGroupPrefix)
+	return code, ok && code != ""
+}
+
+// Groups with this prefix name the imported kanji of an old JLPT level, from
+// "kanji:jlpt4" (easiest) to "kanji:jlpt1".
+const KanjiGroupPrefix = "kanji:jlpt"
+
+// MaxKanjiLevel is the easiest old JLPT level; 1 is the hardest.
+const MaxKanjiLevel = 4
+
+// KanjiLevel returns the JLPT level of a kanji group.
+func KanjiLevel(group string) (int, bool) {
+	level, ok := strings.CutPrefix(group, KanjiGroupPrefix)
+	if !ok || len(level) != 1 || level[0] < '1' || int(level[0]-'0') > MaxKanjiLevel {
+		return 0, false
+	}
+	return int(level[0] - '0'), true
+}
+
+// KanjiGroup names the kanji group of a JLPT level.
+func KanjiGroup(level int) string {
+	return fmt.Sprintf("%s%d", KanjiGroupPrefix, level)
+}
+
+var byID = func() map[string]*Group {
+	m := make(map[string]*Group, len(groups))
+	for i := range groups {
+		m[groups[i].ID] = &groups[i]
+	}
+	return m
+}()
+
+// Groups returns every group in catalog order.
+func Groups() []Group {
+	return groups
+}
+
+// GroupIDs returns every group ID in catalog order.
+func GroupIDs() []string {
+	ids := make([]string, len(groups))
+	for i, g := range groups {
+		ids[i] = g.ID
+	}
+	return ids
+}
+
+func GetGroup(id string) (Group, bool) {
+	g, ok := byID[id]
+	if !ok {
+		return Group{}, false
+	}
+	return *g, true
+}
+
+// GroupsBy mirrors getGroupsBy in kanaData.js.
+func GroupsBy(category Category, section Section) []string {
+	var ids []string
+	for _, g := range groups {
+		if g.Category == category && g.Section == section {
+			ids = append(ids, g.ID)
+		}
+	}
+	return ids
+}
+
+// CharsFor returns the characters of the given groups, in order.
+func CharsFor(ids []string) ([]Char, error) {
+	var chars []Char
+	for _, id := range ids {
+		g, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownGroup, id)
+		}
+		chars = append(chars, g.Chars...)
+	}
+	return chars, nil
+}
+
+// Lookup finds a character anywhere in the catalog.
+func Lookup(kana string) (Char, bool) {
+	for _, g := range groups {
+		for _, c := range g.Chars {
+			if c.Kana == kana {
+				return c, true
+			}
+		}
+	}
+	return Char{}, false
+}
+
+// IsCorrect reports whether the answer is the expected romaji or spells the
+// kana in one of the romanization systems, or in any of them if none are
+// given. "si" is accepted for し unless only Hepburn is. Kanji are also
+// answered by romanizing any of their readings.
+func IsCorrect(c Char, answer string, systems ...romaji.System) bool {
+	if strings.EqualFold(strings.TrimSpace(answer), c.Romanji) {
+		return true
+	}
+	if romaji.Matches(c.Kana, answer, systems...) {
+		return true
+	}
+	for _, reading := range c.Readings {
+		if romaji.Matches(reading, answer, systems...) {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupOf returns the first group in catalog order containing the character.
+func GroupOf(kana string) (Group, bool) {
+	for _, g := range groups {
+		for _, c := range g.Chars {
+			if c.Kana == kana {
+				return g, true
+			}
+		}
+	}
+	return Group{}, false
+}
+
+// GroupsFor returns the IDs of the catalog groups needed to read a kana
+// text, in catalog order. Small っ and the long vowel mark only change the
+// kana around them and need no group of their own. It returns false if the
+// text uses kana outside the catalog, such as ゐ or the small ァ of ファ.
+func GroupsFor(text string) ([]string, bool) {
+	needed := make(map[string]bool)
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		switch runes[i] {
+		case 'っ', 'ッ', 'ー':
+			i++
+			continue
+		}
+		// Combinations like きゃ are their own catalog characters
+		size := 1
+		if i+1 < len(runes) {
+			if _, ok := GroupOf(string(runes[i : i+2])); ok {
+				size = 2
+			}
+		}
+		g, ok := GroupOf(string(runes[i : i+size]))
+		if !ok {
+			return nil, false
+		}
+		needed[g.ID] = true
+		i += size
+	}
+
+	var ids []string
+	for _, g := range groups {
+		if needed[g.ID] {
+			ids = append(ids, g.ID)
+		}
+	}
+	return ids, true
+}
+
+// IsKanaText reports whether s is made only of hiragana, katakana and the
+// long vowel mark, like a single kana or a kana word.
+func IsKanaText(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r != 'ー' && !unicode.In(r, unicode.Hiragana, unicode.Katakana) {
+			return false
+		}
+	}
+	return true
+}
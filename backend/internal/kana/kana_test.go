@@ -0,0 +1,150 @@
+package kana
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Cadimodev/haiji/backend/internal/romaji"
+)
+
+func TestCatalog(t *testing.T) {
+	if got := len(Groups()); got != 54 {
+		t.Errorf("Expected 54 groups, got %d", got)
+	}
+
+	seen := make(map[string]bool)
+	for _, g := range Groups() {
+		if seen[g.ID] {
+			t.Errorf("Duplicate group ID %s", g.ID)
+		}
+		seen[g.ID] = true
+		if len(g.Chars) == 0 {
+			t.Errorf("Group %s has no characters", g.ID)
+		}
+	}
+
+	if got := len(GroupsBy(CategoryKatakana, SectionCombination)); got != 11 {
+		t.Errorf("Expected 11 katakana combination groups, got %d", got)
+	}
+}
+
+func TestCharsFor(t *testing.T) {
+	chars, err := CharsFor([]string{"hsingle", "hn1"})
+	if err != nil {
+		t.Fatalf("CharsFor: %v", err)
+	}
+	if len(chars) != 6 || chars[5].Kana != "ん" {
+		t.Errorf("Unexpected chars: %v", chars)
+	}
+
+	if _, err := CharsFor([]string{"nope"}); !errors.Is(err, ErrUnknownGroup) {
+		t.Errorf("Expected ErrUnknownGroup, got %v", err)
+	}
+}
+
+func TestIsCorrect(t *testing.T) {
+	c, _ := Lookup("し")
+	if !IsCorrect(c, " SHI ") {
+		t.Error("Expected case and whitespace insensitive match")
+	}
+	if !IsCorrect(c, "si") {
+		t.Error("Expected the Kunrei spelling to be accepted by default")
+	}
+	if IsCorrect(c, "si", romaji.Hepburn) {
+		t.Error("Kunrei spelling shouldn't be accepted with only Hepburn")
+	}
+	if !IsCorrect(c, "shi", romaji.Kunrei) {
+		t.Error("The catalog answer should always be accepted")
+	}
+}
+
+func TestCatalogMatchesRomanization(t *testing.T) {
+	for _, g := range groups {
+		for _, c := range g.Chars {
+			if !romaji.Matches(c.Kana, c.Romanji) {
+				t.Errorf("%s: %q isn't a romanization", c.Kana, c.Romanji)
+			}
+		}
+	}
+}
+
+func TestDeckCode(t *testing.T) {
+	if code, ok := DeckCode("deck:ABC123"); !ok || code != "ABC123" {
+		t.Errorf("Got %q, %v", code, ok)
+	}
+	for _, group := range []string{"hsingle", "deck:", "DECK:ABC123"} {
+		if _, ok := DeckCode(group); ok {
+			t.Errorf("%q shouldn't be a deck group", group)
+		}
+	}
+}
+
+func TestKanjiLevel(t *testing.T) {
+	if level, ok := KanjiLevel("kanji:jlpt4"); !ok || level != 4 {
+		t.Errorf("Got %d, %v", level, ok)
+	}
+	if KanjiGroup(2) != "kanji:jlpt2" {
+		t.Errorf("Got %q", KanjiGroup(2))
+	}
+	for _, group := range []string{"kanji:jlpt", "kanji:jlpt5", "kanji:jlpt0", "kanji:jlpt12", "kanji:n5"} {
+		if _, ok := KanjiLevel(group); ok {
+			t.Errorf("%q shouldn't be a kanji group", group)
+		}
+	}
+}
+
+func TestIsCorrectAcceptsReadings(t *testing.T) {
+	c := Char{Kana: "日", Romanji: "nichi", Readings: []string{"ニチ", "ジツ", "ひ", "か"}}
+	for _, answer := range []string{"nichi", "jitsu", "zitu", "hi", "ka"} {
+		if !IsCorrect(c, answer) {
+			t.Errorf("%q should answer %s", answer, c.Kana)
+		}
+	}
+	if IsCorrect(c, "tsuki") {
+		t.Error("tsuki shouldn't answer 日")
+	}
+}
+
+func TestGroupsFor(t *testing.T) {
+	got, ok := GroupsFor("ちょっとコーヒー")
+	want := []string{"ht", "kk", "kh", "hcha"}
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("Got %v, %v, want %v", got, ok, want)
+	}
+	for _, text := range []string{"ファン", "ゐる", "猫", "ゃ"} {
+		if _, ok := GroupsFor(text); ok {
+			t.Errorf("%q shouldn't be readable with the catalog", text)
+		}
+	}
+}
+
+func TestLookAlikes(t *testing.T) {
+	if got := LookAlikes("シ"); !reflect.DeepEqual(got, []string{"ツ", "ン", "ソ"}) {
+		t.Errorf("Got %v", got)
+	}
+	// Sets overlap: ね looks like わ and れ
+	if got := LookAlikes("ね"); !reflect.DeepEqual(got, []string{"わ", "れ"}) {
+		t.Errorf("Got %v", got)
+	}
+	for _, set := range lookAlikes {
+		for _, k := range set {
+			if _, ok := Lookup(k); !ok {
+				t.Errorf("%s isn't in the catalog", k)
+			}
+		}
+	}
+}
+
+func TestIsKanaText(t *testing.T) {
+	for _, s := range []string{"あ", "ねこ", "コーヒー", "きゃ"} {
+		if !IsKanaText(s) {
+			t.Errorf("%q should be kana", s)
+		}
+	}
+	for _, s := range []string{"", "猫", "neko", "ね こ"} {
+		if IsKanaText(s) {
+			t.Errorf("%q shouldn't be kana", s)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package kana
+
+import "slices"
+
+// lookAlikes are sets of catalog characters beginners mix up by shape.
+var lookAlikes = [][]string{
+	// Hiragana
+	{"ぬ", "め"}, {"わ", "ね", "れ"}, {"る", "ろ"}, {"は", "ほ", "ま"},
+	{"さ", "ち", "き"}, {"い", "り", "こ"}, {"あ", "お", "め"}, {"し", "つ"},
+	{"う", "ら"}, {"く", "へ"}, {"け", "は"}, {"に", "こ"}, {"す", "む"},
+	{"そ", "ろ"}, {"ば", "ぱ"}, {"ぶ", "ぷ"}, {"び", "ぴ"}, {"べ", "ぺ"}, {"ぼ", "ぽ"},
+	// Katakana
+	{"シ", "ツ", "ン", "ソ"}, {"ク", "ケ", "タ", "ワ"}, {"コ", "ユ", "ロ"},
+	{"チ", "テ"}, {"ナ", "メ", "ノ"}, {"ル", "レ"}, {"マ", "ム", "ア"},
+	{"ヲ", "フ", "ラ"}, {"ウ", "ワ", "ラ"}, {"セ", "サ"}, {"ヌ", "ス", "フ"},
+	{"エ", "ニ"}, {"ハ", "ル"}, {"ヤ", "セ"},
+	{"バ", "パ"}, {"ブ", "プ"}, {"ビ", "ピ"}, {"ベ", "ペ"}, {"ボ", "ポ"},
+	// Across scripts
+	{"か", "カ"}, {"へ", "ヘ"}, {"り", "リ"}, {"も", "モ"}, {"せ", "セ"}, {"き", "キ"},
+	{"し", "ツ"}, {"ん", "ソ"}, {"ろ", "ロ"}, {"や", "ヤ"},
+}
+
+var lookAlikeIndex = func() map[string][]string {
+	m := make(map[string][]string)
+	for _, set := range lookAlikes {
+		for _, k := range set {
+			for _, other := range set {
+				if other != k && !slices.Contains(m[k], other) {
+					m[k] = append(m[k], other)
+				}
+			}
+		}
+	}
+	return m
+}()
+
+// LookAlikes returns the catalog characters commonly confused with k by
+// shape, such as ツ for シ.
+func LookAlikes(k string) []string {
+	return slices.Clone(lookAlikeIndex[k])
+}
@@ -0,0 +1,118 @@
+package kanji
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const kanjidicSample = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE kanjidic2 [
+<!ELEMENT kanjidic2 (header,character*)>
+<!ATTLIST reading r_type CDATA #REQUIRED>
+]>
+<kanjidic2>
+<header><file_version>4</file_version></header>
+<character>
+<literal>日</literal>
+<misc>
+<grade>1</grade>
+<stroke_count>4</stroke_count>
+<stroke_count>3</stroke_count>
+<freq>1</freq>
+<jlpt>4</jlpt>
+</misc>
+<reading_meaning>
+<rmgroup>
+<reading r_type="pinyin">ri4</reading>
+<reading r_type="ja_on">ニチ</reading>
+<reading r_type="ja_on">ジツ</reading>
+<reading r_type="ja_kun">ひ</reading>
+<reading r_type="ja_kun">-び</reading>
+<reading r_type="ja_kun">-か</reading>
+<meaning>day</meaning>
+<meaning>sun</meaning>
+<meaning m_lang="fr">jour</meaning>
+</rmgroup>
+<nanori>あき</nanori>
+</reading_meaning>
+</character>
+<character>
+<literal>亜</literal>
+<misc>
+<grade>8</grade>
+<stroke_count>7</stroke_count>
+</misc>
+</character>
+</kanjidic2>`
+
+func TestParseKANJIDIC(t *testing.T) {
+	var entries []Entry
+	err := ParseKANJIDIC(strings.NewReader(kanjidicSample), func(e Entry) error {
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Entry{
+		{
+			Literal: "日", Grade: 1, JLPT: 4, StrokeCount: 4, Frequency: 1,
+			Meanings:    []string{"day", "sun"},
+			OnReadings:  []string{"ニチ", "ジツ"},
+			KunReadings: []string{"ひ", "-び", "-か"},
+		},
+		{Literal: "亜", Grade: 8, StrokeCount: 7},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("Got %+v, want %+v", entries, want)
+	}
+}
+
+func TestReadings(t *testing.T) {
+	got := Readings([]string{"イチ", "イツ"}, []string{"ひと-", "ひと.つ"})
+	want := []string{"イチ", "イツ", "ひと", "ひとつ"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got %v, want %v", got, want)
+	}
+}
+
+const kanjivgSample = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE svg PUBLIC "-//W3C//DTD SVG 1.0//EN" "http://www.w3.org/TR/2001/REC-SVG-20010904/DTD/svg10.dtd" [
+<!ATTLIST g
+xmlns:kvg CDATA #FIXED "http://kanjivg.tagaini.net"
+kvg:element CDATA #IMPLIED >
+]>
+<svg xmlns="http://www.w3.org/2000/svg" width="109" height="109" viewBox="0 0 109 109">
+<g id="kvg:StrokePaths_04e00" style="fill:none;stroke:#000000;stroke-width:3;">
+<g id="kvg:04e00" kvg:element="一" kvg:radical="general">
+	<path id="kvg:04e00-s1" kvg:type="㇐" d="M11,54.25c3.19,0.62,6.25,0.75,9.73,0.5"/>
+</g>
+</g>
+<g id="kvg:StrokeNumbers_04e00" style="font-size:8;fill:#808080">
+	<text transform="matrix(1 0 0 1 4.25 54.13)">1</text>
+</g>
+</svg>`
+
+func TestWalkKanjiVG(t *testing.T) {
+	fsys := fstest.MapFS{
+		"04e00.svg":        {Data: []byte(kanjivgSample)},
+		"04e00-Kaisho.svg": {Data: []byte(kanjivgSample)},
+		"README.md":        {Data: []byte("KanjiVG")},
+	}
+	got := make(map[string][]string)
+	err := WalkKanjiVG(fsys, func(literal string, paths []string) error {
+		got[literal] = paths
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]string{"一": {"M11,54.25c3.19,0.62,6.25,0.75,9.73,0.5"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+// Package kanji reads the KANJIDIC2 character dictionary and the KanjiVG
+// stroke order files for `haiji import kanji`.
+package kanji
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Entry is one KANJIDIC2 character. Numbers are 0 when the dictionary
+// doesn't give them.
+type Entry struct {
+	Literal     string
+	Grade       int
+	JLPT        int // old four-level test, 4 is the easiest
+	StrokeCount int
+	Frequency   int
+	Meanings    []string // English only
+	OnReadings  []string
+	KunReadings []string
+}
+
+type character struct {
+	Literal string `xml:"literal"`
+	Misc    struct {
+		Grade int `xml:"grade"`
+		// The first count is the accepted one, the rest are common miscounts
+		StrokeCounts []int `xml:"stroke_count"`
+		Freq         int   `xml:"freq"`
+		JLPT         int   `xml:"jlpt"`
+	} `xml:"misc"`
+	Groups []struct {
+		Readings []struct {
+			Type  string `xml:"r_type,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"reading"`
+		Meanings []struct {
+			Lang  string `xml:"m_lang,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"meaning"`
+	} `xml:"reading_meaning>rmgroup"`
+}
+
+// ParseKANJIDIC streams the characters of a KANJIDIC2 XML file to fn,
+// stopping at the first error fn returns.
+func ParseKANJIDIC(r io.Reader, fn func(Entry) error) error {
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read kanjidic: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "character" {
+			continue
+		}
+
+		var c character
+		if err := dec.DecodeElement(&c, &start); err != nil {
+			return fmt.Errorf("read kanjidic: %w", err)
+		}
+		if err := fn(c.entry()); err != nil {
+			return err
+		}
+	}
+}
+
+func (c character) entry() Entry {
+	e := Entry{
+		Literal:   strings.TrimSpace(c.Literal),
+		Grade:     c.Misc.Grade,
+		JLPT:      c.Misc.JLPT,
+		Frequency: c.Misc.Freq,
+	}
+	if len(c.Misc.StrokeCounts) > 0 {
+		e.StrokeCount = c.Misc.StrokeCounts[0]
+	}
+	for _, g := range c.Groups {
+		for _, r := range g.Readings {
+			switch r.Type {
+			case "ja_on":
+				e.OnReadings = append(e.OnReadings, r.Value)
+			case "ja_kun":
+				e.KunReadings = append(e.KunReadings, r.Value)
+			}
+		}
+		for _, m := range g.Meanings {
+			if m.Lang == "" || m.Lang == "en" {
+				e.Meanings = append(e.Meanings, m.Value)
+			}
+		}
+	}
+	return e
+}
+
+// Readings returns the kana an answer may read a kanji as: the on readings
+// and the kun readings both with and without their okurigana, so ひと.つ
+// gives ひとつ and ひと. Prefix and suffix markers are dropped.
+func Readings(on, kun []string) []string {
+	var readings []string
+	seen := make(map[string]bool)
+	add := func(r string) {
+		if r != "" && !seen[r] {
+			seen[r] = true
+			readings = append(readings, r)
+		}
+	}
+	for _, r := range on {
+		add(strings.Trim(r, "-"))
+	}
+	for _, r := range kun {
+		r = strings.Trim(r, "-")
+		stem, _, _ := strings.Cut(r, ".")
+		add(strings.ReplaceAll(r, ".", ""))
+		add(stem)
+	}
+	return readings
+}
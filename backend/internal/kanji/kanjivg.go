@@ -0,0 +1,74 @@
+package kanji
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ParseKanjiVG returns the SVG path data of a KanjiVG file's strokes, in
+// stroke order.
+func ParseKanjiVG(r io.Reader) ([]string, error) {
+	dec := xml.NewDecoder(r)
+	var paths []string
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return paths, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "path" {
+			continue
+		}
+		for _, attr := range start.Attr {
+			if attr.Name.Local == "d" && attr.Name.Space == "" {
+				paths = append(paths, attr.Value)
+			}
+		}
+	}
+}
+
+// WalkKanjiVG parses the files of a KanjiVG kanji directory, named after the
+// character's code point (065e5.svg is 日), and passes each character's
+// strokes to fn. Variant files such as 065e5-Kaisho.svg are skipped.
+func WalkKanjiVG(fsys fs.FS, fn func(literal string, paths []string) error) error {
+	return fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		literal, ok := literalFromFilename(path.Base(name))
+		if !ok {
+			return nil
+		}
+
+		f, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		paths, err := ParseKanjiVG(f)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", name, err)
+		}
+		return fn(literal, paths)
+	})
+}
+
+func literalFromFilename(name string) (string, bool) {
+	hex, ok := strings.CutSuffix(name, ".svg")
+	if !ok || strings.Contains(hex, "-") {
+		return "", false
+	}
+	code, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return "", false
+	}
+	return string(rune(code)), true
+}
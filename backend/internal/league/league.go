@@ -0,0 +1,75 @@
+// Package league holds the rules of weekly leagues: players are split into
+// buckets within tiers, and when the week ends the top of every bucket moves
+// up a tier and the bottom moves down.
+package league
+
+import "time"
+
+// Tiers, lowest first.
+var Tiers = []string{"Bronze", "Silver", "Gold", "Sapphire", "Ruby", "Emerald", "Amethyst", "Pearl", "Obsidian", "Diamond"}
+
+type Outcome string
+
+const (
+	OutcomePromoted  Outcome = "promoted"
+	OutcomeRelegated Outcome = "relegated"
+	OutcomeStayed    Outcome = "stayed"
+)
+
+// Rules size the buckets and say how many players move at the end of a
+// week.
+type Rules struct {
+	BucketSize int
+	Promote    int
+	Relegate   int
+}
+
+var DefaultRules = Rules{BucketSize: 30, Promote: 5, Relegate: 5}
+
+// WeekStart is the start of the league week containing t: Monday, 00:00 UTC.
+func WeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	// Days since Monday
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// WeekEnd is when the week starting at start ends.
+func WeekEnd(start time.Time) time.Time {
+	return start.AddDate(0, 0, 7)
+}
+
+// TierName returns the name of a tier, clamped to the known ones.
+func TierName(tier int) string {
+	return Tiers[ClampTier(tier)]
+}
+
+func ClampTier(tier int) int {
+	return max(0, min(tier, len(Tiers)-1))
+}
+
+// Outcome says where the player ranked 0-based in a bucket of members ends
+// the week. Players without points aren't promoted, a small bucket promotes
+// before it relegates, and nobody leaves the top or bottom tier through
+// its edge.
+func (r Rules) Outcome(rank, members, tier int, points int64) Outcome {
+	switch {
+	case rank < r.Promote && points > 0 && tier < len(Tiers)-1:
+		return OutcomePromoted
+	case rank >= max(members-r.Relegate, r.Promote) && tier > 0:
+		return OutcomeRelegated
+	}
+	return OutcomeStayed
+}
+
+// NextTier is the tier a player plays in after the outcome.
+func NextTier(tier int, outcome Outcome) int {
+	switch outcome {
+	case OutcomePromoted:
+		tier++
+	case OutcomeRelegated:
+		tier--
+	}
+	return ClampTier(tier)
+}
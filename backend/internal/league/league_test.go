@@ -0,0 +1,58 @@
+package league
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	for _, at := range []time.Time{
+		monday,
+		time.Date(2026, 10, 21, 13, 30, 0, 0, time.UTC),
+		time.Date(2026, 10, 25, 23, 59, 59, 0, time.UTC), // Sunday
+	} {
+		if got := WeekStart(at); !got.Equal(monday) {
+			t.Errorf("WeekStart(%v) = %v, want %v", at, got, monday)
+		}
+	}
+	if got := WeekEnd(monday); !got.Equal(time.Date(2026, 10, 26, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected week end %v", got)
+	}
+}
+
+func TestOutcome(t *testing.T) {
+	r := Rules{BucketSize: 30, Promote: 5, Relegate: 5}
+	tests := []struct {
+		name                string
+		rank, members, tier int
+		points              int64
+		want                Outcome
+	}{
+		{"top of the bucket", 0, 30, 1, 100, OutcomePromoted},
+		{"last promoted", 4, 30, 1, 100, OutcomePromoted},
+		{"middle", 10, 30, 1, 100, OutcomeStayed},
+		{"bottom of the bucket", 29, 30, 1, 0, OutcomeRelegated},
+		{"first relegated", 25, 30, 1, 10, OutcomeRelegated},
+		{"no points, no promotion", 0, 30, 1, 0, OutcomeStayed},
+		{"top tier", 0, 30, len(Tiers) - 1, 100, OutcomeStayed},
+		{"bottom tier", 29, 30, 0, 0, OutcomeStayed},
+		// Six players: five go up, the sixth goes down
+		{"small bucket promotes first", 4, 6, 1, 10, OutcomePromoted},
+		{"small bucket relegates the rest", 5, 6, 1, 10, OutcomeRelegated},
+	}
+	for _, tt := range tests {
+		if got := r.Outcome(tt.rank, tt.members, tt.tier, tt.points); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNextTier(t *testing.T) {
+	if got := NextTier(0, OutcomeRelegated); got != 0 {
+		t.Errorf("Expected to stay in the bottom tier, got %d", got)
+	}
+	if got := NextTier(2, OutcomePromoted); got != 3 {
+		t.Errorf("Expected tier 3, got %d", got)
+	}
+}
@@ -0,0 +1,150 @@
+// Package progression turns activity into XP, XP into levels, and active
+// days into streaks counted in the learner's own time zone.
+package progression
+
+import (
+	"math"
+	"time"
+)
+
+// XP sources, recorded with every ledger entry.
+const (
+	SourcePractice = "practice"
+	SourceBattle   = "battle"
+)
+
+// Practice answers earn XP whether right or wrong; correct ones earn more.
+const (
+	XPPerAnswer        = 1
+	XPPerCorrectAnswer = 3
+)
+
+// Every finished battle earns XP; the podium earns a bonus on top.
+const XPPerBattle = 10
+
+var placementBonus = map[int]int64{1: 40, 2: 20, 3: 10}
+
+func PracticeXP(correct, total int) int64 {
+	return int64((total-correct)*XPPerAnswer + correct*XPPerCorrectAnswer)
+}
+
+// BattleXP rewards a battle finish. Solo games don't earn a placement bonus.
+func BattleXP(placement, players int) int64 {
+	if players < 2 {
+		return XPPerBattle
+	}
+	return XPPerBattle + placementBonus[placement]
+}
+
+// Curve is the level curve: going from level n to n+1 costs
+// Base * n^Exponent XP.
+type Curve struct {
+	Base     float64
+	Exponent float64
+}
+
+var DefaultCurve = Curve{Base: 100, Exponent: 1.5}
+
+// MaxLevel stops the climb on flat or tiny curves.
+const MaxLevel = 999
+
+type Level struct {
+	Level int
+	XP    int64 // earned since reaching Level
+	Next  int64 // needed to reach the next level, 0 at MaxLevel
+}
+
+// Cost returns the XP needed to go from level to level+1.
+func (c Curve) Cost(level int) int64 {
+	return max(int64(math.Round(c.Base*math.Pow(float64(level), c.Exponent))), 1)
+}
+
+func (c Curve) Level(xp int64) Level {
+	level := 1
+	for level < MaxLevel {
+		cost := c.Cost(level)
+		if xp < cost {
+			return Level{Level: level, XP: xp, Next: cost}
+		}
+		xp -= cost
+		level++
+	}
+	return Level{Level: MaxLevel, XP: xp}
+}
+
+// A freeze is earned every FreezeEvery days of streak, holding at most
+// MaxFreezes. Each one covers a missed day.
+const (
+	FreezeEvery = 7
+	MaxFreezes  = 2
+)
+
+// Streak is a run of consecutive active days. Days are calendar dates at
+// midnight UTC, as returned by Day.
+type Streak struct {
+	Current int
+	Longest int
+	LastDay time.Time // zero if never active
+	Freezes int
+}
+
+// Day returns the calendar date of t in loc.
+func Day(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Location loads an IANA time zone, falling back to UTC for names the
+// system doesn't know.
+func Location(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
+
+// Extend records activity on day. Missed days since the last active one are
+// covered by freezes when there are enough of them; otherwise the streak
+// starts over.
+func (s Streak) Extend(day time.Time) Streak {
+	if !s.LastDay.IsZero() {
+		gap := daysBetween(s.LastDay, day)
+		if gap <= 0 {
+			// Already counted, or the user moved to an earlier time zone
+			return s
+		}
+		missed := gap - 1
+		if missed <= s.Freezes {
+			s.Freezes -= missed
+			s.Current++
+		} else {
+			s.Current = 1
+		}
+	} else {
+		s.Current = 1
+	}
+
+	s.LastDay = day
+	s.Longest = max(s.Longest, s.Current)
+	if s.Current%FreezeEvery == 0 && s.Freezes < MaxFreezes {
+		s.Freezes++
+	}
+	return s
+}
+
+// Active returns the streak as shown on day: it still counts while the
+// missed days can be covered by freezes.
+func (s Streak) Active(day time.Time) int {
+	if s.LastDay.IsZero() {
+		return 0
+	}
+	if daysBetween(s.LastDay, day)-1 > s.Freezes {
+		return 0
+	}
+	return s.Current
+}
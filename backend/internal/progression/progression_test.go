@@ -0,0 +1,94 @@
+package progression
+
+import (
+	"testing"
+	"time"
+)
+
+func day(n int) time.Time {
+	return time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, n)
+}
+
+func TestCurve_Level(t *testing.T) {
+	c := Curve{Base: 100, Exponent: 1}
+
+	tests := []struct {
+		xp   int64
+		want Level
+	}{
+		{0, Level{Level: 1, XP: 0, Next: 100}},
+		{99, Level{Level: 1, XP: 99, Next: 100}},
+		{100, Level{Level: 2, XP: 0, Next: 200}},
+		{350, Level{Level: 3, XP: 50, Next: 300}},
+	}
+	for _, tt := range tests {
+		if got := c.Level(tt.xp); got != tt.want {
+			t.Errorf("Level(%d) = %+v, want %+v", tt.xp, got, tt.want)
+		}
+	}
+
+	if got := (Curve{Base: 0, Exponent: 0}).Level(1 << 40); got.Level != MaxLevel {
+		t.Errorf("Expected a flat curve to stop at MaxLevel, got %d", got.Level)
+	}
+}
+
+func TestBattleXP(t *testing.T) {
+	if got := BattleXP(1, 1); got != XPPerBattle {
+		t.Errorf("Solo game: got %d", got)
+	}
+	if BattleXP(1, 4) <= BattleXP(2, 4) || BattleXP(3, 4) <= BattleXP(4, 4) {
+		t.Error("Better placements should earn more XP")
+	}
+}
+
+func TestStreak_Extend(t *testing.T) {
+	var s Streak
+	for i := 0; i < FreezeEvery; i++ {
+		s = s.Extend(day(i))
+	}
+	if s.Current != FreezeEvery || s.Freezes != 1 {
+		t.Fatalf("After a week: got %+v", s)
+	}
+
+	if again := s.Extend(day(FreezeEvery - 1)); again != s {
+		t.Errorf("Same day shouldn't change the streak: %+v", again)
+	}
+
+	// One missed day is covered by the freeze
+	s = s.Extend(day(FreezeEvery + 1))
+	if s.Current != FreezeEvery+1 || s.Freezes != 0 {
+		t.Errorf("Expected the freeze to cover the gap, got %+v", s)
+	}
+
+	// The next gap breaks it
+	s = s.Extend(day(FreezeEvery + 3))
+	if s.Current != 1 || s.Longest != FreezeEvery+1 {
+		t.Errorf("Expected the streak to restart, got %+v", s)
+	}
+}
+
+func TestStreak_Active(t *testing.T) {
+	s := Streak{Current: 5, LastDay: day(0), Freezes: 1}
+
+	if got := s.Active(day(1)); got != 5 {
+		t.Errorf("Day after: got %d", got)
+	}
+	if got := s.Active(day(2)); got != 5 {
+		t.Errorf("One missed day with a freeze: got %d", got)
+	}
+	if got := s.Active(day(3)); got != 0 {
+		t.Errorf("Two missed days: got %d", got)
+	}
+}
+
+func TestDay_UsesTimeZone(t *testing.T) {
+	tokyo := Location("Asia/Tokyo")
+	// 20:00 UTC is already the next day in Tokyo
+	at := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+	if got := Day(at, tokyo); !got.Equal(day(1)) {
+		t.Errorf("Got %v", got)
+	}
+	if got := Day(at, Location("Not/AZone")); !got.Equal(day(0)) {
+		t.Errorf("Unknown zone should fall back to UTC, got %v", got)
+	}
+}
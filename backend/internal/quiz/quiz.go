@@ -0,0 +1,143 @@
+// Package quiz generates multiple-choice questions whose wrong choices are
+// the answers a learner is most likely to confuse with the right one.
+package quiz
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+)
+
+const (
+	MinChoices     = 2
+	MaxChoices     = 6
+	DefaultChoices = 4
+)
+
+type Question struct {
+	Kana    string   `json:"kana"`   // the character asked, whatever the direction
+	Prompt  string   `json:"prompt"` // what the player is shown
+	Choices []string `json:"choices"`
+	Answer  string   `json:"answer"`
+}
+
+type Options struct {
+	Count     int
+	Choices   int // per question, the answer included; 0 for typed answers
+	Direction kana.Direction
+	// Wrong answers the player has given, by character, most frequent first
+	Confusions map[string][]string
+}
+
+// Generate returns opts.Count questions on characters drawn from pool. Wrong
+// choices come first from the player's past mistakes, then from characters
+// shaped like the one asked, then from the rest of the pool. Past mistakes
+// are only offered if they answer some character, so typos aren't.
+func Generate(pool []kana.Char, opts Options, r *rand.Rand) []Question {
+	// Repeated groups or deck entries mustn't weigh a character twice
+	pool = uniqueChars(pool)
+	if len(pool) == 0 {
+		return nil
+	}
+	answers := poolAnswers(pool, opts.Direction)
+
+	questions := make([]Question, 0, opts.Count)
+	for len(questions) < opts.Count {
+		c := pool[r.IntN(len(pool))]
+		// Avoid the same prompt twice in a row, if there's another to ask
+		if len(pool) > 1 && len(questions) > 0 && questions[len(questions)-1].Kana == c.Kana {
+			continue
+		}
+		questions = append(questions, ask(c, pool, answers, opts, r))
+	}
+	return questions
+}
+
+// Ask returns a question on c, with wrong choices picked as Generate does.
+func Ask(c kana.Char, pool []kana.Char, opts Options, r *rand.Rand) Question {
+	return ask(c, pool, poolAnswers(pool, opts.Direction), opts, r)
+}
+
+// uniqueChars returns the pool with each character once, in order.
+func uniqueChars(pool []kana.Char) []kana.Char {
+	seen := make(map[string]bool, len(pool))
+	unique := make([]kana.Char, 0, len(pool))
+	for _, c := range pool {
+		if !seen[c.Kana] {
+			seen[c.Kana] = true
+			unique = append(unique, c)
+		}
+	}
+	return unique
+}
+
+func poolAnswers(pool []kana.Char, d kana.Direction) map[string]bool {
+	if d == "" {
+		d = kana.DirectionToRomaji
+	}
+	answers := make(map[string]bool)
+	for _, c := range pool {
+		answers[d.Expected(c)] = true
+	}
+	return answers
+}
+
+func ask(c kana.Char, pool []kana.Char, answers map[string]bool, opts Options, r *rand.Rand) Question {
+	d := opts.Direction
+	if d == "" {
+		d = kana.DirectionToRomaji
+	}
+	answer := d.Expected(c)
+	if opts.Choices == 0 {
+		return Question{Kana: c.Kana, Prompt: d.Prompt(c), Answer: answer}
+	}
+
+	choices := []string{answer}
+	add := func(choice string) {
+		if len(choices) == opts.Choices || choice == "" || slices.Contains(choices, choice) {
+			return
+		}
+		// A kanji's other readings would be right too
+		if d.IsCorrect(c, choice) {
+			return
+		}
+		choices = append(choices, choice)
+	}
+	for _, given := range opts.Confusions[c.Kana] {
+		if answers[given] || isCatalogAnswer(d, given) {
+			add(given)
+		}
+	}
+	for _, k := range kana.LookAlikes(c.Kana) {
+		if other, ok := kana.Lookup(k); ok {
+			add(d.Expected(other))
+		}
+	}
+	for _, i := range r.Perm(len(pool)) {
+		if len(choices) == opts.Choices {
+			break
+		}
+		add(d.Expected(pool[i]))
+	}
+
+	r.Shuffle(len(choices), func(i, j int) { choices[i], choices[j] = choices[j], choices[i] })
+	return Question{
+		Kana:    c.Kana,
+		Prompt:  d.Prompt(c),
+		Choices: choices,
+		Answer:  answer,
+	}
+}
+
+// isCatalogAnswer reports whether s answers any catalog character.
+func isCatalogAnswer(d kana.Direction, s string) bool {
+	for _, g := range kana.Groups() {
+		for _, c := range g.Chars {
+			if d.Expected(c) == s {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package quiz
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+)
+
+func katakana(t *testing.T) []kana.Char {
+	t.Helper()
+	chars, err := kana.CharsFor([]string{"ksingle", "kk", "ks", "kt", "kn", "kh", "km", "ky", "kr", "kw", "kn1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return chars
+}
+
+func TestGenerate(t *testing.T) {
+	pool := katakana(t)
+	questions := Generate(pool, Options{Count: 200, Choices: 4}, rand.New(rand.NewPCG(1, 2)))
+	if len(questions) != 200 {
+		t.Fatalf("Expected 200 questions, got %d", len(questions))
+	}
+	for i, q := range questions {
+		if len(q.Choices) != 4 || !slices.Contains(q.Choices, q.Answer) {
+			t.Errorf("%s: expected 4 choices with the answer, got %v", q.Kana, q.Choices)
+		}
+		if q.Prompt != q.Kana {
+			t.Errorf("Expected the kana as prompt, got %q", q.Prompt)
+		}
+		if i > 0 && questions[i-1].Kana == q.Kana {
+			t.Errorf("%s asked twice in a row", q.Kana)
+		}
+		if q.Kana == "シ" {
+			// Its look-alikes fill every wrong choice
+			for _, want := range []string{"tsu", "n", "so"} {
+				if !slices.Contains(q.Choices, want) {
+					t.Errorf("シ: expected %q among %v", want, q.Choices)
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateSingleKana(t *testing.T) {
+	n, err := kana.CharsFor([]string{"hn1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	twice, err := kana.CharsFor([]string{"hn1", "hn1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, pool := range map[string][]kana.Char{"single kana": n, "duplicated groups": twice} {
+		questions := Generate(pool, Options{Count: 3, Choices: 4}, rand.New(rand.NewPCG(1, 2)))
+		if len(questions) != 3 {
+			t.Fatalf("%s: expected 3 questions, got %d", name, len(questions))
+		}
+		for _, q := range questions {
+			if q.Kana != "ん" || q.Answer != "n" {
+				t.Errorf("%s: unexpected question %+v", name, q)
+			}
+		}
+	}
+}
+
+func TestGeneratePrefersPastMistakes(t *testing.T) {
+	pool := katakana(t)
+	opts := Options{
+		Count:   50,
+		Choices: 3,
+		// "shu" is a catalog answer, "sgi" a typo
+		Confusions: map[string][]string{"シ": {"sgi", "shu"}},
+	}
+	for _, q := range Generate(pool, opts, rand.New(rand.NewPCG(3, 4))) {
+		if q.Kana != "シ" {
+			continue
+		}
+		if !slices.Contains(q.Choices, "shu") || slices.Contains(q.Choices, "sgi") {
+			t.Errorf("Expected the past mistake but not the typo, got %v", q.Choices)
+		}
+	}
+}
+
+func TestGenerateDirections(t *testing.T) {
+	pool, _ := kana.CharsFor([]string{"hsingle", "hn"})
+	for _, q := range Generate(pool, Options{Count: 30, Choices: 4, Direction: kana.DirectionToKana}, rand.New(rand.NewPCG(5, 6))) {
+		c, _ := kana.Lookup(q.Answer)
+		if q.Answer != q.Kana || q.Prompt != c.Romanji {
+			t.Errorf("Expected a romaji prompt answered by its kana, got %+v", q)
+		}
+		for _, choice := range q.Choices {
+			if !kana.IsKanaText(choice) {
+				t.Errorf("Expected kana choices, got %v", q.Choices)
+			}
+		}
+	}
+}
+
+func TestGenerateSkipsOtherCorrectReadings(t *testing.T) {
+	pool := []kana.Char{
+		{Kana: "日", Romanji: "nichi", Readings: []string{"ニチ", "ひ", "か"}},
+		{Kana: "火", Romanji: "ka", Readings: []string{"カ", "ひ"}},
+		{Kana: "木", Romanji: "moku", Readings: []string{"モク", "き"}},
+	}
+	for _, q := range Generate(pool, Options{Count: 20, Choices: 3}, rand.New(rand.NewPCG(7, 8))) {
+		if q.Kana == "日" && slices.Contains(q.Choices, "ka") {
+			t.Errorf("ka also reads 日, got %v", q.Choices)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package reading
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Glosses kept from a word's first sense.
+const maxGlosses = 3
+
+type jmdictEntry struct {
+	Seq      string   `xml:"ent_seq"`
+	Kanji    []string `xml:"k_ele>keb"`
+	Readings []string `xml:"r_ele>reb"`
+	Senses   []struct {
+		Glosses []struct {
+			Lang  string `xml:"lang,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"gloss"`
+	} `xml:"sense"`
+}
+
+// ParseJMdict streams the words of a JMdict XML file to fn, stopping at the
+// first error fn returns. A word is read by its first reading written in
+// catalog kana; entries with none are skipped.
+func ParseJMdict(r io.Reader, fn func(Item) error) error {
+	dec := xml.NewDecoder(r)
+	// JMdict marks parts of speech with entities (&n;, &v5r;) declared in
+	// its DTD, which the decoder doesn't read; lenient mode leaves them as
+	// text
+	dec.Strict = false
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read jmdict: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "entry" {
+			continue
+		}
+
+		var e jmdictEntry
+		if err := dec.DecodeElement(&e, &start); err != nil {
+			return fmt.Errorf("read jmdict: %w", err)
+		}
+		item, ok := e.item()
+		if !ok {
+			continue
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+}
+
+func (e jmdictEntry) item() (Item, bool) {
+	for _, text := range e.Readings {
+		groups, ok := readable(text)
+		if !ok {
+			continue
+		}
+		item := Item{
+			Kind:     KindWord,
+			Source:   "jmdict",
+			SourceID: strings.TrimSpace(e.Seq),
+			Text:     text,
+			Meaning:  e.meaning(),
+			Groups:   groups,
+		}
+		if len(e.Kanji) > 0 {
+			item.Written = e.Kanji[0]
+		}
+		return item, true
+	}
+	return Item{}, false
+}
+
+func (e jmdictEntry) meaning() string {
+	if len(e.Senses) == 0 {
+		return ""
+	}
+	var glosses []string
+	for _, g := range e.Senses[0].Glosses {
+		if (g.Lang == "" || g.Lang == "eng") && len(glosses) < maxGlosses {
+			glosses = append(glosses, g.Value)
+		}
+	}
+	return strings.Join(glosses, "; ")
+}
@@ -0,0 +1,47 @@
+// Package reading reads words from JMdict and sentences from Tatoeba for
+// `haiji import reading`, keeping those written entirely in catalog kana.
+package reading
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+)
+
+type Kind string
+
+const (
+	KindWord     Kind = "word"
+	KindSentence Kind = "sentence"
+)
+
+// Item is a word or sentence to read.
+type Item struct {
+	Kind     Kind
+	Source   string // "jmdict" or "tatoeba"
+	SourceID string
+	Text     string   // kana, with punctuation for sentences
+	Written  string   // a word's usual kanji spelling, if it has one
+	Meaning  string   // English
+	Groups   []string // catalog groups needed to read Text
+}
+
+// Kana returns text without its punctuation and spaces.
+func Kana(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) || unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// readable returns the catalog groups needed to read text.
+func readable(text string) ([]string, bool) {
+	stripped := Kana(text)
+	if stripped == "" {
+		return nil, false
+	}
+	return kana.GroupsFor(stripped)
+}
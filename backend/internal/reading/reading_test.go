@@ -0,0 +1,84 @@
+package reading
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const jmdictSample = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE JMdict [
+<!ENTITY n "noun (common) (futsuumeishi)">
+]>
+<JMdict>
+<entry>
+<ent_seq>1467640</ent_seq>
+<k_ele><keb>猫</keb></k_ele>
+<r_ele><reb>ねこ</reb></r_ele>
+<sense><pos>&n;</pos><gloss>cat</gloss><gloss xml:lang="eng">feline</gloss><gloss xml:lang="ger">Katze</gloss></sense>
+<sense><gloss>shamisen</gloss></sense>
+</entry>
+<entry>
+<ent_seq>1074700</ent_seq>
+<r_ele><reb>ファン</reb></r_ele>
+<sense><gloss>fan</gloss></sense>
+</entry>
+<entry>
+<ent_seq>1049180</ent_seq>
+<r_ele><reb>ヴァイオリン</reb></r_ele>
+<r_ele><reb>バイオリン</reb></r_ele>
+<sense><gloss>violin</gloss></sense>
+</entry>
+</JMdict>`
+
+func TestParseJMdict(t *testing.T) {
+	var items []Item
+	err := ParseJMdict(strings.NewReader(jmdictSample), func(item Item) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Item{
+		{Kind: KindWord, Source: "jmdict", SourceID: "1467640", Text: "ねこ", Written: "猫", Meaning: "cat; feline", Groups: []string{"hk", "hn"}},
+		// ファン needs a small ァ, but バイオリン is readable
+		{Kind: KindWord, Source: "jmdict", SourceID: "1049180", Text: "バイオリン", Meaning: "violin", Groups: []string{"ksingle", "kr", "kn1", "kb"}},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("Got %+v, want %+v", items, want)
+	}
+}
+
+func TestParseTatoeba(t *testing.T) {
+	pairs := "4704\tわたしは ねこが すきです。\t1\tI like cats.\n" +
+		"4704\tわたしは ねこが すきです。\t2\tI love cats.\n" +
+		"4705\t猫が好きです。\t3\tI like cats.\n"
+	var items []Item
+	if err := ParseTatoeba(strings.NewReader(pairs), func(item Item) error {
+		items = append(items, item)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].SourceID != "4704" || items[0].Meaning != "I like cats." || items[0].Kind != KindSentence {
+		t.Errorf("Expected the kana sentence once with its first translation, got %+v", items)
+	}
+
+	sentences := "1\teng\tHello.\n2\tjpn\tこんにちは。\n"
+	items = nil
+	if err := ParseTatoeba(strings.NewReader(sentences), func(item Item) error {
+		items = append(items, item)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Text != "こんにちは。" || items[0].Meaning != "" {
+		t.Errorf("Expected the Japanese sentence, got %+v", items)
+	}
+
+	if err := ParseTatoeba(strings.NewReader("just text\n"), func(Item) error { return nil }); err == nil {
+		t.Error("Expected an error for a malformed line")
+	}
+}
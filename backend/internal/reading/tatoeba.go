@@ -0,0 +1,60 @@
+package reading
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseTatoeba streams the Japanese sentences of a Tatoeba TSV export to fn,
+// stopping at the first error fn returns. It reads both the sentences file
+// (id, language, text) and sentence pair exports (Japanese id, Japanese
+// text, translation id, translation), which also give the meaning. Each
+// sentence is passed once, with its first translation, and only if it's
+// written in catalog kana.
+func ParseTatoeba(r io.Reader, fn func(Item) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	seen := make(map[string]bool)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Split(scanner.Text(), "\t")
+
+		var id, text, meaning string
+		switch len(fields) {
+		case 3:
+			if fields[1] != "jpn" {
+				continue
+			}
+			id, text = fields[0], fields[2]
+		case 4:
+			id, text, meaning = fields[0], fields[1], fields[3]
+		default:
+			return fmt.Errorf("read tatoeba: line %d has %d fields, want 3 or 4", line, len(fields))
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		groups, ok := readable(text)
+		if !ok {
+			continue
+		}
+		err := fn(Item{
+			Kind:     KindSentence,
+			Source:   "tatoeba",
+			SourceID: id,
+			Text:     text,
+			Meaning:  meaning,
+			Groups:   groups,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read tatoeba: %w", err)
+	}
+	return nil
+}
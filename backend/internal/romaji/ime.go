@@ -0,0 +1,135 @@
+package romaji
+
+import "strings"
+
+// imePreferred settles keystrokes that spell more than one kana the way
+// common IMEs do.
+var imePreferred = map[string]string{
+	"i": "い", "e": "え", "o": "お", "wo": "を",
+	"wi": "うぃ", "we": "うぇ",
+	"ji": "じ", "zi": "じ", "zu": "ず", "di": "ぢ", "du": "づ",
+	"ja": "じゃ", "ju": "じゅ", "jo": "じょ",
+	"zya": "じゃ", "zyu": "じゅ", "zyo": "じょ",
+	"ti": "ち", "tu": "つ",
+}
+
+// smallKana is typed with an x or l prefix, e.g. "xtu" or "lya".
+var smallKana = map[string]string{
+	"a": "ぁ", "i": "ぃ", "u": "ぅ", "e": "ぇ", "o": "ぉ",
+	"ya": "ゃ", "yu": "ゅ", "yo": "ょ", "wa": "ゎ",
+	"tu": "っ", "tsu": "っ", "ka": "ゕ", "ke": "ゖ",
+}
+
+// imeSpellings maps every keystroke sequence to the hiragana it types.
+var imeSpellings, imeMaxLength = func() (map[string]string, int) {
+	m := make(map[string]string)
+	for kana, s := range syllables {
+		for _, romaji := range s {
+			m[romaji] = kana
+		}
+	}
+	for romaji, kana := range imePreferred {
+		m[romaji] = kana
+	}
+	for romaji, kana := range smallKana {
+		m["x"+romaji] = kana
+		m["l"+romaji] = kana
+	}
+
+	longest := 0
+	for romaji := range m {
+		longest = max(longest, len(romaji))
+	}
+	return m, longest
+}()
+
+// ToHiragana converts romaji keystrokes to hiragana the way an IME does:
+// "nn", n' and n before a consonant or at the end are ん, a doubled
+// consonant is っ ("kitte", "matcha"), x or l types a small kana ("xtu",
+// "lya") and "-" is ー. Kana is kept and letters that don't spell anything
+// are left as they are.
+func ToHiragana(input string) string {
+	return convert(input, false)
+}
+
+// ToKatakana is ToHiragana for katakana. Only what is converted from romaji
+// becomes katakana; kana in the input is kept as typed.
+func ToKatakana(input string) string {
+	return convert(input, true)
+}
+
+func convert(input string, katakana bool) string {
+	s := []rune(strings.ToLower(input))
+	var out strings.Builder
+	write := func(kana string) {
+		if katakana {
+			kana = toKatakana(kana)
+		}
+		out.WriteString(kana)
+	}
+
+	for i := 0; i < len(s); {
+		r := s[i]
+		next := rune(0)
+		if i+1 < len(s) {
+			next = s[i+1]
+		}
+
+		switch {
+		case r == '-':
+			out.WriteRune('ー')
+			i++
+			continue
+		case r == 'n' && !isVowelOrY(next):
+			write("ん")
+			i++
+			// The second n of "nn" is part of ん unless a syllable follows
+			if next == '\'' || next == 'n' && (i+1 >= len(s) || !isVowelOrY(s[i+1])) {
+				i++
+			}
+			continue
+		case isConsonant(r) && (next == r || r == 't' && next == 'c'):
+			// "tch" doubles the ch, as in Hepburn
+			write("っ")
+			i++
+			continue
+		}
+
+		if kana, size, ok := spellingAt(s, i); ok {
+			write(kana)
+			i += size
+			continue
+		}
+		out.WriteRune(r)
+		i++
+	}
+	return out.String()
+}
+
+// spellingAt returns the longest keystroke sequence starting at s[i].
+func spellingAt(s []rune, i int) (string, int, bool) {
+	for size := min(imeMaxLength, len(s)-i); size > 0; size-- {
+		if kana, ok := imeSpellings[string(s[i:i+size])]; ok {
+			return kana, size, true
+		}
+	}
+	return "", 0, false
+}
+
+func isVowelOrY(r rune) bool {
+	return strings.ContainsRune("aiueoy", r)
+}
+
+func isConsonant(r rune) bool {
+	return r >= 'a' && r <= 'z' && r != 'n' && !isVowelOrY(r)
+}
+
+// toKatakana maps hiragana to katakana, leaving everything else as is.
+func toKatakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			return r + ('ァ' - 'ぁ')
+		}
+		return r
+	}, s)
+}
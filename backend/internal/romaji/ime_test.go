@@ -0,0 +1,63 @@
+package romaji
+
+import "testing"
+
+func TestToHiragana(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"konnichiha", "こんにちは"},
+		{"shinbun", "しんぶん"},
+		{"hon", "ほん"},
+		{"honn", "ほん"},
+		{"kon'ya", "こんや"},
+		{"kitte", "きって"},
+		{"matcha", "まっちゃ"},
+		{"xtu", "っ"},
+		{"ltsu", "っ"},
+		{"lya", "ゃ"},
+		{"KYA", "きゃ"},
+		{"sikata", "しかた"},
+		{"wo", "を"},
+		{"di", "ぢ"},
+		{"ko-hi-", "こーひー"},
+		{"ねko", "ねこ"},
+		{"k", "k"},
+	}
+	for _, tt := range tests {
+		if got := ToHiragana(tt.input); got != tt.want {
+			t.Errorf("ToHiragana(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestToKatakana(t *testing.T) {
+	if got := ToKatakana("fairu"); got != "ファイル" {
+		t.Errorf("Got %q", got)
+	}
+	if got := ToKatakana("ko-hi-"); got != "コーヒー" {
+		t.Errorf("Got %q", got)
+	}
+	// Typed kana is kept as is
+	if got := ToKatakana("ねko"); got != "ねコ" {
+		t.Errorf("Got %q", got)
+	}
+}
+
+func TestIMEPreferredCoversAmbiguousSpellings(t *testing.T) {
+	spelled := make(map[string]map[string]bool)
+	for kana, s := range syllables {
+		for _, romaji := range s {
+			if spelled[romaji] == nil {
+				spelled[romaji] = make(map[string]bool)
+			}
+			spelled[romaji][kana] = true
+		}
+	}
+	for romaji, kana := range spelled {
+		if _, ok := imePreferred[romaji]; len(kana) > 1 && !ok {
+			t.Errorf("%q spells %v but has no preferred kana", romaji, kana)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// Package romaji converts kana to the major romanization systems and checks
+// typed answers against them.
+package romaji
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type System string
+
+const (
+	Hepburn    System = "hepburn"
+	Kunrei     System = "kunrei"
+	NihonShiki System = "nihon"
+)
+
+// Systems lists every supported system, in spelling table order.
+var Systems = []System{Hepburn, Kunrei, NihonShiki}
+
+var ErrUnknownSystem = errors.New("unknown romanization system")
+
+func ParseSystem(name string) (System, error) {
+	for _, s := range Systems {
+		if string(s) == strings.ToLower(strings.TrimSpace(name)) {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownSystem, name)
+}
+
+// ParseSystems parses a list of system names, dropping duplicates.
+func ParseSystems(names []string) ([]System, error) {
+	var systems []System
+	seen := make(map[System]bool, len(names))
+	for _, name := range names {
+		s, err := ParseSystem(name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[s] {
+			seen[s] = true
+			systems = append(systems, s)
+		}
+	}
+	return systems, nil
+}
+
+func (s System) index() int {
+	for i, sys := range Systems {
+		if sys == s {
+			return i
+		}
+	}
+	return 0
+}
+
+// Romanize spells kana in the given system. Long vowel marks repeat the
+// previous vowel, small っ doubles the next consonant (っち is "tchi" in
+// Hepburn), and ん before a vowel or y is written n'. It returns false if
+// the text isn't kana.
+func Romanize(kana string, system System) (string, bool) {
+	runes := []rune(toHiragana(kana))
+	idx := system.index()
+
+	var out strings.Builder
+	geminate := false
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch r {
+		case 'っ':
+			if geminate {
+				// Doubled っ has nothing to double
+				out.WriteString(smallTsu(system))
+			}
+			geminate = true
+			i++
+			continue
+		case 'ー':
+			if v, ok := lastVowel(out.String()); ok {
+				out.WriteByte(v)
+			}
+			i++
+			continue
+		case 'ん':
+			out.WriteByte('n')
+			if next, _, ok := syllableAt(runes, i+1); ok && startsWithVowelOrY(next[idx]) {
+				out.WriteByte('\'')
+			}
+			i++
+			continue
+		}
+
+		syl, size, ok := syllableAt(runes, i)
+		if !ok {
+			return "", false
+		}
+		romaji := syl[idx]
+		if geminate {
+			if startsWithVowelOrY(romaji) || romaji == "n" {
+				out.WriteString(smallTsu(system))
+			} else if system == Hepburn && strings.HasPrefix(romaji, "ch") {
+				out.WriteByte('t')
+			} else {
+				out.WriteByte(romaji[0])
+			}
+			geminate = false
+		}
+		out.WriteString(romaji)
+		i += size
+	}
+	if geminate {
+		out.WriteString(smallTsu(system))
+	}
+	return out.String(), true
+}
+
+// Matches reports whether answer is a valid romanization of kana in any of
+// the systems, or in every system if none are given. Case, spaces and
+// macron or circumflex long vowels are accepted, so "Tōkyō", "toukyou" and
+// "tookyoo" all match とうきょう. An apostrophe after n before a vowel or y
+// tells ん apart: "kan'i" is かんい and "kani" is かに.
+func Matches(kana, answer string, systems ...System) bool {
+	if len(systems) == 0 {
+		systems = Systems
+	}
+	want := matchKey(answer)
+	if want == "" {
+		return false
+	}
+	for _, s := range systems {
+		if romaji, ok := Romanize(kana, s); ok && matchKey(romaji) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// Only this many particles of a sentence are tried both ways, capping the
+// readings tried at 2^maxParticles.
+const maxParticles = 8
+
+// MatchesSentence is Matches for running text. Punctuation and spaces are
+// ignored and the particles は and へ may also be read "wa" and "e", as
+// they are romanized in sentences. Without splitting the text into words
+// any は or へ could be a particle, so all of them are tried both ways.
+func MatchesSentence(text, answer string, systems ...System) bool {
+	answer = strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) {
+			return -1
+		}
+		return r
+	}, answer)
+	runes := []rune(strings.Map(func(r rune) rune {
+		if isKana(r) {
+			return r
+		}
+		return -1
+	}, text))
+
+	var particles []int
+	for i, r := range runes {
+		if _, ok := particleReadings[r]; ok && len(particles) < maxParticles {
+			particles = append(particles, i)
+		}
+	}
+	for mask := 0; mask < 1<<len(particles); mask++ {
+		reading := append([]rune(nil), runes...)
+		for bit, i := range particles {
+			if mask&(1<<bit) != 0 {
+				reading[i] = particleReadings[reading[i]]
+			}
+		}
+		if Matches(string(reading), answer, systems...) {
+			return true
+		}
+	}
+	return false
+}
+
+var particleReadings = map[rune]rune{'は': 'わ', 'へ': 'え'}
+
+func isKana(r rune) bool {
+	return r >= 'ぁ' && r <= 'ゖ' || r >= 'ァ' && r <= 'ヺ' || r == 'ー'
+}
+
+var longVowels = strings.NewReplacer(
+	"ā", "aa", "ī", "ii", "ū", "uu", "ē", "ee", "ō", "oo",
+	"â", "aa", "î", "ii", "û", "uu", "ê", "ee", "ô", "oo",
+	// おう and おお are both a long o
+	"ou", "oo",
+)
+
+// matchKey normalizes a romanization for comparison. Apostrophes are kept
+// only where they split n from a following vowel or y; anywhere else they
+// can't change the reading.
+func matchKey(s string) string {
+	s = strings.ToLower(s)
+	runes := []rune(strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '-':
+			return -1
+		case '’':
+			return '\''
+		}
+		return r
+	}, s))
+
+	var out strings.Builder
+	for i, r := range runes {
+		if r == '\'' {
+			if i == 0 || runes[i-1] != 'n' || i+1 == len(runes) || !strings.ContainsRune("aiueoy", runes[i+1]) {
+				continue
+			}
+		}
+		out.WriteRune(r)
+	}
+	return longVowels.Replace(out.String())
+}
+
+// syllableAt returns the longest syllable starting at runes[i]: a kana with
+// its small companion (きゃ, ファ) or a single kana.
+func syllableAt(runes []rune, i int) (spelling, int, bool) {
+	if i >= len(runes) {
+		return spelling{}, 0, false
+	}
+	if i+1 < len(runes) {
+		if syl, ok := syllables[string(runes[i:i+2])]; ok {
+			return syl, 2, true
+		}
+	}
+	if syl, ok := syllables[string(runes[i])]; ok {
+		return syl, 1, true
+	}
+	// A small vowel on its own is read as the full vowel
+	if v, ok := smallVowels[runes[i]]; ok {
+		return same(v), 1, true
+	}
+	return spelling{}, 0, false
+}
+
+var smallVowels = map[rune]string{
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+	'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo", 'ゎ': "wa",
+}
+
+// smallTsu spells a っ with no consonant after it, IME style.
+func smallTsu(system System) string {
+	if system == Hepburn {
+		return "xtsu"
+	}
+	return "xtu"
+}
+
+func startsWithVowelOrY(romaji string) bool {
+	return romaji != "" && strings.ContainsRune("aiueoy", rune(romaji[0]))
+}
+
+func lastVowel(s string) (byte, bool) {
+	for i := len(s) - 1; i >= 0; i-- {
+		if strings.IndexByte("aiueo", s[i]) >= 0 {
+			return s[i], true
+		}
+	}
+	return 0, false
+}
+
+// toHiragana maps katakana to hiragana, leaving everything else as is.
+func toHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - ('ァ' - 'ぁ')
+		}
+		return r
+	}, s)
+}
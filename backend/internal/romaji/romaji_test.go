@@ -0,0 +1,119 @@
+package romaji
+
+import "testing"
+
+func TestRomanize(t *testing.T) {
+	tests := []struct {
+		kana                   string
+		hepburn, kunrei, nihon string
+	}{
+		{"し", "shi", "si", "si"},
+		{"つ", "tsu", "tu", "tu"},
+		{"ぢ", "ji", "zi", "di"},
+		{"を", "o", "o", "wo"},
+		{"しゃ", "sha", "sya", "sya"},
+		{"ぢょ", "jo", "zyo", "dyo"},
+		{"キャ", "kya", "kya", "kya"},
+		{"がっこう", "gakkou", "gakkou", "gakkou"},
+		{"まっちゃ", "matcha", "mattya", "mattya"},
+		{"きんえん", "kin'en", "kin'en", "kin'en"},
+		{"ほんや", "hon'ya", "hon'ya", "hon'ya"},
+		{"さんぽ", "sanpo", "sanpo", "sanpo"},
+		{"コーヒー", "koohii", "koohii", "koohii"},
+		{"ファン", "fan", "fan", "fan"},
+		{"あっ", "axtsu", "axtu", "axtu"},
+	}
+	for _, tt := range tests {
+		for system, want := range map[System]string{Hepburn: tt.hepburn, Kunrei: tt.kunrei, NihonShiki: tt.nihon} {
+			got, ok := Romanize(tt.kana, system)
+			if !ok || got != want {
+				t.Errorf("Romanize(%q, %s) = %q, %v; want %q", tt.kana, system, got, ok, want)
+			}
+		}
+	}
+
+	if _, ok := Romanize("猫", Hepburn); ok {
+		t.Error("Expected kanji to be rejected")
+	}
+}
+
+func TestMatches(t *testing.T) {
+	accepted := []struct {
+		kana, answer string
+	}{
+		{"し", "SI"},
+		{"つ", " tu "},
+		{"ぢ", "di"},
+		{"とうきょう", "Tōkyō"},
+		{"とうきょう", "toukyou"},
+		{"とうきょう", "tôkyô"},
+		{"きんえん", "kin’en"},
+		{"かんじ", "kan'ji"}, // an apostrophe before a consonant changes nothing
+		{"コーヒー", "kōhī"},
+	}
+	for _, tt := range accepted {
+		if !Matches(tt.kana, tt.answer) {
+			t.Errorf("Matches(%q, %q) = false", tt.kana, tt.answer)
+		}
+	}
+
+	if Matches("し", "si", Hepburn) {
+		t.Error("si shouldn't match in Hepburn only")
+	}
+	if !Matches("し", "si", Hepburn, Kunrei) {
+		t.Error("si should match with Kunrei accepted")
+	}
+	if Matches("とうきょう", "tokyo") || Matches("し", "") {
+		t.Error("Short vowels and empty answers shouldn't match")
+	}
+
+	// The apostrophe decides where ん ends
+	rejected := []struct {
+		kana, answer string
+	}{
+		{"かんい", "kani"},
+		{"かに", "kan'i"},
+		{"しにょう", "shin'you"},
+		{"しんよう", "shinyou"},
+		{"きんえん", "kinen"},
+	}
+	for _, tt := range rejected {
+		if Matches(tt.kana, tt.answer) {
+			t.Errorf("Matches(%q, %q) = true", tt.kana, tt.answer)
+		}
+	}
+	if !Matches("しにょう", "shinyou") || !Matches("しんよう", "shin'you") {
+		t.Error("Expected each reading to match its own spelling")
+	}
+}
+
+func TestMatchesSentence(t *testing.T) {
+	for _, answer := range []string{
+		"watashi wa gakusei desu",
+		"watasi ha gakusei desu",
+		"Watashiwa gakusei desu.",
+	} {
+		if !MatchesSentence("わたしは　がくせいです。", answer) {
+			t.Errorf("%q should match", answer)
+		}
+	}
+	if !MatchesSentence("がっこうへいく", "gakkou e iku") {
+		t.Error("へ should be read as e")
+	}
+	if !MatchesSentence("はなはきれい？", "hana wa kirei?") {
+		t.Error("Question marks should be ignored")
+	}
+	if MatchesSentence("わたしはがくせいです", "watashi wa sensei desu") {
+		t.Error("A different sentence shouldn't match")
+	}
+}
+
+func TestParseSystems(t *testing.T) {
+	systems, err := ParseSystems([]string{"Hepburn", "nihon", "hepburn"})
+	if err != nil || len(systems) != 2 || systems[0] != Hepburn || systems[1] != NihonShiki {
+		t.Errorf("Got %v, %v", systems, err)
+	}
+	if _, err := ParseSystems([]string{"wapuro"}); err == nil {
+		t.Error("Expected error for unknown system")
+	}
+}
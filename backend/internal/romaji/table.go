@@ -0,0 +1,147 @@
+package romaji
+
+// spelling is a syllable in each system, in Systems order.
+type spelling [3]string
+
+func same(s string) spelling {
+	return spelling{s, s, s}
+}
+
+// syllables maps hiragana, alone or with a small kana, to its spellings.
+// Katakana is looked up through its hiragana counterpart. Extended katakana
+// sounds (ファ, ティ, ...) have no Kunrei or Nihon-shiki standard and use
+// the Hepburn spelling everywhere.
+var syllables = map[string]spelling{
+	"あ":  same("a"),
+	"い":  same("i"),
+	"う":  same("u"),
+	"え":  same("e"),
+	"お":  same("o"),
+	"か":  same("ka"),
+	"き":  same("ki"),
+	"く":  same("ku"),
+	"け":  same("ke"),
+	"こ":  same("ko"),
+	"さ":  same("sa"),
+	"し":  {"shi", "si", "si"},
+	"す":  same("su"),
+	"せ":  same("se"),
+	"そ":  same("so"),
+	"た":  same("ta"),
+	"ち":  {"chi", "ti", "ti"},
+	"つ":  {"tsu", "tu", "tu"},
+	"て":  same("te"),
+	"と":  same("to"),
+	"な":  same("na"),
+	"に":  same("ni"),
+	"ぬ":  same("nu"),
+	"ね":  same("ne"),
+	"の":  same("no"),
+	"は":  same("ha"),
+	"ひ":  same("hi"),
+	"ふ":  {"fu", "hu", "hu"},
+	"へ":  same("he"),
+	"ほ":  same("ho"),
+	"ま":  same("ma"),
+	"み":  same("mi"),
+	"む":  same("mu"),
+	"め":  same("me"),
+	"も":  same("mo"),
+	"や":  same("ya"),
+	"ゆ":  same("yu"),
+	"よ":  same("yo"),
+	"ら":  same("ra"),
+	"り":  same("ri"),
+	"る":  same("ru"),
+	"れ":  same("re"),
+	"ろ":  same("ro"),
+	"わ":  same("wa"),
+	"ゐ":  {"i", "i", "wi"},
+	"ゑ":  {"e", "e", "we"},
+	"を":  {"o", "o", "wo"},
+	"が":  same("ga"),
+	"ぎ":  same("gi"),
+	"ぐ":  same("gu"),
+	"げ":  same("ge"),
+	"ご":  same("go"),
+	"ざ":  same("za"),
+	"じ":  {"ji", "zi", "zi"},
+	"ず":  same("zu"),
+	"ぜ":  same("ze"),
+	"ぞ":  same("zo"),
+	"だ":  same("da"),
+	"ぢ":  {"ji", "zi", "di"},
+	"づ":  {"zu", "zu", "du"},
+	"で":  same("de"),
+	"ど":  same("do"),
+	"ば":  same("ba"),
+	"び":  same("bi"),
+	"ぶ":  same("bu"),
+	"べ":  same("be"),
+	"ぼ":  same("bo"),
+	"ぱ":  same("pa"),
+	"ぴ":  same("pi"),
+	"ぷ":  same("pu"),
+	"ぺ":  same("pe"),
+	"ぽ":  same("po"),
+	"ゔ":  same("vu"),
+	"きゃ": same("kya"),
+	"きゅ": same("kyu"),
+	"きょ": same("kyo"),
+	"にゃ": same("nya"),
+	"にゅ": same("nyu"),
+	"にょ": same("nyo"),
+	"ひゃ": same("hya"),
+	"ひゅ": same("hyu"),
+	"ひょ": same("hyo"),
+	"みゃ": same("mya"),
+	"みゅ": same("myu"),
+	"みょ": same("myo"),
+	"りゃ": same("rya"),
+	"りゅ": same("ryu"),
+	"りょ": same("ryo"),
+	"ぎゃ": same("gya"),
+	"ぎゅ": same("gyu"),
+	"ぎょ": same("gyo"),
+	"びゃ": same("bya"),
+	"びゅ": same("byu"),
+	"びょ": same("byo"),
+	"ぴゃ": same("pya"),
+	"ぴゅ": same("pyu"),
+	"ぴょ": same("pyo"),
+	"しゃ": {"sha", "sya", "sya"},
+	"ちゃ": {"cha", "tya", "tya"},
+	"じゃ": {"ja", "zya", "zya"},
+	"ぢゃ": {"ja", "zya", "dya"},
+	"しゅ": {"shu", "syu", "syu"},
+	"ちゅ": {"chu", "tyu", "tyu"},
+	"じゅ": {"ju", "zyu", "zyu"},
+	"ぢゅ": {"ju", "zyu", "dyu"},
+	"しょ": {"sho", "syo", "syo"},
+	"ちょ": {"cho", "tyo", "tyo"},
+	"じょ": {"jo", "zyo", "zyo"},
+	"ぢょ": {"jo", "zyo", "dyo"},
+	"ふぁ": same("fa"),
+	"ふぃ": same("fi"),
+	"ふぇ": same("fe"),
+	"ふぉ": same("fo"),
+	"てぃ": same("ti"),
+	"でぃ": same("di"),
+	"とぅ": same("tu"),
+	"どぅ": same("du"),
+	"うぃ": same("wi"),
+	"うぇ": same("we"),
+	"うぉ": same("wo"),
+	"いぇ": same("ye"),
+	"つぁ": same("tsa"),
+	"つぃ": same("tsi"),
+	"つぇ": same("tse"),
+	"つぉ": same("tso"),
+	"ゔぁ": same("va"),
+	"ゔぃ": same("vi"),
+	"ゔぇ": same("ve"),
+	"ゔぉ": same("vo"),
+	"しぇ": {"she", "sye", "sye"},
+	"ちぇ": {"che", "tye", "tye"},
+	"じぇ": {"je", "zye", "zye"},
+}
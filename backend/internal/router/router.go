@@ -18,6 +18,23 @@ func New(
 	authHandler *handlers.AuthHandler,
 	gameHandler *handlers.GameHandler,
 	systemHandler *handlers.SystemHandler,
+	dailyHandler *handlers.DailyHandler,
+	ghostHandler *handlers.GhostHandler,
+	reviewHandler *handlers.ReviewHandler,
+	practiceHandler *handlers.PracticeHandler,
+	achievementHandler *handlers.AchievementHandler,
+	progressionHandler *handlers.ProgressionHandler,
+	deckHandler *handlers.DeckHandler,
+	handwritingHandler *handlers.HandwritingHandler,
+	kanjiHandler *handlers.KanjiHandler,
+	readingHandler *handlers.ReadingHandler,
+	quizHandler *handlers.QuizHandler,
+	curriculumHandler *handlers.CurriculumHandler,
+	classroomHandler *handlers.ClassroomHandler,
+	sprintHandler *handlers.SprintHandler,
+	leagueHandler *handlers.LeagueHandler,
+	shopHandler *handlers.ShopHandler,
+	shareHandler *handlers.ShareHandler,
 ) http.Handler {
 
 	// Rate limiters
@@ -25,6 +42,8 @@ func New(
 	registerLimiter := middleware.NewRateLimiter(5, time.Minute)
 	refreshLimiter := middleware.NewRateLimiter(60, time.Minute)
 	roomLimiter := middleware.NewRateLimiter(10, time.Minute)
+	ticketLimiter := middleware.NewRateLimiter(30, time.Minute)
+	recognizeLimiter := middleware.NewRateLimiter(120, time.Minute)
 	authMiddleware := middleware.AuthMiddleware(apiCFG)
 
 	mux := http.NewServeMux()
@@ -54,6 +73,8 @@ func New(
 	mux.Handle("POST /api/users", registerLimiter.Middleware(http.HandlerFunc(userHandler.Create)))
 	mux.Handle("PUT /api/users", authMiddleware(http.HandlerFunc(userHandler.Update)))
 	mux.Handle("GET /api/user-profile", authMiddleware(http.HandlerFunc(userHandler.GetProfile)))
+	mux.Handle("GET /api/users/me/achievements", authMiddleware(http.HandlerFunc(achievementHandler.List)))
+	mux.Handle("PUT /api/users/me/settings", authMiddleware(http.HandlerFunc(progressionHandler.UpdateSettings)))
 
 	mux.Handle("POST /api/login", loginLimiter.Middleware(http.HandlerFunc(authHandler.Login)))
 	mux.Handle("POST /api/refresh-token", refreshLimiter.Middleware(http.HandlerFunc(authHandler.RefreshToken)))
@@ -62,8 +83,85 @@ func New(
 
 	// Game Endpoints
 	mux.Handle("POST /api/kana-battle", authMiddleware(roomLimiter.Middleware(http.HandlerFunc(gameHandler.CreateRoom))))
+	mux.Handle("POST /api/ws-ticket", authMiddleware(ticketLimiter.Middleware(http.HandlerFunc(gameHandler.IssueWSTicket))))
 	mux.HandleFunc("/api/ws", gameHandler.HandleWS)
 
+	// Daily Challenge Endpoints
+	mux.Handle("GET /api/daily", authMiddleware(http.HandlerFunc(dailyHandler.GetToday)))
+	mux.Handle("POST /api/daily/start", authMiddleware(http.HandlerFunc(dailyHandler.Start)))
+	mux.Handle("POST /api/daily/finish", authMiddleware(http.HandlerFunc(dailyHandler.Finish)))
+	mux.HandleFunc("GET /api/daily/leaderboard", dailyHandler.Leaderboard)
+
+	// Ghost Battle Endpoints
+	mux.Handle("GET /api/runs", authMiddleware(http.HandlerFunc(ghostHandler.ListRuns)))
+	mux.Handle("POST /api/ghost-challenges", authMiddleware(http.HandlerFunc(ghostHandler.CreateChallenge)))
+	mux.Handle("GET /api/ghost-challenges/{code}", authMiddleware(http.HandlerFunc(ghostHandler.GetChallenge)))
+	mux.Handle("POST /api/ghost-challenges/{code}/play", authMiddleware(roomLimiter.Middleware(http.HandlerFunc(ghostHandler.PlayChallenge))))
+	mux.Handle("GET /api/ghost-results", authMiddleware(http.HandlerFunc(ghostHandler.ListResults)))
+
+	// Spaced Repetition Endpoints
+	mux.Handle("GET /api/reviews/due", authMiddleware(http.HandlerFunc(reviewHandler.Due)))
+	mux.Handle("POST /api/reviews", authMiddleware(http.HandlerFunc(reviewHandler.Submit)))
+
+	// Practice Statistics Endpoints
+	mux.Handle("POST /api/practice-sessions", authMiddleware(http.HandlerFunc(practiceHandler.RecordSession)))
+	mux.Handle("GET /api/stats/characters", authMiddleware(http.HandlerFunc(practiceHandler.CharacterStats)))
+	mux.Handle("GET /api/stats/confusions", authMiddleware(http.HandlerFunc(practiceHandler.Confusions)))
+	mux.Handle("GET /api/stats/activity", authMiddleware(http.HandlerFunc(practiceHandler.Activity)))
+
+	// Custom Deck Endpoints
+	mux.Handle("POST /api/decks", authMiddleware(http.HandlerFunc(deckHandler.Create)))
+	mux.Handle("GET /api/decks", authMiddleware(http.HandlerFunc(deckHandler.ListMine)))
+	mux.Handle("GET /api/decks/{code}", authMiddleware(http.HandlerFunc(deckHandler.Get)))
+	mux.Handle("PUT /api/decks/{code}", authMiddleware(http.HandlerFunc(deckHandler.Update)))
+	mux.Handle("DELETE /api/decks/{code}", authMiddleware(http.HandlerFunc(deckHandler.Delete)))
+
+	// Handwriting Endpoints
+	mux.Handle("POST /api/handwriting/recognize", authMiddleware(recognizeLimiter.Middleware(http.HandlerFunc(handwritingHandler.Recognize))))
+
+	// Kanji Endpoints
+	mux.HandleFunc("GET /api/kanji", kanjiHandler.List)
+	mux.HandleFunc("GET /api/kanji/levels", kanjiHandler.Levels)
+	mux.HandleFunc("GET /api/kanji/{literal}", kanjiHandler.Get)
+
+	// Reading Practice Endpoints
+	mux.Handle("GET /api/reading/next", authMiddleware(http.HandlerFunc(readingHandler.Next)))
+	mux.Handle("POST /api/reading/answers", authMiddleware(http.HandlerFunc(readingHandler.Check)))
+
+	// Multiple-Choice Quiz Endpoints
+	mux.Handle("GET /api/quiz", authMiddleware(http.HandlerFunc(quizHandler.Generate)))
+
+	// Curriculum Endpoints
+	mux.Handle("GET /api/curriculum", authMiddleware(http.HandlerFunc(curriculumHandler.Get)))
+
+	// Classroom Endpoints
+	mux.Handle("POST /api/classes", authMiddleware(http.HandlerFunc(classroomHandler.Create)))
+	mux.Handle("GET /api/classes", authMiddleware(http.HandlerFunc(classroomHandler.List)))
+	mux.Handle("POST /api/classes/join", authMiddleware(http.HandlerFunc(classroomHandler.Join)))
+	mux.Handle("GET /api/classes/{id}", authMiddleware(http.HandlerFunc(classroomHandler.Get)))
+	mux.Handle("DELETE /api/classes/{id}/members/{userID}", authMiddleware(http.HandlerFunc(classroomHandler.RemoveMember)))
+	mux.Handle("POST /api/classes/{id}/assignments", authMiddleware(http.HandlerFunc(classroomHandler.CreateAssignment)))
+	mux.Handle("GET /api/classes/{id}/report", authMiddleware(http.HandlerFunc(classroomHandler.Report)))
+	mux.Handle("POST /api/classes/{id}/battles", authMiddleware(roomLimiter.Middleware(http.HandlerFunc(classroomHandler.CreateBattle))))
+
+	// Solo Sprint Endpoints
+	mux.Handle("POST /api/sprints", authMiddleware(http.HandlerFunc(sprintHandler.Start)))
+	mux.Handle("POST /api/sprints/finish", authMiddleware(http.HandlerFunc(sprintHandler.Finish)))
+	mux.Handle("GET /api/sprints/stats", authMiddleware(http.HandlerFunc(sprintHandler.Stats)))
+
+	// League Endpoints
+	mux.Handle("GET /api/league", authMiddleware(http.HandlerFunc(leagueHandler.Get)))
+
+	// Shop Endpoints
+	mux.Handle("GET /api/shop", authMiddleware(http.HandlerFunc(shopHandler.Catalog)))
+	mux.Handle("POST /api/shop/purchases", authMiddleware(http.HandlerFunc(shopHandler.Purchase)))
+	mux.Handle("PUT /api/shop/equipped", authMiddleware(http.HandlerFunc(shopHandler.Equip)))
+	mux.Handle("DELETE /api/shop/equipped/{slot}", authMiddleware(http.HandlerFunc(shopHandler.Unequip)))
+	mux.Handle("GET /api/wallet", authMiddleware(http.HandlerFunc(shopHandler.Wallet)))
+
+	// Share Endpoints
+	mux.HandleFunc("GET /api/share/{id}/card.png", shareHandler.Card)
+
 	// DEV endpoints
 	if apiCFG.Platform == "dev" {
 		mux.HandleFunc("POST /admin/reset", systemHandler.Reset)
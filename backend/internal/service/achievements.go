@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/achievements"
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/google/uuid"
+)
+
+// UserNotifier delivers a websocket message to a user's open connections.
+type UserNotifier interface {
+	NotifyUser(userID uuid.UUID, payload []byte)
+}
+
+// AchievementRecorder is the part of AchievementService other services
+// report activity to.
+type AchievementRecorder interface {
+	RecordPractice(ctx context.Context, userID uuid.UUID, answers []achievements.Answer) error
+	// RecordStreak reports the user's longest daily streak so far
+	RecordStreak(ctx context.Context, userID uuid.UUID, longest int) error
+}
+
+type AchievementService interface {
+	AchievementRecorder
+	// Battles are recorded as the hub reports finished games
+	game.ResultStore
+
+	List(ctx context.Context, userID uuid.UUID) ([]dto.AchievementResponse, error)
+}
+
+type achievementService struct {
+	db       database.Querier
+	notifier UserNotifier
+	now      func() time.Time
+}
+
+func NewAchievementService(db database.Querier, notifier UserNotifier) AchievementService {
+	return &achievementService{
+		db:       db,
+		notifier: notifier,
+		now:      time.Now,
+	}
+}
+
+func (s *achievementService) RecordPractice(ctx context.Context, userID uuid.UUID, answers []achievements.Answer) error {
+	return s.increment(ctx, userID, achievements.PracticeIncrements(answers))
+}
+
+func (s *achievementService) SaveRun(ctx context.Context, run game.RecordedRun) error {
+	return s.increment(ctx, run.UserID, achievements.BattleIncrements(run.Placement, run.Players))
+}
+
+// SaveGhostResult is a no-op: ghost races are counted as solo battles by
+// SaveRun, so beating a ghost isn't a win.
+func (s *achievementService) SaveGhostResult(ctx context.Context, result game.GhostResult) error {
+	return nil
+}
+
+func (s *achievementService) RecordStreak(ctx context.Context, userID uuid.UUID, longest int) error {
+	best, err := s.db.RaiseUserCounter(ctx, database.RaiseUserCounterParams{
+		UserID: userID,
+		Name:   achievements.CounterBestStreak,
+		Value:  int64(longest),
+	})
+	if err != nil {
+		return err
+	}
+	return s.award(ctx, userID, map[string]int64{achievements.CounterBestStreak: best})
+}
+
+func (s *achievementService) increment(ctx context.Context, userID uuid.UUID, increments map[string]int64) error {
+	counters := make(map[string]int64, len(increments))
+	for name, inc := range increments {
+		value, err := s.db.IncrementUserCounter(ctx, database.IncrementUserCounterParams{
+			UserID: userID,
+			Name:   name,
+			Value:  inc,
+		})
+		if err != nil {
+			return err
+		}
+		counters[name] = value
+	}
+	return s.award(ctx, userID, counters)
+}
+
+// award stores the rules reached by the updated counters and announces the
+// ones the user didn't have yet.
+func (s *achievementService) award(ctx context.Context, userID uuid.UUID, counters map[string]int64) error {
+	now := s.now()
+	for _, rule := range achievements.Reached(counters) {
+		inserted, err := s.db.CreateUserAchievement(ctx, database.CreateUserAchievementParams{
+			UserID:        userID,
+			AchievementID: rule.ID,
+			UnlockedAt:    now,
+		})
+		if err != nil {
+			return err
+		}
+		if inserted == 0 {
+			continue
+		}
+
+		slog.Info("Achievement unlocked", "user", userID, "achievement", rule.ID)
+		s.notify(userID, ruleToResponse(rule, 0, &now))
+	}
+	return nil
+}
+
+func (s *achievementService) notify(userID uuid.UUID, achievement dto.AchievementResponse) {
+	if s.notifier == nil {
+		return
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"type":        "ACHIEVEMENT_UNLOCKED",
+		"achievement": achievement,
+	})
+	if err != nil {
+		slog.Error("Error marshalling achievement", "error", err)
+		return
+	}
+	s.notifier.NotifyUser(userID, payload)
+}
+
+func (s *achievementService) List(ctx context.Context, userID uuid.UUID) ([]dto.AchievementResponse, error) {
+	counterRows, err := s.db.ListUserCounters(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	counters := make(map[string]int64, len(counterRows))
+	for _, c := range counterRows {
+		counters[c.Name] = c.Value
+	}
+
+	unlockedRows, err := s.db.ListUserAchievements(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	unlocked := make(map[string]time.Time, len(unlockedRows))
+	for _, a := range unlockedRows {
+		unlocked[a.AchievementID] = a.UnlockedAt
+	}
+
+	response := make([]dto.AchievementResponse, len(achievements.Rules))
+	for i, rule := range achievements.Rules {
+		var unlockedAt *time.Time
+		if at, ok := unlocked[rule.ID]; ok {
+			unlockedAt = &at
+		}
+		response[i] = ruleToResponse(rule, counters[rule.Counter], unlockedAt)
+	}
+	return response, nil
+}
+
+func ruleToResponse(rule achievements.Rule, progress int64, unlockedAt *time.Time) dto.AchievementResponse {
+	if unlockedAt != nil {
+		progress = rule.Target
+	}
+	return dto.AchievementResponse{
+		ID:          rule.ID,
+		Name:        rule.Name,
+		Description: rule.Description,
+		Unlocked:    unlockedAt != nil,
+		UnlockedAt:  unlockedAt,
+		Progress:    min(progress, rule.Target),
+		Target:      rule.Target,
+	}
+}
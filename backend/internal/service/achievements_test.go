@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/achievements"
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/google/uuid"
+)
+
+type achievementMockQuerier struct {
+	database.Querier
+	counters map[string]int64
+	unlocked map[string]time.Time
+}
+
+func newAchievementMockQuerier() *achievementMockQuerier {
+	return &achievementMockQuerier{
+		counters: make(map[string]int64),
+		unlocked: make(map[string]time.Time),
+	}
+}
+
+func (m *achievementMockQuerier) IncrementUserCounter(ctx context.Context, arg database.IncrementUserCounterParams) (int64, error) {
+	m.counters[arg.Name] += arg.Value
+	return m.counters[arg.Name], nil
+}
+
+func (m *achievementMockQuerier) RaiseUserCounter(ctx context.Context, arg database.RaiseUserCounterParams) (int64, error) {
+	m.counters[arg.Name] = max(m.counters[arg.Name], arg.Value)
+	return m.counters[arg.Name], nil
+}
+
+func (m *achievementMockQuerier) ListUserCounters(ctx context.Context, userID uuid.UUID) ([]database.UserCounter, error) {
+	var rows []database.UserCounter
+	for name, value := range m.counters {
+		rows = append(rows, database.UserCounter{UserID: userID, Name: name, Value: value})
+	}
+	return rows, nil
+}
+
+func (m *achievementMockQuerier) CreateUserAchievement(ctx context.Context, arg database.CreateUserAchievementParams) (int64, error) {
+	if _, ok := m.unlocked[arg.AchievementID]; ok {
+		return 0, nil
+	}
+	m.unlocked[arg.AchievementID] = arg.UnlockedAt
+	return 1, nil
+}
+
+func (m *achievementMockQuerier) ListUserAchievements(ctx context.Context, userID uuid.UUID) ([]database.UserAchievement, error) {
+	var rows []database.UserAchievement
+	for id, at := range m.unlocked {
+		rows = append(rows, database.UserAchievement{UserID: userID, AchievementID: id, UnlockedAt: at})
+	}
+	return rows, nil
+}
+
+type mockNotifier struct {
+	messages []map[string]interface{}
+}
+
+func (m *mockNotifier) NotifyUser(userID uuid.UUID, payload []byte) {
+	var parsed map[string]interface{}
+	json.Unmarshal(payload, &parsed)
+	m.messages = append(m.messages, parsed)
+}
+
+func TestAchievementService_BattleWinAwardedOnce(t *testing.T) {
+	db := newAchievementMockQuerier()
+	notifier := &mockNotifier{}
+	svc := NewAchievementService(db, notifier)
+	userID := uuid.New()
+
+	win := game.RecordedRun{UserID: userID, Placement: 1, Players: 2}
+	for i := 0; i < 2; i++ {
+		if err := svc.SaveRun(context.Background(), win); err != nil {
+			t.Fatalf("SaveRun: %v", err)
+		}
+	}
+
+	// first_battle and first_battle_win, announced once each
+	if len(notifier.messages) != 2 {
+		t.Fatalf("Expected 2 notifications, got %d", len(notifier.messages))
+	}
+	if notifier.messages[0]["type"] != "ACHIEVEMENT_UNLOCKED" {
+		t.Errorf("Unexpected notification: %v", notifier.messages[0])
+	}
+
+	list, err := svc.List(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	for _, a := range list {
+		switch a.ID {
+		case "first_battle_win":
+			if !a.Unlocked || a.UnlockedAt == nil || a.Progress != 1 {
+				t.Errorf("Expected first_battle_win unlocked, got %+v", a)
+			}
+		case "battle_wins_10":
+			if a.Unlocked || a.Progress != 2 || a.Target != 10 {
+				t.Errorf("Expected battle_wins_10 locked at 2/10, got %+v", a)
+			}
+		}
+	}
+}
+
+func TestAchievementService_RecordStreak(t *testing.T) {
+	db := newAchievementMockQuerier()
+	svc := NewAchievementService(db, nil)
+	userID := uuid.New()
+
+	for _, longest := range []int{6, 7} {
+		if err := svc.RecordStreak(context.Background(), userID, longest); err != nil {
+			t.Fatalf("RecordStreak: %v", err)
+		}
+	}
+	if _, ok := db.unlocked["streak_7"]; !ok {
+		t.Errorf("Expected streak_7 after a 7-day streak, counters %v", db.counters)
+	}
+
+	// The best streak never goes down
+	if err := svc.RecordStreak(context.Background(), userID, 3); err != nil {
+		t.Fatalf("RecordStreak: %v", err)
+	}
+	if db.counters[achievements.CounterBestStreak] != 7 {
+		t.Errorf("Expected best streak 7, got %d", db.counters[achievements.CounterBestStreak])
+	}
+}
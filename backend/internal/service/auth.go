@@ -9,6 +9,7 @@ import (
 	"github.com/Cadimodev/haiji/backend/internal/auth"
 	"github.com/Cadimodev/haiji/backend/internal/database"
 	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/progression"
 	"github.com/Cadimodev/haiji/backend/internal/sessions"
 	"github.com/google/uuid"
 )
@@ -28,26 +29,44 @@ type authService struct {
 	jwtSecret     string
 	refreshPepper string
 	platform      string
+	curve         progression.Curve
 }
 
-func NewAuthService(txManager database.TxManager, db database.Querier, jwtSecret, refreshPepper, platform string) AuthService {
+func NewAuthService(txManager database.TxManager, db database.Querier, jwtSecret, refreshPepper, platform string, curve progression.Curve) AuthService {
 	return &authService{
 		txManager:     txManager,
 		db:            db,
 		jwtSecret:     jwtSecret,
 		refreshPepper: refreshPepper,
 		platform:      platform,
+		curve:         curve,
 	}
 }
 
-// Helper to convert DB user to DTO
-func userToResponse(u database.User) dto.UserResponse {
+// Helper to convert DB user to DTO. The streak is shown as of now in the
+// user's time zone.
+func userToResponse(u database.User, curve progression.Curve, now time.Time) dto.UserResponse {
+	level := curve.Level(u.Xp)
+	streak := userStreak(u)
 	return dto.UserResponse{
 		ID:        u.ID,
 		Email:     u.Email,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 		Username:  u.Username,
+		TimeZone:  u.TimeZone,
+		Role:      u.Role,
+
+		RomajiSystems: u.RomajiSystems,
+
+		XP:          u.Xp,
+		Level:       level.Level,
+		LevelXP:     level.XP,
+		NextLevelXP: level.Next,
+
+		Streak:        streak.Active(progression.Day(now, progression.Location(u.TimeZone))),
+		LongestStreak: streak.Longest,
+		StreakFreezes: streak.Freezes,
 	}
 }
 
@@ -103,7 +122,7 @@ func (s *authService) Register(ctx context.Context, params dto.CreateUserRequest
 		}
 
 		response = dto.UserWithTokenResponse{
-			UserResponse: userToResponse(user),
+			UserResponse: userToResponse(user, s.curve, time.Now()),
 			Token:        accessToken,
 		}
 		refreshToken = rt
@@ -161,7 +180,7 @@ func (s *authService) Login(ctx context.Context, params dto.LoginRequest, userAg
 	}
 
 	return dto.UserWithTokenResponse{
-		UserResponse: userToResponse(user),
+		UserResponse: userToResponse(user, s.curve, time.Now()),
 		Token:        accessToken,
 	}, refreshToken, nil
 }
@@ -221,7 +240,7 @@ func (s *authService) UpdateUser(ctx context.Context, userID uuid.UUID, params d
 	}
 
 	return dto.UserWithTokenResponse{
-		UserResponse: userToResponse(user),
+		UserResponse: userToResponse(user, s.curve, time.Now()),
 		Token:        newAccess,
 	}, newRefresh, nil
 }
@@ -251,7 +270,7 @@ func (s *authService) RefreshToken(ctx context.Context, refreshTokenHex string)
 	// Returning an empty string signals the handler to keep the existing cookie.
 
 	return dto.UserWithTokenResponse{
-		UserResponse: userToResponse(user),
+		UserResponse: userToResponse(user, s.curve, time.Now()),
 		Token:        accessToken,
 	}, "", nil
 }
@@ -274,5 +293,5 @@ func (s *authService) GetUser(ctx context.Context, userID uuid.UUID) (dto.UserRe
 	if err != nil {
 		return dto.UserResponse{}, err
 	}
-	return userToResponse(user), nil
+	return userToResponse(user, s.curve, time.Now()), nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/Cadimodev/haiji/backend/internal/auth"
 	"github.com/Cadimodev/haiji/backend/internal/database"
 	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/progression"
 	"github.com/google/uuid"
 )
 
@@ -76,7 +77,7 @@ func TestAuthService_Login(t *testing.T) {
 	}
 	mockTx := &MockTxManager{db: mockDB}
 
-	authService := NewAuthService(mockTx, mockDB, "secret", "pepper", "dev")
+	authService := NewAuthService(mockTx, mockDB, "secret", "pepper", "dev", progression.DefaultCurve)
 
 	// Case 1: User Not Found
 	_, _, err := authService.Login(context.Background(), dto.LoginRequest{
@@ -163,7 +164,7 @@ func TestAuthService_Register(t *testing.T) {
 	}
 	mockDB.users["existing_user"] = existingUser
 
-	authService := NewAuthService(mockTx, mockDB, "secret", "pepper", "dev")
+	authService := NewAuthService(mockTx, mockDB, "secret", "pepper", "dev", progression.DefaultCurve)
 
 	// Case 1: Success
 	resp, refreshToken, err := authService.Register(context.Background(), dto.CreateUserRequest{
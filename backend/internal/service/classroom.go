@@ -0,0 +1,515 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/classroom"
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/google/uuid"
+)
+
+const classCodeLength = 6
+
+var (
+	ErrNotTeacher        = errors.New("only teachers can create classes")
+	ErrNotClassTeacher   = errors.New("only the class's teacher can do this")
+	ErrClassNotFound     = errors.New("class not found")
+	ErrClassMemberAbsent = errors.New("not a member of this class")
+	ErrOwnClass          = errors.New("you teach this class")
+	ErrDueInPast         = errors.New("due_at must be in the future")
+	ErrEmptyAssignment   = errors.New("the assignment's groups have no characters")
+	ErrUserNotFound      = errors.New("user not found")
+)
+
+// ClassRoomCreator starts battle rooms for a class.
+type ClassRoomCreator interface {
+	CreateRoom(opts game.RoomOptions, hostID uuid.UUID) (string, error)
+}
+
+type ClassroomService interface {
+	// SetRole makes a user a teacher or a student again
+	SetRole(ctx context.Context, username string, role classroom.Role) error
+
+	CreateClass(ctx context.Context, userID uuid.UUID, params dto.CreateClassRequest) (dto.ClassResponse, error)
+	ListClasses(ctx context.Context, userID uuid.UUID) ([]dto.ClassResponse, error)
+	GetClass(ctx context.Context, userID, classID uuid.UUID) (dto.ClassDetailResponse, error)
+	JoinClass(ctx context.Context, userID uuid.UUID, code string) (dto.ClassResponse, error)
+	// RemoveMember lets the teacher remove a student, or a student leave
+	RemoveMember(ctx context.Context, userID, classID, memberID uuid.UUID) error
+
+	CreateAssignment(ctx context.Context, userID, classID uuid.UUID, params dto.CreateAssignmentRequest) (dto.AssignmentResponse, error)
+	Report(ctx context.Context, userID, classID uuid.UUID) (dto.ClassReportResponse, error)
+	WriteReportCSV(ctx context.Context, userID, classID uuid.UUID, w io.Writer) error
+
+	// CreateBattle opens a battle room only the class can join
+	CreateBattle(ctx context.Context, userID, classID uuid.UUID, opts game.RoomOptions) (dto.ClassBattleResponse, error)
+}
+
+type classroomService struct {
+	db    database.Querier
+	rooms ClassRoomCreator
+	now   func() time.Time
+}
+
+func NewClassroomService(db database.Querier, rooms ClassRoomCreator) ClassroomService {
+	return &classroomService{
+		db:    db,
+		rooms: rooms,
+		now:   time.Now,
+	}
+}
+
+func (s *classroomService) SetRole(ctx context.Context, username string, role classroom.Role) error {
+	n, err := s.db.SetUserRole(ctx, database.SetUserRoleParams{
+		Username: username,
+		Role:     string(role),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: %s", ErrUserNotFound, username)
+	}
+	return nil
+}
+
+func (s *classroomService) CreateClass(ctx context.Context, userID uuid.UUID, params dto.CreateClassRequest) (dto.ClassResponse, error) {
+	user, err := s.db.GetUserByID(ctx, userID)
+	if err != nil {
+		return dto.ClassResponse{}, err
+	}
+	if classroom.Role(user.Role) != classroom.RoleTeacher {
+		return dto.ClassResponse{}, ErrNotTeacher
+	}
+
+	for i := 0; i < 5; i++ {
+		class, err := s.db.CreateClass(ctx, database.CreateClassParams{
+			TeacherID: userID,
+			Name:      strings.TrimSpace(params.Name),
+			JoinCode:  shareCode(classCodeLength),
+		})
+		if database.IsUnique(err) {
+			continue
+		}
+		if err != nil {
+			return dto.ClassResponse{}, err
+		}
+		return classResponse(class, user.Username, 0, true), nil
+	}
+	return dto.ClassResponse{}, errors.New("couldn't find a free join code")
+}
+
+func (s *classroomService) ListClasses(ctx context.Context, userID uuid.UUID) ([]dto.ClassResponse, error) {
+	rows, err := s.db.ListClassesForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	classes := make([]dto.ClassResponse, len(rows))
+	for i, row := range rows {
+		classes[i] = classResponse(database.Class{
+			ID:        row.ID,
+			TeacherID: row.TeacherID,
+			Name:      row.Name,
+			JoinCode:  row.JoinCode,
+			CreatedAt: row.CreatedAt,
+		}, row.TeacherUsername, int(row.Members), row.TeacherID == userID)
+	}
+	return classes, nil
+}
+
+// GetClass shows the teacher the members and join code, and students their
+// own progress on each assignment.
+func (s *classroomService) GetClass(ctx context.Context, userID, classID uuid.UUID) (dto.ClassDetailResponse, error) {
+	class, err := s.classForMember(ctx, userID, classID)
+	if err != nil {
+		return dto.ClassDetailResponse{}, err
+	}
+	teacher, err := s.db.GetUserByID(ctx, class.TeacherID)
+	if err != nil {
+		return dto.ClassDetailResponse{}, err
+	}
+	members, err := s.db.ListClassMembers(ctx, classID)
+	if err != nil {
+		return dto.ClassDetailResponse{}, err
+	}
+	assignments, err := s.db.ListAssignments(ctx, classID)
+	if err != nil {
+		return dto.ClassDetailResponse{}, err
+	}
+
+	isTeacher := class.TeacherID == userID
+	res := dto.ClassDetailResponse{
+		ClassResponse: classResponse(class, teacher.Username, len(members), isTeacher),
+		Assignments:   make([]dto.AssignmentResponse, len(assignments)),
+	}
+	for i, a := range assignments {
+		res.Assignments[i] = assignmentResponse(a)
+	}
+
+	if isTeacher {
+		res.Members = make([]dto.ClassMemberResponse, len(members))
+		for i, m := range members {
+			res.Members[i] = dto.ClassMemberResponse{UserID: m.UserID, Username: m.Username, JoinedAt: m.JoinedAt}
+		}
+		return res, nil
+	}
+
+	progress, err := s.db.GetAssignmentProgress(ctx, classID)
+	if err != nil {
+		return dto.ClassDetailResponse{}, err
+	}
+	now := s.now()
+	for _, row := range progress {
+		if row.UserID != userID {
+			continue
+		}
+		for i, a := range assignments {
+			if a.ID == row.AssignmentID {
+				p := assignmentProgress(a, row, now)
+				res.Assignments[i].Progress = &p
+			}
+		}
+	}
+	return res, nil
+}
+
+func (s *classroomService) JoinClass(ctx context.Context, userID uuid.UUID, code string) (dto.ClassResponse, error) {
+	class, err := s.db.GetClassByJoinCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.ClassResponse{}, ErrClassNotFound
+	}
+	if err != nil {
+		return dto.ClassResponse{}, err
+	}
+	if class.TeacherID == userID {
+		return dto.ClassResponse{}, ErrOwnClass
+	}
+
+	err = s.db.AddClassMember(ctx, database.AddClassMemberParams{ClassID: class.ID, UserID: userID})
+	if err != nil {
+		return dto.ClassResponse{}, err
+	}
+
+	detail, err := s.GetClass(ctx, userID, class.ID)
+	if err != nil {
+		return dto.ClassResponse{}, err
+	}
+	return detail.ClassResponse, nil
+}
+
+func (s *classroomService) RemoveMember(ctx context.Context, userID, classID, memberID uuid.UUID) error {
+	class, err := s.getClass(ctx, classID)
+	if err != nil {
+		return err
+	}
+	if userID != memberID && class.TeacherID != userID {
+		return ErrNotClassTeacher
+	}
+
+	n, err := s.db.RemoveClassMember(ctx, database.RemoveClassMemberParams{ClassID: classID, UserID: memberID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrClassMemberAbsent
+	}
+	return nil
+}
+
+func (s *classroomService) CreateAssignment(ctx context.Context, userID, classID uuid.UUID, params dto.CreateAssignmentRequest) (dto.AssignmentResponse, error) {
+	if _, err := s.classForTeacher(ctx, userID, classID); err != nil {
+		return dto.AssignmentResponse{}, err
+	}
+	if !params.DueAt.After(s.now()) {
+		return dto.AssignmentResponse{}, ErrDueInPast
+	}
+
+	// The characters are fixed when assigned, so later deck edits don't
+	// change what was asked for
+	chars, err := groupChars(ctx, s.db, params.Groups)
+	if err != nil {
+		return dto.AssignmentResponse{}, err
+	}
+	var kanaList []string
+	seen := make(map[string]bool, len(chars))
+	for _, c := range chars {
+		if !seen[c.Kana] {
+			seen[c.Kana] = true
+			kanaList = append(kanaList, c.Kana)
+		}
+	}
+	if len(kanaList) == 0 {
+		return dto.AssignmentResponse{}, ErrEmptyAssignment
+	}
+
+	a, err := s.db.CreateAssignment(ctx, database.CreateAssignmentParams{
+		ClassID:        classID,
+		Title:          strings.TrimSpace(params.Title),
+		Groups:         params.Groups,
+		Kana:           kanaList,
+		TargetAccuracy: params.TargetAccuracy,
+		DueAt:          params.DueAt,
+	})
+	if err != nil {
+		return dto.AssignmentResponse{}, err
+	}
+	return assignmentResponse(a), nil
+}
+
+func (s *classroomService) Report(ctx context.Context, userID, classID uuid.UUID) (dto.ClassReportResponse, error) {
+	r, err := s.report(ctx, userID, classID)
+	if err != nil {
+		return dto.ClassReportResponse{}, err
+	}
+
+	res := dto.ClassReportResponse{
+		ClassID:     r.class.ID,
+		ClassName:   r.class.Name,
+		GeneratedAt: r.generatedAt,
+		Assignments: make([]dto.AssignmentResponse, len(r.assignments)),
+		Students:    make([]dto.StudentReportResponse, len(r.students)),
+	}
+	for i, a := range r.assignments {
+		res.Assignments[i] = assignmentResponse(a)
+	}
+	for i, st := range r.students {
+		res.Students[i] = dto.StudentReportResponse{
+			UserID:      st.member.UserID,
+			Username:    st.member.Username,
+			JoinedAt:    st.member.JoinedAt,
+			Assignments: st.progress,
+			Battles: dto.BattleStatsResponse{
+				Battles:      int(st.battles.Battles),
+				ClassBattles: int(st.battles.ClassBattles),
+				BestScore:    int(st.battles.BestScore),
+				AverageScore: st.battles.AverageScore,
+			},
+		}
+	}
+	return res, nil
+}
+
+func (s *classroomService) WriteReportCSV(ctx context.Context, userID, classID uuid.UUID, w io.Writer) error {
+	r, err := s.report(ctx, userID, classID)
+	if err != nil {
+		return err
+	}
+
+	out := classroom.Report{
+		ClassName:   r.class.Name,
+		GeneratedAt: r.generatedAt,
+		Assignments: make([]classroom.Assignment, len(r.assignments)),
+		Students:    make([]classroom.StudentReport, len(r.students)),
+	}
+	for i, a := range r.assignments {
+		out.Assignments[i] = classroom.Assignment{
+			ID:             a.ID,
+			Title:          a.Title,
+			TargetAccuracy: a.TargetAccuracy,
+			DueAt:          a.DueAt,
+		}
+	}
+	for i, st := range r.students {
+		student := classroom.StudentReport{
+			UserID:      st.member.UserID,
+			Username:    st.member.Username,
+			JoinedAt:    st.member.JoinedAt,
+			Assignments: make([]classroom.AssignmentReport, len(st.progress)),
+			Battles: classroom.BattleStats{
+				Battles:      int(st.battles.Battles),
+				ClassBattles: int(st.battles.ClassBattles),
+				BestScore:    int(st.battles.BestScore),
+				AverageScore: st.battles.AverageScore,
+			},
+		}
+		for j, p := range st.progress {
+			student.Assignments[j] = classroom.AssignmentReport{
+				Progress: classroom.Progress{Attempts: p.Attempts, Correct: p.Correct, KanaPracticed: p.KanaPracticed},
+				Status:   classroom.Status(p.Status),
+			}
+		}
+		out.Students[i] = student
+	}
+	return classroom.WriteCSV(w, out)
+}
+
+type classReport struct {
+	class       database.Class
+	generatedAt time.Time
+	assignments []database.ClassAssignment
+	students    []studentReport
+}
+
+type studentReport struct {
+	member   database.ListClassMembersRow
+	progress []dto.AssignmentProgressResponse // in the order of the assignments
+	battles  database.GetClassBattleStatsRow
+}
+
+// report gathers every member's assignment progress and battle stats for
+// the class's teacher.
+func (s *classroomService) report(ctx context.Context, userID, classID uuid.UUID) (classReport, error) {
+	class, err := s.classForTeacher(ctx, userID, classID)
+	if err != nil {
+		return classReport{}, err
+	}
+	members, err := s.db.ListClassMembers(ctx, classID)
+	if err != nil {
+		return classReport{}, err
+	}
+	assignments, err := s.db.ListAssignments(ctx, classID)
+	if err != nil {
+		return classReport{}, err
+	}
+	progressRows, err := s.db.GetAssignmentProgress(ctx, classID)
+	if err != nil {
+		return classReport{}, err
+	}
+	battleRows, err := s.db.GetClassBattleStats(ctx, classID)
+	if err != nil {
+		return classReport{}, err
+	}
+
+	type key struct{ assignment, user uuid.UUID }
+	progress := make(map[key]database.GetAssignmentProgressRow, len(progressRows))
+	for _, row := range progressRows {
+		progress[key{row.AssignmentID, row.UserID}] = row
+	}
+	battles := make(map[uuid.UUID]database.GetClassBattleStatsRow, len(battleRows))
+	for _, row := range battleRows {
+		battles[row.UserID] = row
+	}
+
+	now := s.now()
+	r := classReport{
+		class:       class,
+		generatedAt: now,
+		assignments: assignments,
+		students:    make([]studentReport, len(members)),
+	}
+	for i, m := range members {
+		st := studentReport{
+			member:   m,
+			progress: make([]dto.AssignmentProgressResponse, len(assignments)),
+			battles:  battles[m.UserID],
+		}
+		for j, a := range assignments {
+			st.progress[j] = assignmentProgress(a, progress[key{a.ID, m.UserID}], now)
+		}
+		r.students[i] = st
+	}
+	return r, nil
+}
+
+func (s *classroomService) CreateBattle(ctx context.Context, userID, classID uuid.UUID, opts game.RoomOptions) (dto.ClassBattleResponse, error) {
+	if _, err := s.classForTeacher(ctx, userID, classID); err != nil {
+		return dto.ClassBattleResponse{}, err
+	}
+	members, err := s.db.ListClassMembers(ctx, classID)
+	if err != nil {
+		return dto.ClassBattleResponse{}, err
+	}
+
+	opts.Members = make([]uuid.UUID, len(members))
+	for i, m := range members {
+		opts.Members[i] = m.UserID
+	}
+	code, err := s.rooms.CreateRoom(opts, userID)
+	if err != nil {
+		return dto.ClassBattleResponse{}, err
+	}
+
+	err = s.db.CreateClassBattle(ctx, database.CreateClassBattleParams{ClassID: classID, RoomCode: code})
+	if err != nil {
+		return dto.ClassBattleResponse{}, err
+	}
+	return dto.ClassBattleResponse{RoomCode: code}, nil
+}
+
+func (s *classroomService) getClass(ctx context.Context, classID uuid.UUID) (database.Class, error) {
+	class, err := s.db.GetClass(ctx, classID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.Class{}, ErrClassNotFound
+	}
+	return class, err
+}
+
+func (s *classroomService) classForTeacher(ctx context.Context, userID, classID uuid.UUID) (database.Class, error) {
+	class, err := s.getClass(ctx, classID)
+	if err != nil {
+		return database.Class{}, err
+	}
+	if class.TeacherID != userID {
+		return database.Class{}, ErrNotClassTeacher
+	}
+	return class, nil
+}
+
+// classForMember returns the class if the user teaches or is in it. Others
+// are told it doesn't exist.
+func (s *classroomService) classForMember(ctx context.Context, userID, classID uuid.UUID) (database.Class, error) {
+	class, err := s.getClass(ctx, classID)
+	if err != nil || class.TeacherID == userID {
+		return class, err
+	}
+	member, err := s.db.IsClassMember(ctx, database.IsClassMemberParams{ClassID: classID, UserID: userID})
+	if err != nil {
+		return database.Class{}, err
+	}
+	if !member {
+		return database.Class{}, ErrClassNotFound
+	}
+	return class, nil
+}
+
+func classResponse(c database.Class, teacherUsername string, members int, isTeacher bool) dto.ClassResponse {
+	res := dto.ClassResponse{
+		ID:              c.ID,
+		Name:            c.Name,
+		TeacherID:       c.TeacherID,
+		TeacherUsername: teacherUsername,
+		MemberCount:     members,
+		CreatedAt:       c.CreatedAt,
+	}
+	if isTeacher {
+		res.JoinCode = c.JoinCode
+	}
+	return res
+}
+
+func assignmentResponse(a database.ClassAssignment) dto.AssignmentResponse {
+	return dto.AssignmentResponse{
+		ID:             a.ID,
+		Title:          a.Title,
+		Groups:         a.Groups,
+		Kana:           a.Kana,
+		TargetAccuracy: a.TargetAccuracy,
+		DueAt:          a.DueAt,
+		CreatedAt:      a.CreatedAt,
+	}
+}
+
+func assignmentProgress(a database.ClassAssignment, row database.GetAssignmentProgressRow, now time.Time) dto.AssignmentProgressResponse {
+	p := classroom.Progress{
+		Attempts:      int(row.Attempts),
+		Correct:       int(row.Correct),
+		KanaPracticed: int(row.KanaPracticed),
+	}
+	return dto.AssignmentProgressResponse{
+		AssignmentID:  a.ID,
+		Attempts:      p.Attempts,
+		Correct:       p.Correct,
+		Accuracy:      p.Accuracy(),
+		KanaPracticed: p.KanaPracticed,
+		Status:        string(classroom.AssignmentStatus(p, len(a.Kana), a.TargetAccuracy, a.DueAt, now)),
+	}
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/classroom"
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/google/uuid"
+)
+
+type classroomMockQuerier struct {
+	database.Querier
+	users       map[uuid.UUID]database.User
+	classes     map[uuid.UUID]database.Class
+	members     map[uuid.UUID][]database.ListClassMembersRow
+	assignments []database.ClassAssignment
+	progress    []database.GetAssignmentProgressRow
+	battles     []database.CreateClassBattleParams
+}
+
+func newClassroomMockQuerier() *classroomMockQuerier {
+	return &classroomMockQuerier{
+		users:   make(map[uuid.UUID]database.User),
+		classes: make(map[uuid.UUID]database.Class),
+		members: make(map[uuid.UUID][]database.ListClassMembersRow),
+	}
+}
+
+func (m *classroomMockQuerier) addUser(username string, role classroom.Role) uuid.UUID {
+	id := uuid.New()
+	m.users[id] = database.User{ID: id, Username: username, Role: string(role)}
+	return id
+}
+
+func (m *classroomMockQuerier) GetUserByID(ctx context.Context, id uuid.UUID) (database.User, error) {
+	u, ok := m.users[id]
+	if !ok {
+		return database.User{}, sql.ErrNoRows
+	}
+	return u, nil
+}
+
+func (m *classroomMockQuerier) CreateClass(ctx context.Context, arg database.CreateClassParams) (database.Class, error) {
+	c := database.Class{ID: uuid.New(), TeacherID: arg.TeacherID, Name: arg.Name, JoinCode: arg.JoinCode, CreatedAt: time.Now()}
+	m.classes[c.ID] = c
+	return c, nil
+}
+
+func (m *classroomMockQuerier) GetClass(ctx context.Context, id uuid.UUID) (database.Class, error) {
+	c, ok := m.classes[id]
+	if !ok {
+		return database.Class{}, sql.ErrNoRows
+	}
+	return c, nil
+}
+
+func (m *classroomMockQuerier) GetClassByJoinCode(ctx context.Context, code string) (database.Class, error) {
+	for _, c := range m.classes {
+		if c.JoinCode == code {
+			return c, nil
+		}
+	}
+	return database.Class{}, sql.ErrNoRows
+}
+
+func (m *classroomMockQuerier) AddClassMember(ctx context.Context, arg database.AddClassMemberParams) error {
+	m.members[arg.ClassID] = append(m.members[arg.ClassID], database.ListClassMembersRow{
+		UserID:   arg.UserID,
+		Username: m.users[arg.UserID].Username,
+		JoinedAt: time.Now(),
+	})
+	return nil
+}
+
+func (m *classroomMockQuerier) IsClassMember(ctx context.Context, arg database.IsClassMemberParams) (bool, error) {
+	for _, row := range m.members[arg.ClassID] {
+		if row.UserID == arg.UserID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *classroomMockQuerier) ListClassMembers(ctx context.Context, classID uuid.UUID) ([]database.ListClassMembersRow, error) {
+	return m.members[classID], nil
+}
+
+func (m *classroomMockQuerier) CreateAssignment(ctx context.Context, arg database.CreateAssignmentParams) (database.ClassAssignment, error) {
+	a := database.ClassAssignment{
+		ID:             uuid.New(),
+		ClassID:        arg.ClassID,
+		Title:          arg.Title,
+		Groups:         arg.Groups,
+		Kana:           arg.Kana,
+		TargetAccuracy: arg.TargetAccuracy,
+		DueAt:          arg.DueAt,
+		CreatedAt:      time.Now(),
+	}
+	m.assignments = append(m.assignments, a)
+	return a, nil
+}
+
+func (m *classroomMockQuerier) ListAssignments(ctx context.Context, classID uuid.UUID) ([]database.ClassAssignment, error) {
+	return m.assignments, nil
+}
+
+func (m *classroomMockQuerier) GetAssignmentProgress(ctx context.Context, classID uuid.UUID) ([]database.GetAssignmentProgressRow, error) {
+	return m.progress, nil
+}
+
+func (m *classroomMockQuerier) GetClassBattleStats(ctx context.Context, classID uuid.UUID) ([]database.GetClassBattleStatsRow, error) {
+	return nil, nil
+}
+
+func (m *classroomMockQuerier) CreateClassBattle(ctx context.Context, arg database.CreateClassBattleParams) error {
+	m.battles = append(m.battles, arg)
+	return nil
+}
+
+type mockClassRooms struct {
+	opts game.RoomOptions
+}
+
+func (m *mockClassRooms) CreateRoom(opts game.RoomOptions, hostID uuid.UUID) (string, error) {
+	m.opts = opts
+	return "ROOM01", nil
+}
+
+func TestClassroomService_CreateAndJoin(t *testing.T) {
+	db := newClassroomMockQuerier()
+	svc := NewClassroomService(db, nil)
+	ctx := context.Background()
+	teacherID := db.addUser("sensei", classroom.RoleTeacher)
+	studentID := db.addUser("hana", classroom.RoleStudent)
+
+	if _, err := svc.CreateClass(ctx, studentID, dto.CreateClassRequest{Name: "1-A"}); !errors.Is(err, ErrNotTeacher) {
+		t.Errorf("Expected ErrNotTeacher for a student, got %v", err)
+	}
+	class, err := svc.CreateClass(ctx, teacherID, dto.CreateClassRequest{Name: " 1-A "})
+	if err != nil {
+		t.Fatalf("CreateClass failed: %v", err)
+	}
+	if class.Name != "1-A" || len(class.JoinCode) != classCodeLength {
+		t.Errorf("Unexpected class %+v", class)
+	}
+
+	// Strangers can't see the class
+	if _, err := svc.GetClass(ctx, studentID, class.ID); !errors.Is(err, ErrClassNotFound) {
+		t.Errorf("Expected ErrClassNotFound before joining, got %v", err)
+	}
+	if _, err := svc.JoinClass(ctx, teacherID, class.JoinCode); !errors.Is(err, ErrOwnClass) {
+		t.Errorf("Expected ErrOwnClass for the teacher, got %v", err)
+	}
+
+	joined, err := svc.JoinClass(ctx, studentID, strings.ToLower(class.JoinCode))
+	if err != nil {
+		t.Fatalf("JoinClass failed: %v", err)
+	}
+	if joined.JoinCode != "" || joined.MemberCount != 1 || joined.TeacherUsername != "sensei" {
+		t.Errorf("Unexpected class for a student %+v", joined)
+	}
+}
+
+func TestClassroomService_AssignmentProgress(t *testing.T) {
+	db := newClassroomMockQuerier()
+	svc := NewClassroomService(db, nil).(*classroomService)
+	ctx := context.Background()
+	teacherID := db.addUser("sensei", classroom.RoleTeacher)
+	studentID := db.addUser("hana", classroom.RoleStudent)
+	class, _ := svc.CreateClass(ctx, teacherID, dto.CreateClassRequest{Name: "1-A"})
+	svc.JoinClass(ctx, studentID, class.JoinCode)
+
+	params := dto.CreateAssignmentRequest{
+		Title:          "Vowels",
+		Groups:         []string{"hsingle"},
+		TargetAccuracy: 0.8,
+		DueAt:          time.Now().Add(-time.Hour),
+	}
+	if _, err := svc.CreateAssignment(ctx, teacherID, class.ID, params); !errors.Is(err, ErrDueInPast) {
+		t.Errorf("Expected ErrDueInPast, got %v", err)
+	}
+	params.DueAt = time.Now().Add(48 * time.Hour)
+	if _, err := svc.CreateAssignment(ctx, studentID, class.ID, params); !errors.Is(err, ErrNotClassTeacher) {
+		t.Errorf("Expected ErrNotClassTeacher for a student, got %v", err)
+	}
+	assignment, err := svc.CreateAssignment(ctx, teacherID, class.ID, params)
+	if err != nil {
+		t.Fatalf("CreateAssignment failed: %v", err)
+	}
+	if len(assignment.Kana) != 5 {
+		t.Errorf("Expected the 5 vowels, got %v", assignment.Kana)
+	}
+
+	db.progress = []database.GetAssignmentProgressRow{
+		{AssignmentID: assignment.ID, UserID: studentID, Attempts: 10, Correct: 9, KanaPracticed: 5},
+	}
+
+	// The student sees their own progress
+	detail, err := svc.GetClass(ctx, studentID, class.ID)
+	if err != nil {
+		t.Fatalf("GetClass failed: %v", err)
+	}
+	if p := detail.Assignments[0].Progress; p == nil || p.Status != string(classroom.StatusCompleted) {
+		t.Errorf("Expected a completed assignment, got %+v", p)
+	}
+	if detail.Members != nil {
+		t.Error("Expected members hidden from students")
+	}
+
+	// Only the teacher gets the report
+	if _, err := svc.Report(ctx, studentID, class.ID); !errors.Is(err, ErrNotClassTeacher) {
+		t.Errorf("Expected ErrNotClassTeacher, got %v", err)
+	}
+	report, err := svc.Report(ctx, teacherID, class.ID)
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if len(report.Students) != 1 || report.Students[0].Assignments[0].Accuracy != 0.9 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+
+	var buf bytes.Buffer
+	if err := svc.WriteReportCSV(ctx, teacherID, class.ID, &buf); err != nil {
+		t.Fatalf("WriteReportCSV failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "hana,") || !strings.Contains(buf.String(), ",completed,") {
+		t.Errorf("Unexpected CSV:\n%s", buf.String())
+	}
+}
+
+func TestClassroomService_CreateBattle(t *testing.T) {
+	db := newClassroomMockQuerier()
+	rooms := &mockClassRooms{}
+	svc := NewClassroomService(db, rooms)
+	ctx := context.Background()
+	teacherID := db.addUser("sensei", classroom.RoleTeacher)
+	studentID := db.addUser("hana", classroom.RoleStudent)
+	class, _ := svc.CreateClass(ctx, teacherID, dto.CreateClassRequest{Name: "1-A"})
+	svc.JoinClass(ctx, studentID, class.JoinCode)
+
+	opts := game.RoomOptions{Duration: 60, Groups: []string{"hsingle"}}
+	if _, err := svc.CreateBattle(ctx, studentID, class.ID, opts); !errors.Is(err, ErrNotClassTeacher) {
+		t.Errorf("Expected ErrNotClassTeacher, got %v", err)
+	}
+	res, err := svc.CreateBattle(ctx, teacherID, class.ID, opts)
+	if err != nil {
+		t.Fatalf("CreateBattle failed: %v", err)
+	}
+	if res.RoomCode != "ROOM01" || len(db.battles) != 1 || db.battles[0].RoomCode != "ROOM01" {
+		t.Errorf("Expected the room recorded as a class battle, got %+v", db.battles)
+	}
+	if len(rooms.opts.Members) != 1 || rooms.opts.Members[0] != studentID {
+		t.Errorf("Expected the room limited to the class, got %v", rooms.opts.Members)
+	}
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/curriculum"
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/google/uuid"
+)
+
+// CurriculumRecorder is the part of CurriculumService other services report
+// practice to.
+type CurriculumRecorder interface {
+	// RecordPractice advances the user through the curriculum and returns
+	// the IDs of the lessons it unlocked.
+	RecordPractice(ctx context.Context, userID uuid.UUID) ([]string, error)
+}
+
+// MasteryReader is the part of CurriculumService other services ask what a
+// user has mastered.
+type MasteryReader interface {
+	// MasteredGroups returns the groups of the lessons the user has mastered.
+	MasteredGroups(ctx context.Context, userID uuid.UUID) ([]string, error)
+}
+
+type CurriculumService interface {
+	CurriculumRecorder
+	MasteryReader
+
+	Get(ctx context.Context, userID uuid.UUID) (dto.CurriculumResponse, error)
+}
+
+type curriculumService struct {
+	txManager database.TxManager
+	now       func() time.Time
+}
+
+func NewCurriculumService(txManager database.TxManager) CurriculumService {
+	return &curriculumService{
+		txManager: txManager,
+		now:       time.Now,
+	}
+}
+
+func (s *curriculumService) RecordPractice(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	_, unlocked, err := s.advance(ctx, userID)
+	return unlocked, err
+}
+
+// Get advances the user first, so answers recorded before the curriculum
+// existed count too.
+func (s *curriculumService) Get(ctx context.Context, userID uuid.UUID) (dto.CurriculumResponse, error) {
+	lessons, _, err := s.advance(ctx, userID)
+	if err != nil {
+		return dto.CurriculumResponse{}, err
+	}
+
+	res := dto.CurriculumResponse{Lessons: lessons, UnlockedGroups: []string{}}
+	for _, l := range lessons {
+		if l.Status != string(curriculum.StatusLocked) {
+			res.UnlockedGroups = append(res.UnlockedGroups, l.Groups...)
+		}
+	}
+	return res, nil
+}
+
+// MasteredGroups advances the user first, like Get.
+func (s *curriculumService) MasteredGroups(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	lessons, _, err := s.advance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	groups := []string{}
+	for _, l := range lessons {
+		if l.Status == string(curriculum.StatusMastered) {
+			groups = append(groups, l.Groups...)
+		}
+	}
+	return groups, nil
+}
+
+// advance walks the lessons in order, unlocking each one whose predecessor
+// is mastered and marking unlocked lessons mastered once the recent answers
+// meet their thresholds. Mastery is kept even if accuracy drops later.
+func (s *curriculumService) advance(ctx context.Context, userID uuid.UUID) ([]dto.LessonResponse, []string, error) {
+	plan := curriculum.Lessons()
+	var allKana []string
+	for _, l := range plan {
+		allKana = append(allKana, l.Kana()...)
+	}
+
+	var lessons []dto.LessonResponse
+	var unlocked []string
+	err := s.txManager.ExecTx(ctx, func(qtx database.Querier) error {
+		rows, err := qtx.ListCurriculumProgress(ctx, userID)
+		if err != nil {
+			return err
+		}
+		progress := make(map[string]database.CurriculumProgress, len(rows))
+		for _, row := range rows {
+			progress[row.LessonID] = row
+		}
+
+		statRows, err := qtx.GetRecentCharacterAccuracy(ctx, database.GetRecentCharacterAccuracyParams{
+			UserID: userID,
+			Kana:   allKana,
+			Last:   curriculum.RecentAnswers,
+		})
+		if err != nil {
+			return err
+		}
+		stats := make(map[string]curriculum.Stat, len(statRows))
+		for _, row := range statRows {
+			stats[row.Kana] = curriculum.Stat{Attempts: int(row.Attempts), Correct: int(row.Correct)}
+		}
+
+		open := true // the first lesson is always unlocked
+		lessons = make([]dto.LessonResponse, len(plan))
+		for i, l := range plan {
+			// A lesson unlocked before stays unlocked, e.g. if the plan changes
+			row, ok := progress[l.ID]
+			if open && !ok {
+				if err := qtx.UnlockLesson(ctx, database.UnlockLessonParams{UserID: userID, LessonID: l.ID}); err != nil {
+					return err
+				}
+				row = database.CurriculumProgress{UserID: userID, LessonID: l.ID, UnlockedAt: s.now()}
+				ok = true
+				if i > 0 {
+					unlocked = append(unlocked, l.ID)
+				}
+			}
+			if ok && !row.MasteredAt.Valid && l.Mastered(stats) {
+				if _, err := qtx.MarkLessonMastered(ctx, database.MarkLessonMasteredParams{UserID: userID, LessonID: l.ID}); err != nil {
+					return err
+				}
+				row.MasteredAt.Time, row.MasteredAt.Valid = s.now(), true
+			}
+
+			lessons[i] = lessonResponse(l, stats, row, ok)
+			open = ok && row.MasteredAt.Valid
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return lessons, unlocked, nil
+}
+
+func lessonResponse(l curriculum.Lesson, stats map[string]curriculum.Stat, row database.CurriculumProgress, unlocked bool) dto.LessonResponse {
+	res := dto.LessonResponse{
+		ID:          l.ID,
+		Title:       l.Title,
+		Groups:      l.Groups,
+		Kana:        l.Kana(),
+		Status:      string(curriculum.StatusLocked),
+		MinAttempts: l.MinAttempts,
+		Threshold:   l.Threshold,
+	}
+	for _, k := range res.Kana {
+		res.Attempts += stats[k].Attempts
+	}
+	res.Accuracy, _ = l.Accuracy(stats)
+	if !unlocked {
+		return res
+	}
+
+	unlockedAt := row.UnlockedAt
+	res.UnlockedAt = &unlockedAt
+	switch {
+	case row.MasteredAt.Valid:
+		masteredAt := row.MasteredAt.Time
+		res.MasteredAt = &masteredAt
+		res.Status = string(curriculum.StatusMastered)
+	case res.Attempts > 0:
+		res.Status = string(curriculum.StatusInProgress)
+	default:
+		res.Status = string(curriculum.StatusUnlocked)
+	}
+	return res
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/Cadimodev/haiji/backend/internal/curriculum"
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/google/uuid"
+)
+
+type curriculumMockQuerier struct {
+	database.Querier
+	progress map[string]database.CurriculumProgress
+	stats    map[string]curriculum.Stat
+}
+
+func (m *curriculumMockQuerier) ListCurriculumProgress(ctx context.Context, userID uuid.UUID) ([]database.CurriculumProgress, error) {
+	var rows []database.CurriculumProgress
+	for _, row := range m.progress {
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (m *curriculumMockQuerier) UnlockLesson(ctx context.Context, arg database.UnlockLessonParams) error {
+	if _, ok := m.progress[arg.LessonID]; !ok {
+		m.progress[arg.LessonID] = database.CurriculumProgress{UserID: arg.UserID, LessonID: arg.LessonID}
+	}
+	return nil
+}
+
+func (m *curriculumMockQuerier) MarkLessonMastered(ctx context.Context, arg database.MarkLessonMasteredParams) (int64, error) {
+	row := m.progress[arg.LessonID]
+	row.MasteredAt.Valid = true
+	m.progress[arg.LessonID] = row
+	return 1, nil
+}
+
+func (m *curriculumMockQuerier) GetRecentCharacterAccuracy(ctx context.Context, arg database.GetRecentCharacterAccuracyParams) ([]database.GetRecentCharacterAccuracyRow, error) {
+	var rows []database.GetRecentCharacterAccuracyRow
+	for _, k := range arg.Kana {
+		if s, ok := m.stats[k]; ok {
+			rows = append(rows, database.GetRecentCharacterAccuracyRow{Kana: k, Attempts: int64(s.Attempts), Correct: int64(s.Correct)})
+		}
+	}
+	return rows, nil
+}
+
+func (m *curriculumMockQuerier) answer(l curriculum.Lesson, attempts, correct int) {
+	for _, k := range l.Kana() {
+		m.stats[k] = curriculum.Stat{Attempts: attempts, Correct: correct}
+	}
+}
+
+func newCurriculumService() (*curriculumMockQuerier, CurriculumService) {
+	db := &curriculumMockQuerier{
+		progress: make(map[string]database.CurriculumProgress),
+		stats:    make(map[string]curriculum.Stat),
+	}
+	return db, NewCurriculumService(&MockTxManager{db: db})
+}
+
+func TestCurriculumService_Get(t *testing.T) {
+	db, svc := newCurriculumService()
+	lessons := curriculum.Lessons()
+	db.answer(lessons[0], 10, 10)
+	db.answer(lessons[1], 10, 6)
+
+	res, err := svc.Get(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	want := []curriculum.Status{curriculum.StatusMastered, curriculum.StatusInProgress, curriculum.StatusLocked}
+	for i, status := range want {
+		if res.Lessons[i].Status != string(status) {
+			t.Errorf("%s: expected %s, got %s", res.Lessons[i].ID, status, res.Lessons[i].Status)
+		}
+	}
+	if res.Lessons[1].Accuracy != 0.6 {
+		t.Errorf("Expected 0.6 accuracy, got %v", res.Lessons[1].Accuracy)
+	}
+	if !slices.Equal(res.UnlockedGroups, []string{"hsingle", "hk"}) {
+		t.Errorf("Expected the first two lessons' groups, got %v", res.UnlockedGroups)
+	}
+
+	mastered, err := svc.MasteredGroups(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("MasteredGroups failed: %v", err)
+	}
+	if !slices.Equal(mastered, []string{"hsingle"}) {
+		t.Errorf("Expected the first lesson's groups, got %v", mastered)
+	}
+}
+
+func TestCurriculumService_RecordPracticeUnlocksInOrder(t *testing.T) {
+	db, svc := newCurriculumService()
+	lessons := curriculum.Lessons()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	unlocked, err := svc.RecordPractice(ctx, userID)
+	if err != nil {
+		t.Fatalf("RecordPractice failed: %v", err)
+	}
+	if len(unlocked) != 0 {
+		t.Errorf("Expected only the first lesson, which is always open, got %v", unlocked)
+	}
+
+	// Mastering a later lesson doesn't skip ahead of the first
+	db.answer(lessons[1], 10, 10)
+	if unlocked, _ = svc.RecordPractice(ctx, userID); len(unlocked) != 0 {
+		t.Errorf("Expected nothing unlocked, got %v", unlocked)
+	}
+
+	// Mastering the first cascades through the one already mastered
+	db.answer(lessons[0], 10, 10)
+	unlocked, _ = svc.RecordPractice(ctx, userID)
+	if !slices.Equal(unlocked, []string{lessons[1].ID, lessons[2].ID}) {
+		t.Errorf("Expected %s and %s unlocked, got %v", lessons[1].ID, lessons[2].ID, unlocked)
+	}
+
+	// Mastery sticks after accuracy drops
+	db.answer(lessons[0], 10, 0)
+	res, _ := svc.Get(ctx, userID)
+	if res.Lessons[0].Status != string(curriculum.StatusMastered) {
+		t.Errorf("Expected the first lesson to stay mastered, got %s", res.Lessons[0].Status)
+	}
+}
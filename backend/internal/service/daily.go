@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"hash/fnv"
+	"math/rand/v2"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/google/uuid"
+)
+
+// DailyLength is the number of kana in each daily challenge.
+const DailyLength = 20
+
+const dailyDateLayout = "2006-01-02"
+
+var (
+	ErrDailyAlreadyPlayed = errors.New("daily challenge already played")
+	ErrDailyNotStarted    = errors.New("daily challenge not started")
+)
+
+type DailyService interface {
+	GetToday(ctx context.Context, userID uuid.UUID) (dto.DailyChallengeResponse, error)
+	Start(ctx context.Context, userID uuid.UUID) (dto.DailyStartResponse, error)
+	Finish(ctx context.Context, userID uuid.UUID, params dto.DailyFinishRequest) (dto.DailyResultResponse, error)
+	Leaderboard(ctx context.Context, date time.Time, limit int) ([]dto.DailyLeaderboardEntry, error)
+}
+
+type dailyService struct {
+	db  database.Querier
+	now func() time.Time
+}
+
+func NewDailyService(db database.Querier) DailyService {
+	return &dailyService{
+		db:  db,
+		now: time.Now,
+	}
+}
+
+// DailySeed derives the seed of a day's challenge from its UTC date.
+func DailySeed(date time.Time) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("haiji-daily-" + date.UTC().Format(dailyDateLayout)))
+	return int64(h.Sum64())
+}
+
+// DailySequence deterministically generates the kana sequence for a seed,
+// drawing a group and then a character from every basic and combination group.
+func DailySequence(seed int64, length int) []kana.Char {
+	r := rand.New(rand.NewPCG(uint64(seed), 0))
+	groups := kana.Groups()
+
+	seq := make([]kana.Char, 0, length)
+	for len(seq) < length {
+		g := groups[r.IntN(len(groups))]
+		c := g.Chars[r.IntN(len(g.Chars))]
+		// Avoid the same prompt twice in a row
+		if len(seq) > 0 && seq[len(seq)-1].Kana == c.Kana {
+			continue
+		}
+		seq = append(seq, c)
+	}
+	return seq
+}
+
+func (s *dailyService) today() time.Time {
+	now := s.now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// challenge returns the stored configuration for the day, creating it on first use.
+func (s *dailyService) challenge(ctx context.Context, date time.Time) (database.DailyChallenge, error) {
+	return s.db.UpsertDailyChallenge(ctx, database.UpsertDailyChallengeParams{
+		ChallengeDate: date,
+		Seed:          DailySeed(date),
+		Length:        DailyLength,
+	})
+}
+
+func (s *dailyService) GetToday(ctx context.Context, userID uuid.UUID) (dto.DailyChallengeResponse, error) {
+	date := s.today()
+	challenge, err := s.challenge(ctx, date)
+	if err != nil {
+		return dto.DailyChallengeResponse{}, err
+	}
+
+	response := dto.DailyChallengeResponse{
+		Date:   date.Format(dailyDateLayout),
+		Length: int(challenge.Length),
+	}
+
+	attempt, err := s.db.GetDailyAttempt(ctx, database.GetDailyAttemptParams{
+		UserID:        userID,
+		ChallengeDate: date,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return response, nil
+	}
+	if err != nil {
+		return dto.DailyChallengeResponse{}, err
+	}
+
+	response.Started = true
+	response.Sequence = DailySequence(challenge.Seed, int(challenge.Length))
+	if attempt.FinishedAt.Valid {
+		result, err := s.attemptToResult(ctx, attempt)
+		if err != nil {
+			return dto.DailyChallengeResponse{}, err
+		}
+		response.Result = &result
+	}
+	return response, nil
+}
+
+func (s *dailyService) Start(ctx context.Context, userID uuid.UUID) (dto.DailyStartResponse, error) {
+	date := s.today()
+	challenge, err := s.challenge(ctx, date)
+	if err != nil {
+		return dto.DailyStartResponse{}, err
+	}
+
+	attempt, err := s.db.CreateDailyAttempt(ctx, database.CreateDailyAttemptParams{
+		UserID:        userID,
+		ChallengeDate: date,
+		StartedAt:     s.now(),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// Already started: the clock keeps running, unless it's finished
+		attempt, err = s.db.GetDailyAttempt(ctx, database.GetDailyAttemptParams{
+			UserID:        userID,
+			ChallengeDate: date,
+		})
+		if err != nil {
+			return dto.DailyStartResponse{}, err
+		}
+		if attempt.FinishedAt.Valid {
+			return dto.DailyStartResponse{}, ErrDailyAlreadyPlayed
+		}
+	} else if err != nil {
+		return dto.DailyStartResponse{}, err
+	}
+
+	return dto.DailyStartResponse{
+		Date:      date.Format(dailyDateLayout),
+		StartedAt: attempt.StartedAt,
+		Sequence:  DailySequence(challenge.Seed, int(challenge.Length)),
+	}, nil
+}
+
+func (s *dailyService) Finish(ctx context.Context, userID uuid.UUID, params dto.DailyFinishRequest) (dto.DailyResultResponse, error) {
+	date, err := time.Parse(dailyDateLayout, params.Date)
+	if err != nil {
+		return dto.DailyResultResponse{}, err
+	}
+
+	attempt, err := s.db.GetDailyAttempt(ctx, database.GetDailyAttemptParams{
+		UserID:        userID,
+		ChallengeDate: date,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.DailyResultResponse{}, ErrDailyNotStarted
+	}
+	if err != nil {
+		return dto.DailyResultResponse{}, err
+	}
+	if attempt.FinishedAt.Valid {
+		return dto.DailyResultResponse{}, ErrDailyAlreadyPlayed
+	}
+
+	challenge, err := s.challenge(ctx, date)
+	if err != nil {
+		return dto.DailyResultResponse{}, err
+	}
+	sequence := DailySequence(challenge.Seed, int(challenge.Length))
+	systems, err := userRomajiSystems(ctx, s.db, userID)
+	if err != nil {
+		return dto.DailyResultResponse{}, err
+	}
+
+	correct := 0
+	for i, answer := range params.Answers {
+		if i >= len(sequence) {
+			break
+		}
+		if kana.IsCorrect(sequence[i], answer, systems...) {
+			correct++
+		}
+	}
+
+	finishedAt := s.now()
+	duration := finishedAt.Sub(attempt.StartedAt).Milliseconds()
+
+	// The update only matches unfinished attempts, so concurrent finishes score once
+	attempt, err = s.db.FinishDailyAttempt(ctx, database.FinishDailyAttemptParams{
+		UserID:        userID,
+		ChallengeDate: date,
+		FinishedAt:    sql.NullTime{Time: finishedAt, Valid: true},
+		Correct:       int32(correct),
+		Total:         int32(len(sequence)),
+		DurationMs:    sql.NullInt32{Int32: int32(duration), Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.DailyResultResponse{}, ErrDailyAlreadyPlayed
+	}
+	if err != nil {
+		return dto.DailyResultResponse{}, err
+	}
+
+	return s.attemptToResult(ctx, attempt)
+}
+
+func (s *dailyService) attemptToResult(ctx context.Context, attempt database.DailyAttempt) (dto.DailyResultResponse, error) {
+	ahead, err := s.db.CountDailyAttemptsAhead(ctx, database.CountDailyAttemptsAheadParams{
+		ChallengeDate: attempt.ChallengeDate,
+		Correct:       attempt.Correct,
+		DurationMs:    attempt.DurationMs,
+	})
+	if err != nil {
+		return dto.DailyResultResponse{}, err
+	}
+
+	return dto.DailyResultResponse{
+		Date:       attempt.ChallengeDate.Format(dailyDateLayout),
+		Correct:    int(attempt.Correct),
+		Total:      int(attempt.Total),
+		DurationMs: int(attempt.DurationMs.Int32),
+		FinishedAt: attempt.FinishedAt.Time,
+		Rank:       int(ahead) + 1,
+	}, nil
+}
+
+func (s *dailyService) Leaderboard(ctx context.Context, date time.Time, limit int) ([]dto.DailyLeaderboardEntry, error) {
+	rows, err := s.db.GetDailyLeaderboard(ctx, database.GetDailyLeaderboardParams{
+		ChallengeDate: date,
+		Limit:         int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]dto.DailyLeaderboardEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = dto.DailyLeaderboardEntry{
+			Rank:       i + 1,
+			UserID:     row.UserID,
+			Username:   row.Username,
+			Correct:    int(row.Correct),
+			Total:      int(row.Total),
+			DurationMs: int(row.DurationMs.Int32),
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/google/uuid"
+)
+
+type dailyMockQuerier struct {
+	database.Querier
+	challenges map[time.Time]database.DailyChallenge
+	attempts   map[string]database.DailyAttempt
+}
+
+func (m *dailyMockQuerier) GetUserByID(ctx context.Context, id uuid.UUID) (database.User, error) {
+	return database.User{ID: id}, nil
+}
+
+func newDailyMockQuerier() *dailyMockQuerier {
+	return &dailyMockQuerier{
+		challenges: make(map[time.Time]database.DailyChallenge),
+		attempts:   make(map[string]database.DailyAttempt),
+	}
+}
+
+func attemptKey(userID uuid.UUID, date time.Time) string {
+	return userID.String() + date.Format("2006-01-02")
+}
+
+func (m *dailyMockQuerier) UpsertDailyChallenge(ctx context.Context, arg database.UpsertDailyChallengeParams) (database.DailyChallenge, error) {
+	if c, ok := m.challenges[arg.ChallengeDate]; ok {
+		return c, nil
+	}
+	c := database.DailyChallenge{ChallengeDate: arg.ChallengeDate, Seed: arg.Seed, Length: arg.Length}
+	m.challenges[arg.ChallengeDate] = c
+	return c, nil
+}
+
+func (m *dailyMockQuerier) CreateDailyAttempt(ctx context.Context, arg database.CreateDailyAttemptParams) (database.DailyAttempt, error) {
+	key := attemptKey(arg.UserID, arg.ChallengeDate)
+	if _, ok := m.attempts[key]; ok {
+		return database.DailyAttempt{}, sql.ErrNoRows
+	}
+	a := database.DailyAttempt{UserID: arg.UserID, ChallengeDate: arg.ChallengeDate, StartedAt: arg.StartedAt}
+	m.attempts[key] = a
+	return a, nil
+}
+
+func (m *dailyMockQuerier) GetDailyAttempt(ctx context.Context, arg database.GetDailyAttemptParams) (database.DailyAttempt, error) {
+	a, ok := m.attempts[attemptKey(arg.UserID, arg.ChallengeDate)]
+	if !ok {
+		return database.DailyAttempt{}, sql.ErrNoRows
+	}
+	return a, nil
+}
+
+func (m *dailyMockQuerier) FinishDailyAttempt(ctx context.Context, arg database.FinishDailyAttemptParams) (database.DailyAttempt, error) {
+	key := attemptKey(arg.UserID, arg.ChallengeDate)
+	a, ok := m.attempts[key]
+	if !ok || a.FinishedAt.Valid {
+		return database.DailyAttempt{}, sql.ErrNoRows
+	}
+	a.FinishedAt = arg.FinishedAt
+	a.Correct = arg.Correct
+	a.Total = arg.Total
+	a.DurationMs = arg.DurationMs
+	m.attempts[key] = a
+	return a, nil
+}
+
+func (m *dailyMockQuerier) CountDailyAttemptsAhead(ctx context.Context, arg database.CountDailyAttemptsAheadParams) (int64, error) {
+	return 0, nil
+}
+
+func TestDailySequence_Deterministic(t *testing.T) {
+	day := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	a := DailySequence(DailySeed(day), DailyLength)
+	b := DailySequence(DailySeed(day.Add(5*time.Hour)), DailyLength)
+
+	if len(a) != DailyLength {
+		t.Fatalf("Expected %d kana, got %d", DailyLength, len(a))
+	}
+	for i := range a {
+		if a[i].Kana != b[i].Kana {
+			t.Fatalf("Sequences differ at %d: %v vs %v", i, a[i], b[i])
+		}
+	}
+
+	c := DailySequence(DailySeed(day.AddDate(0, 0, 1)), DailyLength)
+	same := true
+	for i := range a {
+		if a[i].Kana != c[i].Kana {
+			same = false
+		}
+	}
+	if same {
+		t.Error("Expected a different sequence on the next day")
+	}
+}
+
+func TestDailyService_OneScoredAttempt(t *testing.T) {
+	db := newDailyMockQuerier()
+	start := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+	now := start
+	s := &dailyService{db: db, now: func() time.Time { return now }}
+	ctx := context.Background()
+	userID := uuid.New()
+
+	// Finishing before starting fails
+	_, err := s.Finish(ctx, userID, dto.DailyFinishRequest{Date: "2026-03-14"})
+	if !errors.Is(err, ErrDailyNotStarted) {
+		t.Fatalf("Expected ErrDailyNotStarted, got %v", err)
+	}
+
+	started, err := s.Start(ctx, userID)
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	// Answer the first half correctly
+	answers := make([]string, len(started.Sequence))
+	for i, c := range started.Sequence {
+		if i < len(answers)/2 {
+			answers[i] = c.Romanji
+		} else {
+			answers[i] = "x"
+		}
+	}
+
+	now = start.Add(42 * time.Second)
+	result, err := s.Finish(ctx, userID, dto.DailyFinishRequest{Date: started.Date, Answers: answers})
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if result.Correct != DailyLength/2 || result.Total != DailyLength {
+		t.Errorf("Expected %d/%d, got %d/%d", DailyLength/2, DailyLength, result.Correct, result.Total)
+	}
+	if result.DurationMs != 42000 {
+		t.Errorf("Expected server-timed duration 42000ms, got %d", result.DurationMs)
+	}
+
+	// Second attempt is refused
+	if _, err := s.Start(ctx, userID); !errors.Is(err, ErrDailyAlreadyPlayed) {
+		t.Errorf("Expected ErrDailyAlreadyPlayed on restart, got %v", err)
+	}
+	if _, err := s.Finish(ctx, userID, dto.DailyFinishRequest{Date: started.Date, Answers: answers}); !errors.Is(err, ErrDailyAlreadyPlayed) {
+		t.Errorf("Expected ErrDailyAlreadyPlayed on second finish, got %v", err)
+	}
+
+	today, err := s.GetToday(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetToday: %v", err)
+	}
+	if today.Result == nil || today.Result.Correct != DailyLength/2 {
+		t.Errorf("Expected today's result in GetToday, got %+v", today.Result)
+	}
+}
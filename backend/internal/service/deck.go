@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/google/uuid"
+)
+
+const deckCodeLength = 6
+
+var (
+	ErrDeckNotFound     = errors.New("deck not found")
+	ErrNotDeckOwner     = errors.New("only the owner can change a deck")
+	ErrInvalidDeckName  = errors.New("deck name can't be blank")
+	ErrInvalidDeckEntry = errors.New("invalid deck entry")
+)
+
+type DeckService interface {
+	// Lets rooms use decks as groups
+	game.DeckResolver
+
+	Create(ctx context.Context, userID uuid.UUID, params dto.DeckRequest) (dto.DeckResponse, error)
+	Get(ctx context.Context, code string) (dto.DeckResponse, error)
+	ListMine(ctx context.Context, userID uuid.UUID) ([]dto.DeckSummaryResponse, error)
+	Update(ctx context.Context, userID uuid.UUID, code string, params dto.DeckRequest) (dto.DeckResponse, error)
+	Delete(ctx context.Context, userID uuid.UUID, code string) error
+}
+
+type deckService struct {
+	txManager database.TxManager
+	db        database.Querier
+}
+
+func NewDeckService(txManager database.TxManager, db database.Querier) DeckService {
+	return &deckService{
+		txManager: txManager,
+		db:        db,
+	}
+}
+
+func (s *deckService) Create(ctx context.Context, userID uuid.UUID, params dto.DeckRequest) (dto.DeckResponse, error) {
+	name, prompts, answers, err := validateDeck(params)
+	if err != nil {
+		return dto.DeckResponse{}, err
+	}
+
+	for i := 0; i < 5; i++ {
+		code := shareCode(deckCodeLength)
+		err = s.txManager.ExecTx(ctx, func(qtx database.Querier) error {
+			deck, err := qtx.CreateDeck(ctx, database.CreateDeckParams{
+				Code:    code,
+				OwnerID: userID,
+				Name:    name,
+			})
+			if err != nil {
+				return err
+			}
+			return qtx.CreateDeckEntries(ctx, database.CreateDeckEntriesParams{
+				DeckID:  deck.ID,
+				Prompts: prompts,
+				Answers: answers,
+			})
+		})
+		if database.IsUnique(err) {
+			continue
+		}
+		if err != nil {
+			return dto.DeckResponse{}, err
+		}
+		return s.Get(ctx, code)
+	}
+	return dto.DeckResponse{}, errors.New("couldn't find a free deck code")
+}
+
+func (s *deckService) Get(ctx context.Context, code string) (dto.DeckResponse, error) {
+	deck, err := s.db.GetDeckByCode(ctx, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.DeckResponse{}, ErrDeckNotFound
+	}
+	if err != nil {
+		return dto.DeckResponse{}, err
+	}
+
+	rows, err := s.db.ListDeckEntries(ctx, deck.ID)
+	if err != nil {
+		return dto.DeckResponse{}, err
+	}
+	entries := make([]kana.Char, len(rows))
+	for i, row := range rows {
+		entries[i] = kana.Char{Kana: row.Prompt, Romanji: row.Answer}
+	}
+
+	return dto.DeckResponse{
+		Code:      deck.Code,
+		Group:     kana.DeckGroupPrefix + deck.Code,
+		Name:      deck.Name,
+		Owner:     deck.OwnerUsername,
+		Entries:   entries,
+		CreatedAt: deck.CreatedAt,
+		UpdatedAt: deck.UpdatedAt,
+	}, nil
+}
+
+func (s *deckService) ListMine(ctx context.Context, userID uuid.UUID) ([]dto.DeckSummaryResponse, error) {
+	rows, err := s.db.ListDecksByOwner(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.DeckSummaryResponse, len(rows))
+	for i, row := range rows {
+		response[i] = dto.DeckSummaryResponse{
+			Code:       row.Code,
+			Group:      kana.DeckGroupPrefix + row.Code,
+			Name:       row.Name,
+			EntryCount: int(row.EntryCount),
+			CreatedAt:  row.CreatedAt,
+			UpdatedAt:  row.UpdatedAt,
+		}
+	}
+	return response, nil
+}
+
+func (s *deckService) Update(ctx context.Context, userID uuid.UUID, code string, params dto.DeckRequest) (dto.DeckResponse, error) {
+	name, prompts, answers, err := validateDeck(params)
+	if err != nil {
+		return dto.DeckResponse{}, err
+	}
+
+	deck, err := s.ownedDeck(ctx, userID, code)
+	if err != nil {
+		return dto.DeckResponse{}, err
+	}
+
+	err = s.txManager.ExecTx(ctx, func(qtx database.Querier) error {
+		if err := qtx.RenameDeck(ctx, database.RenameDeckParams{
+			ID:   deck.ID,
+			Name: name,
+		}); err != nil {
+			return err
+		}
+		if err := qtx.DeleteDeckEntries(ctx, deck.ID); err != nil {
+			return err
+		}
+		return qtx.CreateDeckEntries(ctx, database.CreateDeckEntriesParams{
+			DeckID:  deck.ID,
+			Prompts: prompts,
+			Answers: answers,
+		})
+	})
+	if err != nil {
+		return dto.DeckResponse{}, err
+	}
+	return s.Get(ctx, code)
+}
+
+func (s *deckService) Delete(ctx context.Context, userID uuid.UUID, code string) error {
+	deck, err := s.ownedDeck(ctx, userID, code)
+	if err != nil {
+		return err
+	}
+	return s.db.DeleteDeck(ctx, deck.ID)
+}
+
+func (s *deckService) ownedDeck(ctx context.Context, userID uuid.UUID, code string) (database.GetDeckByCodeRow, error) {
+	deck, err := s.db.GetDeckByCode(ctx, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return deck, ErrDeckNotFound
+	}
+	if err != nil {
+		return deck, err
+	}
+	if deck.OwnerID != userID {
+		return deck, ErrNotDeckOwner
+	}
+	return deck, nil
+}
+
+func (s *deckService) ResolveDecks(ctx context.Context, groups []string) (map[string][]kana.Char, error) {
+	return loadGroupEntries(ctx, s.db, groups)
+}
+
+// loadGroupEntries returns the entries of the "deck:CODE" and
+// "kanji:jlptN" groups, keyed by group. Other groups are ignored.
+func loadGroupEntries(ctx context.Context, db database.Querier, groups []string) (map[string][]kana.Char, error) {
+	var codes []string
+	var levels []int32
+	for _, g := range groups {
+		if code, ok := kana.DeckCode(g); ok {
+			codes = append(codes, code)
+		}
+		if level, ok := kana.KanjiLevel(g); ok {
+			levels = append(levels, int32(level))
+		}
+	}
+	if len(codes) == 0 && len(levels) == 0 {
+		return nil, nil
+	}
+
+	entries := make(map[string][]kana.Char, len(codes)+len(levels))
+	if len(codes) > 0 {
+		rows, err := db.ListDeckEntriesByCodes(ctx, codes)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			group := kana.DeckGroupPrefix + row.Code
+			entries[group] = append(entries[group], kana.Char{Kana: row.Prompt, Romanji: row.Answer})
+		}
+	}
+
+	// Decks always have entries, so a missing one doesn't exist
+	for _, code := range codes {
+		if _, ok := entries[kana.DeckGroupPrefix+code]; !ok {
+			return nil, fmt.Errorf("%w: %s", game.ErrUnknownDeck, code)
+		}
+	}
+
+	if len(levels) > 0 {
+		rows, err := db.ListKanjiReadingsByJLPT(ctx, levels)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if c, ok := kanjiChar(row.Literal, row.OnReadings, row.KunReadings); ok {
+				group := kana.KanjiGroup(int(row.Jlpt))
+				entries[group] = append(entries[group], c)
+			}
+		}
+	}
+	return entries, nil
+}
+
+// validateDeck normalizes the name and splits the entries into the prompt
+// and answer columns.
+func validateDeck(params dto.DeckRequest) (name string, prompts, answers []string, err error) {
+	name = strings.TrimSpace(params.Name)
+	if name == "" {
+		return "", nil, nil, ErrInvalidDeckName
+	}
+
+	seen := make(map[string]bool, len(params.Entries))
+	for _, e := range params.Entries {
+		prompt := strings.TrimSpace(e.Kana)
+		answer := strings.Join(strings.Fields(strings.ToLower(e.Romanji)), " ")
+
+		if !kana.IsKanaText(prompt) {
+			return "", nil, nil, fmt.Errorf("%w: %q is not kana", ErrInvalidDeckEntry, e.Kana)
+		}
+		if !isRomaji(answer) {
+			return "", nil, nil, fmt.Errorf("%w: %q is not romaji", ErrInvalidDeckEntry, e.Romanji)
+		}
+		// Answers are checked by prompt, so each prompt has one answer
+		if seen[prompt] {
+			return "", nil, nil, fmt.Errorf("%w: %s appears twice", ErrInvalidDeckEntry, prompt)
+		}
+		seen[prompt] = true
+
+		prompts = append(prompts, prompt)
+		answers = append(answers, answer)
+	}
+	return name, prompts, answers, nil
+}
+
+func isRomaji(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && r != '\'' && r != '-' && r != ' ' {
+			return false
+		}
+	}
+	return true
+}
+
+// groupChars returns the characters of catalog, deck and kanji groups, in
+// order.
+func groupChars(ctx context.Context, db database.Querier, groups []string) ([]kana.Char, error) {
+	entries, err := loadGroupEntries(ctx, db, groups)
+	if err != nil {
+		return nil, err
+	}
+	var chars []kana.Char
+	for _, id := range groups {
+		g, isCatalog := kana.GetGroup(id)
+		_, isDeck := kana.DeckCode(id)
+		_, isKanji := kana.KanjiLevel(id)
+		switch {
+		case isCatalog:
+			chars = append(chars, g.Chars...)
+		case isDeck || isKanji:
+			chars = append(chars, entries[id]...)
+		default:
+			return nil, fmt.Errorf("%w: %s", kana.ErrUnknownGroup, id)
+		}
+	}
+	return chars, nil
+}
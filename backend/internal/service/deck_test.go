@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/google/uuid"
+)
+
+type deckMockQuerier struct {
+	practiceMockQuerier
+	decks   map[string]database.Deck
+	entries map[uuid.UUID][]database.ListDeckEntriesRow
+}
+
+func newDeckMockQuerier() *deckMockQuerier {
+	return &deckMockQuerier{
+		decks:   make(map[string]database.Deck),
+		entries: make(map[uuid.UUID][]database.ListDeckEntriesRow),
+	}
+}
+
+func (m *deckMockQuerier) CreateDeck(ctx context.Context, arg database.CreateDeckParams) (database.Deck, error) {
+	deck := database.Deck{ID: uuid.New(), Code: arg.Code, OwnerID: arg.OwnerID, Name: arg.Name, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	m.decks[arg.Code] = deck
+	return deck, nil
+}
+
+func (m *deckMockQuerier) CreateDeckEntries(ctx context.Context, arg database.CreateDeckEntriesParams) error {
+	for i := range arg.Prompts {
+		m.entries[arg.DeckID] = append(m.entries[arg.DeckID], database.ListDeckEntriesRow{Prompt: arg.Prompts[i], Answer: arg.Answers[i]})
+	}
+	return nil
+}
+
+func (m *deckMockQuerier) DeleteDeckEntries(ctx context.Context, deckID uuid.UUID) error {
+	delete(m.entries, deckID)
+	return nil
+}
+
+func (m *deckMockQuerier) RenameDeck(ctx context.Context, arg database.RenameDeckParams) error {
+	for code, d := range m.decks {
+		if d.ID == arg.ID {
+			d.Name = arg.Name
+			m.decks[code] = d
+		}
+	}
+	return nil
+}
+
+func (m *deckMockQuerier) GetDeckByCode(ctx context.Context, code string) (database.GetDeckByCodeRow, error) {
+	d, ok := m.decks[code]
+	if !ok {
+		return database.GetDeckByCodeRow{}, sql.ErrNoRows
+	}
+	return database.GetDeckByCodeRow{ID: d.ID, Code: d.Code, OwnerID: d.OwnerID, OwnerUsername: "teacher", Name: d.Name}, nil
+}
+
+func (m *deckMockQuerier) ListDeckEntries(ctx context.Context, deckID uuid.UUID) ([]database.ListDeckEntriesRow, error) {
+	return m.entries[deckID], nil
+}
+
+func (m *deckMockQuerier) ListDeckEntriesByCodes(ctx context.Context, codes []string) ([]database.ListDeckEntriesByCodesRow, error) {
+	var rows []database.ListDeckEntriesByCodesRow
+	for _, code := range codes {
+		d, ok := m.decks[code]
+		if !ok {
+			continue
+		}
+		for _, e := range m.entries[d.ID] {
+			rows = append(rows, database.ListDeckEntriesByCodesRow{Code: code, Prompt: e.Prompt, Answer: e.Answer})
+		}
+	}
+	return rows, nil
+}
+
+var animalsDeck = dto.DeckRequest{
+	Name: " Animals ",
+	Entries: []dto.DeckEntryRequest{
+		{Kana: "ねこ", Romanji: "Neko"},
+		{Kana: "いぬ", Romanji: "inu"},
+		{Kana: "は", Romanji: "wa"},
+	},
+}
+
+func TestDeckService_CreateAndShare(t *testing.T) {
+	db := newDeckMockQuerier()
+	svc := NewDeckService(&MockTxManager{db: db}, db)
+	ownerID := uuid.New()
+
+	deck, err := svc.Create(context.Background(), ownerID, animalsDeck)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if len(deck.Code) != deckCodeLength || deck.Group != "deck:"+deck.Code || deck.Name != "Animals" {
+		t.Errorf("Unexpected deck: %+v", deck)
+	}
+	if len(deck.Entries) != 3 || deck.Entries[0].Romanji != "neko" {
+		t.Errorf("Expected normalized entries, got %+v", deck.Entries)
+	}
+
+	decks, err := svc.ResolveDecks(context.Background(), []string{"hsingle", deck.Group})
+	if err != nil || len(decks[deck.Group]) != 3 {
+		t.Errorf("ResolveDecks: %v, %v", decks, err)
+	}
+	if _, err := svc.ResolveDecks(context.Background(), []string{"deck:NOPE99"}); !errors.Is(err, game.ErrUnknownDeck) {
+		t.Errorf("Expected ErrUnknownDeck, got %v", err)
+	}
+
+	update := dto.DeckRequest{Name: "Pets", Entries: animalsDeck.Entries[:1]}
+	if _, err := svc.Update(context.Background(), uuid.New(), deck.Code, update); !errors.Is(err, ErrNotDeckOwner) {
+		t.Errorf("Expected ErrNotDeckOwner, got %v", err)
+	}
+	updated, err := svc.Update(context.Background(), ownerID, deck.Code, update)
+	if err != nil || updated.Name != "Pets" || len(updated.Entries) != 1 {
+		t.Errorf("Update: %+v, %v", updated, err)
+	}
+}
+
+func TestDeckService_RejectsInvalidEntries(t *testing.T) {
+	db := newDeckMockQuerier()
+	svc := NewDeckService(&MockTxManager{db: db}, db)
+
+	tests := []dto.DeckRequest{
+		{Name: "Kanji", Entries: []dto.DeckEntryRequest{{Kana: "猫", Romanji: "neko"}}},
+		{Name: "Bad answer", Entries: []dto.DeckEntryRequest{{Kana: "ねこ", Romanji: "ねこ"}}},
+		{Name: "Twice", Entries: []dto.DeckEntryRequest{{Kana: "ねこ", Romanji: "neko"}, {Kana: "ねこ", Romanji: "nyanko"}}},
+	}
+	for _, params := range tests {
+		if _, err := svc.Create(context.Background(), uuid.New(), params); !errors.Is(err, ErrInvalidDeckEntry) {
+			t.Errorf("%s: expected ErrInvalidDeckEntry, got %v", params.Name, err)
+		}
+	}
+
+	blank := dto.DeckRequest{Name: "   ", Entries: animalsDeck.Entries}
+	if _, err := svc.Create(context.Background(), uuid.New(), blank); !errors.Is(err, ErrInvalidDeckName) {
+		t.Errorf("Expected ErrInvalidDeckName, got %v", err)
+	}
+}
+
+func TestPracticeService_RecordSessionWithDeck(t *testing.T) {
+	db := newDeckMockQuerier()
+	deck, err := NewDeckService(&MockTxManager{db: db}, db).Create(context.Background(), uuid.New(), animalsDeck)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	svc := NewPracticeService(&MockTxManager{db: db}, db, nil, nil, nil).(*practiceService)
+	now := time.Now()
+	response, err := svc.RecordSession(context.Background(), uuid.New(), dto.PracticeSessionRequest{
+		Groups:    []string{deck.Group},
+		StartedAt: now.Add(-time.Minute),
+		Answers: []dto.PracticeAnswerRequest{
+			{Kana: "ねこ", Answer: "neko"},
+			// The deck's answer wins over the catalog's "ha"
+			{Kana: "は", Answer: "wa"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RecordSession: %v", err)
+	}
+	if response.Correct != 2 {
+		t.Errorf("Expected both answers correct, got %+v", response)
+	}
+
+	_, err = svc.RecordSession(context.Background(), uuid.New(), dto.PracticeSessionRequest{
+		Groups:    []string{"deck:NOPE99"},
+		StartedAt: now.Add(-time.Minute),
+		Answers:   []dto.PracticeAnswerRequest{{Kana: "ね", Answer: "ne"}},
+	})
+	if !errors.Is(err, game.ErrUnknownDeck) {
+		t.Errorf("Expected ErrUnknownDeck, got %v", err)
+	}
+}
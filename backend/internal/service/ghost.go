@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/google/uuid"
+)
+
+const ghostCodeLength = 8
+
+// Unambiguous characters for shareable codes
+const shareCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+var (
+	ErrRunNotFound       = errors.New("run not found")
+	ErrNotRunOwner       = errors.New("only your own runs can be shared")
+	ErrChallengeNotFound = errors.New("ghost challenge not found")
+	ErrOwnGhost          = errors.New("you can't race your own ghost")
+	ErrRunNotRaceable    = errors.New("only battle runs can be raced as ghosts")
+)
+
+// GhostRoomCreator starts rooms where a recorded run is replayed.
+type GhostRoomCreator interface {
+	CreateGhostRoom(ghost game.Ghost, challengerID uuid.UUID) (string, error)
+}
+
+type GhostService interface {
+	// Records finished games for the hub
+	game.ResultStore
+
+	ListRuns(ctx context.Context, userID uuid.UUID, limit int) ([]dto.BattleRunResponse, error)
+	CreateChallenge(ctx context.Context, userID, runID uuid.UUID) (dto.GhostChallengeResponse, error)
+	GetChallenge(ctx context.Context, code string) (dto.GhostChallengeResponse, error)
+	PlayChallenge(ctx context.Context, userID uuid.UUID, code string) (dto.GhostRoomResponse, error)
+	ListResults(ctx context.Context, userID uuid.UUID, limit int) ([]dto.GhostResultResponse, error)
+}
+
+type ghostService struct {
+	db    database.Querier
+	rooms GhostRoomCreator
+}
+
+func NewGhostService(db database.Querier, rooms GhostRoomCreator) GhostService {
+	return &ghostService{
+		db:    db,
+		rooms: rooms,
+	}
+}
+
+func (s *ghostService) SaveRun(ctx context.Context, run game.RecordedRun) error {
+	events := run.Events
+	if events == nil {
+		events = []game.ScoreEvent{}
+	}
+	data, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.CreateBattleRun(ctx, database.CreateBattleRunParams{
+		UserID:          run.UserID,
+		RoomCode:        run.RoomCode,
+		Groups:          run.Groups,
+		DurationSeconds: int32(run.Duration),
+		FinalScore:      int32(run.FinalScore),
+		Events:          data,
+		Direction:       string(run.Direction),
+		Choices:         int32(run.Choices),
+		Mode:            string(run.Mode),
+	})
+	return err
+}
+
+func (s *ghostService) SaveGhostResult(ctx context.Context, result game.GhostResult) error {
+	_, err := s.db.CreateGhostResult(ctx, database.CreateGhostResultParams{
+		ChallengeCode:   result.ChallengeCode,
+		RunID:           result.RunID,
+		ChallengerID:    result.ChallengerID,
+		GhostUserID:     result.GhostUserID,
+		ChallengerScore: int32(result.ChallengerScore),
+		GhostScore:      int32(result.GhostScore),
+	})
+	return err
+}
+
+func (s *ghostService) ListRuns(ctx context.Context, userID uuid.UUID, limit int) ([]dto.BattleRunResponse, error) {
+	runs, err := s.db.ListBattleRunsByUser(ctx, database.ListBattleRunsByUserParams{
+		UserID: userID,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.BattleRunResponse, len(runs))
+	for i, run := range runs {
+		response[i] = dto.BattleRunResponse{
+			ID:         run.ID,
+			RoomCode:   run.RoomCode,
+			Mode:       run.Mode,
+			Groups:     run.Groups,
+			Direction:  run.Direction,
+			Choices:    int(run.Choices),
+			Duration:   int(run.DurationSeconds),
+			FinalScore: int(run.FinalScore),
+			CreatedAt:  run.CreatedAt,
+		}
+	}
+	return response, nil
+}
+
+func (s *ghostService) CreateChallenge(ctx context.Context, userID, runID uuid.UUID) (dto.GhostChallengeResponse, error) {
+	run, err := s.db.GetBattleRun(ctx, runID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.GhostChallengeResponse{}, ErrRunNotFound
+	}
+	if err != nil {
+		return dto.GhostChallengeResponse{}, err
+	}
+	if run.UserID != userID {
+		return dto.GhostChallengeResponse{}, ErrNotRunOwner
+	}
+	if game.Mode(run.Mode) != game.ModeBattle {
+		return dto.GhostChallengeResponse{}, ErrRunNotRaceable
+	}
+
+	for i := 0; i < 5; i++ {
+		code := shareCode(ghostCodeLength)
+		_, err = s.db.CreateGhostChallenge(ctx, database.CreateGhostChallengeParams{
+			Code:      code,
+			RunID:     run.ID,
+			CreatedBy: userID,
+		})
+		if database.IsUnique(err) {
+			continue
+		}
+		if err != nil {
+			return dto.GhostChallengeResponse{}, err
+		}
+		return s.GetChallenge(ctx, code)
+	}
+	return dto.GhostChallengeResponse{}, errors.New("couldn't find a free challenge code")
+}
+
+func (s *ghostService) GetChallenge(ctx context.Context, code string) (dto.GhostChallengeResponse, error) {
+	row, err := s.db.GetGhostChallenge(ctx, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.GhostChallengeResponse{}, ErrChallengeNotFound
+	}
+	if err != nil {
+		return dto.GhostChallengeResponse{}, err
+	}
+
+	return dto.GhostChallengeResponse{
+		Code:       row.Code,
+		RunID:      row.RunID,
+		UserID:     row.UserID,
+		Username:   row.Username,
+		Groups:     row.Groups,
+		Direction:  row.Direction,
+		Choices:    int(row.Choices),
+		Duration:   int(row.DurationSeconds),
+		FinalScore: int(row.FinalScore),
+		CreatedAt:  row.CreatedAt,
+	}, nil
+}
+
+func (s *ghostService) PlayChallenge(ctx context.Context, userID uuid.UUID, code string) (dto.GhostRoomResponse, error) {
+	row, err := s.db.GetGhostChallenge(ctx, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.GhostRoomResponse{}, ErrChallengeNotFound
+	}
+	if err != nil {
+		return dto.GhostRoomResponse{}, err
+	}
+	if row.UserID == userID {
+		return dto.GhostRoomResponse{}, ErrOwnGhost
+	}
+	// Challenges shared before runs had a mode can still point at one
+	if game.Mode(row.Mode) != game.ModeBattle {
+		return dto.GhostRoomResponse{}, ErrRunNotRaceable
+	}
+
+	var events []game.ScoreEvent
+	if err := json.Unmarshal(row.Events, &events); err != nil {
+		return dto.GhostRoomResponse{}, err
+	}
+
+	roomCode, err := s.rooms.CreateGhostRoom(game.Ghost{
+		ChallengeCode: row.Code,
+		RunID:         row.RunID,
+		UserID:        row.UserID,
+		Username:      row.Username,
+		Groups:        row.Groups,
+		Direction:     kana.Direction(row.Direction),
+		Choices:       int(row.Choices),
+		Duration:      int(row.DurationSeconds),
+		Events:        events,
+	}, userID)
+	if err != nil {
+		return dto.GhostRoomResponse{}, err
+	}
+
+	return dto.GhostRoomResponse{RoomCode: roomCode}, nil
+}
+
+func (s *ghostService) ListResults(ctx context.Context, userID uuid.UUID, limit int) ([]dto.GhostResultResponse, error) {
+	rows, err := s.db.ListGhostResultsForUser(ctx, database.ListGhostResultsForUserParams{
+		ChallengerID: userID,
+		Limit:        int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dto.GhostResultResponse, len(rows))
+	for i, row := range rows {
+		results[i] = dto.GhostResultResponse{
+			ID:                 row.ID,
+			ChallengeCode:      row.ChallengeCode,
+			RunID:              row.RunID,
+			ChallengerID:       row.ChallengerID,
+			ChallengerUsername: row.ChallengerUsername,
+			ChallengerScore:    int(row.ChallengerScore),
+			GhostUserID:        row.GhostUserID,
+			GhostUsername:      row.GhostUsername,
+			GhostScore:         int(row.GhostScore),
+			CreatedAt:          row.CreatedAt,
+		}
+	}
+	return results, nil
+}
+
+func shareCode(length int) string {
+	b := make([]byte, length)
+	rand.Read(b)
+	for i := range b {
+		b[i] = shareCodeAlphabet[int(b[i])%len(shareCodeAlphabet)]
+	}
+	return string(b)
+}
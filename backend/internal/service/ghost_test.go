@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/google/uuid"
+)
+
+type ghostMockQuerier struct {
+	database.Querier
+	users      map[uuid.UUID]string
+	runs       map[uuid.UUID]database.BattleRun
+	challenges map[string]database.GhostChallenge
+}
+
+func newGhostMockQuerier() *ghostMockQuerier {
+	return &ghostMockQuerier{
+		users:      make(map[uuid.UUID]string),
+		runs:       make(map[uuid.UUID]database.BattleRun),
+		challenges: make(map[string]database.GhostChallenge),
+	}
+}
+
+func (m *ghostMockQuerier) CreateBattleRun(ctx context.Context, arg database.CreateBattleRunParams) (database.BattleRun, error) {
+	run := database.BattleRun{
+		ID:              uuid.New(),
+		UserID:          arg.UserID,
+		RoomCode:        arg.RoomCode,
+		Groups:          arg.Groups,
+		DurationSeconds: arg.DurationSeconds,
+		FinalScore:      arg.FinalScore,
+		Events:          arg.Events,
+		CreatedAt:       time.Now(),
+		Mode:            arg.Mode,
+	}
+	m.runs[run.ID] = run
+	return run, nil
+}
+
+func (m *ghostMockQuerier) GetBattleRun(ctx context.Context, id uuid.UUID) (database.BattleRun, error) {
+	run, ok := m.runs[id]
+	if !ok {
+		return database.BattleRun{}, sql.ErrNoRows
+	}
+	return run, nil
+}
+
+func (m *ghostMockQuerier) CreateGhostChallenge(ctx context.Context, arg database.CreateGhostChallengeParams) (database.GhostChallenge, error) {
+	c := database.GhostChallenge{Code: arg.Code, RunID: arg.RunID, CreatedBy: arg.CreatedBy, CreatedAt: time.Now()}
+	m.challenges[arg.Code] = c
+	return c, nil
+}
+
+func (m *ghostMockQuerier) GetGhostChallenge(ctx context.Context, code string) (database.GetGhostChallengeRow, error) {
+	c, ok := m.challenges[code]
+	if !ok {
+		return database.GetGhostChallengeRow{}, sql.ErrNoRows
+	}
+	run := m.runs[c.RunID]
+	return database.GetGhostChallengeRow{
+		Code:            c.Code,
+		CreatedAt:       c.CreatedAt,
+		RunID:           run.ID,
+		UserID:          run.UserID,
+		Username:        m.users[run.UserID],
+		Groups:          run.Groups,
+		DurationSeconds: run.DurationSeconds,
+		FinalScore:      run.FinalScore,
+		Events:          run.Events,
+		Mode:            run.Mode,
+	}, nil
+}
+
+type mockGhostRooms struct {
+	ghost        game.Ghost
+	challengerID uuid.UUID
+}
+
+func (m *mockGhostRooms) CreateGhostRoom(ghost game.Ghost, challengerID uuid.UUID) (string, error) {
+	m.ghost = ghost
+	m.challengerID = challengerID
+	return "ROOM01", nil
+}
+
+func TestGhostService_ChallengeFlow(t *testing.T) {
+	db := newGhostMockQuerier()
+	rooms := &mockGhostRooms{}
+	svc := NewGhostService(db, rooms)
+	ctx := context.Background()
+
+	ownerID := uuid.New()
+	challengerID := uuid.New()
+	db.users[ownerID] = "owner"
+
+	err := svc.SaveRun(ctx, game.RecordedRun{
+		UserID:     ownerID,
+		RoomCode:   "ABC123",
+		Mode:       game.ModeBattle,
+		Groups:     []string{"hsingle"},
+		Duration:   60,
+		FinalScore: 2,
+		Events:     []game.ScoreEvent{{OffsetMs: 800, Score: 1}, {OffsetMs: 1500, Score: 2}},
+	})
+	if err != nil {
+		t.Fatalf("SaveRun: %v", err)
+	}
+	var runID uuid.UUID
+	for id := range db.runs {
+		runID = id
+	}
+
+	if _, err := svc.CreateChallenge(ctx, challengerID, runID); !errors.Is(err, ErrNotRunOwner) {
+		t.Errorf("Expected ErrNotRunOwner sharing someone else's run, got %v", err)
+	}
+	if _, err := svc.CreateChallenge(ctx, ownerID, uuid.New()); !errors.Is(err, ErrRunNotFound) {
+		t.Errorf("Expected ErrRunNotFound, got %v", err)
+	}
+
+	challenge, err := svc.CreateChallenge(ctx, ownerID, runID)
+	if err != nil {
+		t.Fatalf("CreateChallenge: %v", err)
+	}
+	if len(challenge.Code) != ghostCodeLength || challenge.Username != "owner" || challenge.Duration != 60 {
+		t.Errorf("Unexpected challenge: %+v", challenge)
+	}
+
+	if _, err := svc.PlayChallenge(ctx, ownerID, challenge.Code); !errors.Is(err, ErrOwnGhost) {
+		t.Errorf("Expected ErrOwnGhost, got %v", err)
+	}
+	if _, err := svc.PlayChallenge(ctx, challengerID, "NOPE"); !errors.Is(err, ErrChallengeNotFound) {
+		t.Errorf("Expected ErrChallengeNotFound, got %v", err)
+	}
+
+	room, err := svc.PlayChallenge(ctx, challengerID, challenge.Code)
+	if err != nil {
+		t.Fatalf("PlayChallenge: %v", err)
+	}
+	if room.RoomCode != "ROOM01" || rooms.challengerID != challengerID {
+		t.Errorf("Unexpected room: %+v", room)
+	}
+	if rooms.ghost.UserID != ownerID || len(rooms.ghost.Events) != 2 || rooms.ghost.Events[1].OffsetMs != 1500 {
+		t.Errorf("Ghost not built from the recorded run: %+v", rooms.ghost)
+	}
+}
+
+func TestGhostService_OnlyBattlesAreRaceable(t *testing.T) {
+	db := newGhostMockQuerier()
+	svc := NewGhostService(db, &mockGhostRooms{})
+	ctx := context.Background()
+
+	ownerID := uuid.New()
+	if err := svc.SaveRun(ctx, game.RecordedRun{UserID: ownerID, Mode: game.ModePresenter}); err != nil {
+		t.Fatalf("SaveRun: %v", err)
+	}
+	for id, run := range db.runs {
+		if _, err := svc.CreateChallenge(ctx, ownerID, id); !errors.Is(err, ErrRunNotRaceable) {
+			t.Errorf("Expected ErrRunNotRaceable, got %v", err)
+		}
+
+		// A challenge shared before runs had a mode can't be played either
+		db.challenges["OLDCODE1"] = database.GhostChallenge{Code: "OLDCODE1", RunID: run.ID, CreatedBy: ownerID}
+		if _, err := svc.PlayChallenge(ctx, uuid.New(), "OLDCODE1"); !errors.Is(err, ErrRunNotRaceable) {
+			t.Errorf("Expected ErrRunNotRaceable playing, got %v", err)
+		}
+	}
+}
+
+func TestGhostService_SaveRunWithoutEvents(t *testing.T) {
+	db := newGhostMockQuerier()
+	svc := NewGhostService(db, &mockGhostRooms{})
+
+	if err := svc.SaveRun(context.Background(), game.RecordedRun{UserID: uuid.New()}); err != nil {
+		t.Fatalf("SaveRun: %v", err)
+	}
+	for _, run := range db.runs {
+		var events []game.ScoreEvent
+		if err := json.Unmarshal(run.Events, &events); err != nil || events == nil {
+			t.Errorf("Expected an empty JSON array, got %s", run.Events)
+		}
+	}
+}
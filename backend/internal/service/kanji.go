@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"io/fs"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/kanji"
+	"github.com/Cadimodev/haiji/backend/internal/romaji"
+)
+
+var (
+	ErrKanjiNotFound    = errors.New("kanji not found")
+	ErrInvalidJLPTLevel = errors.New("jlpt level must be between 1 and 4")
+)
+
+type KanjiService interface {
+	Levels(ctx context.Context) ([]dto.KanjiLevelResponse, error)
+	ListByJLPT(ctx context.Context, level, limit, offset int) ([]dto.KanjiResponse, error)
+	Get(ctx context.Context, literal string) (dto.KanjiResponse, error)
+	// Import loads a KANJIDIC2 file and, if kanjivg isn't nil, the stroke
+	// paths of a KanjiVG kanji directory, replacing what was imported before
+	Import(ctx context.Context, kanjidic io.Reader, kanjivg fs.FS) (KanjiImportResult, error)
+}
+
+type KanjiImportResult struct {
+	Kanji       int
+	StrokePaths int
+}
+
+type kanjiService struct {
+	txManager database.TxManager
+	db        database.Querier
+}
+
+func NewKanjiService(txManager database.TxManager, db database.Querier) KanjiService {
+	return &kanjiService{
+		txManager: txManager,
+		db:        db,
+	}
+}
+
+func (s *kanjiService) Levels(ctx context.Context) ([]dto.KanjiLevelResponse, error) {
+	rows, err := s.db.CountKanjiByJLPT(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.KanjiLevelResponse, len(rows))
+	for i, row := range rows {
+		response[i] = dto.KanjiLevelResponse{
+			Level: int(row.Jlpt),
+			Group: kana.KanjiGroup(int(row.Jlpt)),
+			Count: int(row.Kanji),
+		}
+	}
+	return response, nil
+}
+
+func (s *kanjiService) ListByJLPT(ctx context.Context, level, limit, offset int) ([]dto.KanjiResponse, error) {
+	if level < 1 || level > kana.MaxKanjiLevel {
+		return nil, ErrInvalidJLPTLevel
+	}
+
+	rows, err := s.db.ListKanjiByJLPT(ctx, database.ListKanjiByJLPTParams{
+		Jlpt:   sql.NullInt32{Int32: int32(level), Valid: true},
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.KanjiResponse, len(rows))
+	for i, row := range rows {
+		response[i] = kanjiResponse(row)
+		response[i].StrokePaths = nil
+	}
+	return response, nil
+}
+
+func (s *kanjiService) Get(ctx context.Context, literal string) (dto.KanjiResponse, error) {
+	row, err := s.db.GetKanji(ctx, literal)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.KanjiResponse{}, ErrKanjiNotFound
+	}
+	if err != nil {
+		return dto.KanjiResponse{}, err
+	}
+	return kanjiResponse(row), nil
+}
+
+func (s *kanjiService) Import(ctx context.Context, kanjidic io.Reader, kanjivg fs.FS) (KanjiImportResult, error) {
+	var result KanjiImportResult
+	err := s.txManager.ExecTx(ctx, func(qtx database.Querier) error {
+		err := kanji.ParseKANJIDIC(kanjidic, func(e kanji.Entry) error {
+			result.Kanji++
+			return qtx.UpsertKanji(ctx, database.UpsertKanjiParams{
+				Literal:     e.Literal,
+				Grade:       optionalInt32(e.Grade),
+				Jlpt:        optionalInt32(e.JLPT),
+				StrokeCount: int32(e.StrokeCount),
+				Frequency:   optionalInt32(e.Frequency),
+				Meanings:    nonNil(e.Meanings),
+				OnReadings:  nonNil(e.OnReadings),
+				KunReadings: nonNil(e.KunReadings),
+			})
+		})
+		if err != nil || kanjivg == nil {
+			return err
+		}
+
+		// KanjiVG also draws kana and symbols, which have no row to update
+		return kanji.WalkKanjiVG(kanjivg, func(literal string, paths []string) error {
+			updated, err := qtx.UpdateKanjiStrokePaths(ctx, database.UpdateKanjiStrokePathsParams{
+				Literal:     literal,
+				StrokePaths: nonNil(paths),
+			})
+			result.StrokePaths += int(updated)
+			return err
+		})
+	})
+	if err != nil {
+		return KanjiImportResult{}, err
+	}
+	return result, nil
+}
+
+// kanjiChar turns a kanji into a prompt answered by any of its readings.
+// The expected answer is the first reading that romanizes; kanji with none
+// can't be asked and return false.
+func kanjiChar(literal string, on, kun []string) (kana.Char, bool) {
+	readings := kanji.Readings(on, kun)
+	for _, r := range readings {
+		if answer, ok := romaji.Romanize(r, romaji.Hepburn); ok {
+			return kana.Char{Kana: literal, Romanji: answer, Readings: readings}, true
+		}
+	}
+	return kana.Char{}, false
+}
+
+func kanjiResponse(row database.Kanji) dto.KanjiResponse {
+	return dto.KanjiResponse{
+		Literal:     row.Literal,
+		Meanings:    nonNil(row.Meanings),
+		OnReadings:  nonNil(row.OnReadings),
+		KunReadings: nonNil(row.KunReadings),
+		JLPT:        int(row.Jlpt.Int32),
+		Grade:       int(row.Grade.Int32),
+		StrokeCount: int(row.StrokeCount),
+		Frequency:   int(row.Frequency.Int32),
+		StrokePaths: row.StrokePaths,
+	}
+}
+
+// optionalInt32 stores the dictionary's 0 for "not given" as NULL.
+func optionalInt32(n int) sql.NullInt32 {
+	return sql.NullInt32{Int32: int32(n), Valid: n != 0}
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/google/uuid"
+)
+
+type kanjiMockQuerier struct {
+	practiceMockQuerier
+	kanji map[string]database.Kanji
+	order []string
+}
+
+func newKanjiMockQuerier() *kanjiMockQuerier {
+	return &kanjiMockQuerier{kanji: make(map[string]database.Kanji)}
+}
+
+func (m *kanjiMockQuerier) UpsertKanji(ctx context.Context, arg database.UpsertKanjiParams) error {
+	if _, ok := m.kanji[arg.Literal]; !ok {
+		m.order = append(m.order, arg.Literal)
+	}
+	m.kanji[arg.Literal] = database.Kanji{
+		Literal:     arg.Literal,
+		Grade:       arg.Grade,
+		Jlpt:        arg.Jlpt,
+		StrokeCount: arg.StrokeCount,
+		Frequency:   arg.Frequency,
+		Meanings:    arg.Meanings,
+		OnReadings:  arg.OnReadings,
+		KunReadings: arg.KunReadings,
+		StrokePaths: []string{},
+	}
+	return nil
+}
+
+func (m *kanjiMockQuerier) UpdateKanjiStrokePaths(ctx context.Context, arg database.UpdateKanjiStrokePathsParams) (int64, error) {
+	k, ok := m.kanji[arg.Literal]
+	if !ok {
+		return 0, nil
+	}
+	k.StrokePaths = arg.StrokePaths
+	m.kanji[arg.Literal] = k
+	return 1, nil
+}
+
+func (m *kanjiMockQuerier) GetKanji(ctx context.Context, literal string) (database.Kanji, error) {
+	k, ok := m.kanji[literal]
+	if !ok {
+		return database.Kanji{}, sql.ErrNoRows
+	}
+	return k, nil
+}
+
+func (m *kanjiMockQuerier) ListKanjiReadingsByJLPT(ctx context.Context, levels []int32) ([]database.ListKanjiReadingsByJLPTRow, error) {
+	var rows []database.ListKanjiReadingsByJLPTRow
+	for _, literal := range m.order {
+		k := m.kanji[literal]
+		for _, level := range levels {
+			if k.Jlpt.Valid && k.Jlpt.Int32 == level {
+				rows = append(rows, database.ListKanjiReadingsByJLPTRow{
+					Literal:     k.Literal,
+					Jlpt:        level,
+					OnReadings:  k.OnReadings,
+					KunReadings: k.KunReadings,
+				})
+			}
+		}
+	}
+	return rows, nil
+}
+
+const testKanjidic = `<kanjidic2>
+<character>
+<literal>日</literal>
+<misc><grade>1</grade><stroke_count>4</stroke_count><freq>1</freq><jlpt>4</jlpt></misc>
+<reading_meaning><rmgroup>
+<reading r_type="ja_on">ニチ</reading>
+<reading r_type="ja_kun">ひ</reading>
+<meaning>day</meaning>
+</rmgroup></reading_meaning>
+</character>
+<character>
+<literal>一</literal>
+<misc><grade>1</grade><stroke_count>1</stroke_count><freq>2</freq><jlpt>4</jlpt></misc>
+<reading_meaning><rmgroup>
+<reading r_type="ja_on">イチ</reading>
+<reading r_type="ja_kun">ひと.つ</reading>
+<meaning>one</meaning>
+</rmgroup></reading_meaning>
+</character>
+<character>
+<literal>亜</literal>
+<misc><grade>8</grade><stroke_count>7</stroke_count></misc>
+</character>
+</kanjidic2>`
+
+func TestKanjiService_Import(t *testing.T) {
+	db := newKanjiMockQuerier()
+	svc := NewKanjiService(&MockTxManager{db: db}, db)
+
+	kanjivg := fstest.MapFS{
+		"04e00.svg": {Data: []byte(`<svg><g><path d="M11,54.25c3.19,0.62,6.25,0.75,9.73,0.5"/></g></svg>`)},
+		"03042.svg": {Data: []byte(`<svg><g><path d="M1,1"/></g></svg>`)}, // あ isn't a kanji
+	}
+	result, err := svc.Import(context.Background(), strings.NewReader(testKanjidic), kanjivg)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if result.Kanji != 3 || result.StrokePaths != 1 {
+		t.Errorf("Expected 3 kanji and 1 with strokes, got %+v", result)
+	}
+
+	one, err := svc.Get(context.Background(), "一")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if one.JLPT != 4 || one.StrokeCount != 1 || len(one.StrokePaths) != 1 || one.Meanings[0] != "one" {
+		t.Errorf("Unexpected kanji %+v", one)
+	}
+	if a := db.kanji["亜"]; a.Jlpt.Valid || a.Frequency.Valid || a.OnReadings == nil {
+		t.Errorf("Expected missing fields to be NULL and empty readings non-nil, got %+v", a)
+	}
+	if _, err := svc.Get(context.Background(), "月"); !errors.Is(err, ErrKanjiNotFound) {
+		t.Errorf("Expected ErrKanjiNotFound, got %v", err)
+	}
+	if _, err := svc.ListByJLPT(context.Background(), 5, 10, 0); !errors.Is(err, ErrInvalidJLPTLevel) {
+		t.Errorf("Expected ErrInvalidJLPTLevel, got %v", err)
+	}
+}
+
+func TestPracticeService_RecordSessionWithKanji(t *testing.T) {
+	db := newKanjiMockQuerier()
+	if _, err := NewKanjiService(&MockTxManager{db: db}, db).Import(context.Background(), strings.NewReader(testKanjidic), nil); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	svc := NewPracticeService(&MockTxManager{db: db}, db, nil, nil, nil).(*practiceService)
+	now := time.Now()
+	response, err := svc.RecordSession(context.Background(), uuid.New(), dto.PracticeSessionRequest{
+		Groups:    []string{"kanji:jlpt4"},
+		StartedAt: now.Add(-time.Minute),
+		Answers: []dto.PracticeAnswerRequest{
+			{Kana: "日", Answer: "hi"},
+			{Kana: "一", Answer: "hitotsu"},
+			{Kana: "一", Answer: "ni"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RecordSession: %v", err)
+	}
+	if response.Correct != 2 {
+		t.Errorf("Expected any reading to be correct, got %+v", response)
+	}
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/league"
+	"github.com/Cadimodev/haiji/backend/internal/progression"
+	"github.com/google/uuid"
+)
+
+// XP sources that earn league points
+var leagueSources = []string{progression.SourcePractice, progression.SourceBattle}
+
+// LeagueEnroller is the part of LeagueService other services report activity
+// to.
+type LeagueEnroller interface {
+	// Enroll puts the user in a bucket of this week's league, if they
+	// aren't in one yet.
+	Enroll(ctx context.Context, userID uuid.UUID) error
+}
+
+type LeagueService interface {
+	LeagueEnroller
+
+	Get(ctx context.Context, userID uuid.UUID) (dto.LeagueResponse, error)
+	// CloseWeeks applies the promotions and relegations of every week that
+	// has ended and returns how many weeks it closed.
+	CloseWeeks(ctx context.Context) (int, error)
+	// RunScheduler closes weeks as they end, checking every interval.
+	RunScheduler(interval time.Duration)
+}
+
+type leagueService struct {
+	txManager database.TxManager
+	db        database.Querier
+	rules     league.Rules
+	now       func() time.Time
+}
+
+func NewLeagueService(txManager database.TxManager, db database.Querier, rules league.Rules) LeagueService {
+	return &leagueService{
+		txManager: txManager,
+		db:        db,
+		rules:     rules,
+		now:       time.Now,
+	}
+}
+
+func (s *leagueService) Enroll(ctx context.Context, userID uuid.UUID) error {
+	week := league.WeekStart(s.now())
+	_, err := s.db.GetLeagueMember(ctx, database.GetLeagueMemberParams{UserID: userID, WeekStart: week})
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	return s.txManager.ExecTx(ctx, func(qtx database.Querier) error {
+		tier, err := leagueTier(ctx, qtx, userID)
+		if err != nil {
+			return err
+		}
+		if err := qtx.CreateLeagueWeek(ctx, week); err != nil {
+			return err
+		}
+
+		// Fill the first bucket with room before opening a new one
+		bucket, err := qtx.FindOpenLeagueBucket(ctx, database.FindOpenLeagueBucketParams{
+			WeekStart:  week,
+			Tier:       tier,
+			BucketSize: int32(s.rules.BucketSize),
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			bucket, err = qtx.NextLeagueBucket(ctx, database.NextLeagueBucketParams{WeekStart: week, Tier: tier})
+		}
+		if err != nil {
+			return err
+		}
+
+		return qtx.JoinLeague(ctx, database.JoinLeagueParams{
+			UserID:    userID,
+			WeekStart: week,
+			Tier:      tier,
+			Bucket:    bucket,
+		})
+	})
+}
+
+func (s *leagueService) Get(ctx context.Context, userID uuid.UUID) (dto.LeagueResponse, error) {
+	week := league.WeekStart(s.now())
+	res := dto.LeagueResponse{
+		WeekStart: week,
+		EndsAt:    league.WeekEnd(week),
+		Promote:   s.rules.Promote,
+		Relegate:  s.rules.Relegate,
+		Standings: []dto.LeagueStanding{},
+	}
+
+	member, err := s.db.GetLeagueMember(ctx, database.GetLeagueMemberParams{UserID: userID, WeekStart: week})
+	if errors.Is(err, sql.ErrNoRows) {
+		tier, err := leagueTier(ctx, s.db, userID)
+		if err != nil {
+			return dto.LeagueResponse{}, err
+		}
+		res.Tier, res.TierName = int(tier), league.TierName(int(tier))
+		return res, nil
+	}
+	if err != nil {
+		return dto.LeagueResponse{}, err
+	}
+
+	rows, err := s.db.GetLeagueBucketStandings(ctx, database.GetLeagueBucketStandingsParams{
+		Sources:     leagueSources,
+		EarnedFrom:  week,
+		EarnedUntil: league.WeekEnd(week),
+		WeekStart:   week,
+		Tier:        member.Tier,
+		Bucket:      member.Bucket,
+	})
+	if err != nil {
+		return dto.LeagueResponse{}, err
+	}
+
+	res.Tier, res.TierName = int(member.Tier), league.TierName(int(member.Tier))
+	res.Enrolled = true
+	res.Bucket = int(member.Bucket)
+	for i, row := range rows {
+		res.Standings = append(res.Standings, dto.LeagueStanding{
+			Rank:     i + 1,
+			UserID:   row.UserID,
+			Username: row.Username,
+			Points:   row.Points,
+			Outcome:  string(s.rules.Outcome(i, len(rows), int(member.Tier), row.Points)),
+			IsMe:     row.UserID == userID,
+		})
+	}
+	return res, nil
+}
+
+func (s *leagueService) CloseWeeks(ctx context.Context) (int, error) {
+	weeks, err := s.db.ListOpenLeagueWeeks(ctx, league.WeekStart(s.now()))
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	for _, week := range weeks {
+		ok, err := s.closeWeek(ctx, week)
+		if err != nil {
+			return closed, err
+		}
+		if ok {
+			closed++
+		}
+	}
+	return closed, nil
+}
+
+// closeWeek ranks every bucket of the week and moves its players between
+// tiers. It reports false if another instance closed the week first.
+func (s *leagueService) closeWeek(ctx context.Context, week time.Time) (bool, error) {
+	closed := false
+	err := s.txManager.ExecTx(ctx, func(qtx database.Querier) error {
+		n, err := qtx.CloseLeagueWeek(ctx, week)
+		if err != nil || n == 0 {
+			return err
+		}
+		closed = true
+
+		rows, err := qtx.ListLeagueWeekStandings(ctx, database.ListLeagueWeekStandingsParams{
+			Sources:     leagueSources,
+			EarnedFrom:  week,
+			EarnedUntil: league.WeekEnd(week),
+			WeekStart:   week,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Rows come ordered by bucket, best first
+		for start := 0; start < len(rows); {
+			end := start
+			for end < len(rows) && rows[end].Tier == rows[start].Tier && rows[end].Bucket == rows[start].Bucket {
+				end++
+			}
+			for i, row := range rows[start:end] {
+				outcome := s.rules.Outcome(i, end-start, int(row.Tier), row.Points)
+				if err := qtx.SetLeagueOutcome(ctx, database.SetLeagueOutcomeParams{
+					UserID:    row.UserID,
+					WeekStart: week,
+					Points:    sql.NullInt64{Int64: row.Points, Valid: true},
+					Outcome:   sql.NullString{String: string(outcome), Valid: true},
+				}); err != nil {
+					return err
+				}
+				if err := qtx.SetLeagueTier(ctx, database.SetLeagueTierParams{
+					UserID: row.UserID,
+					Tier:   int32(league.NextTier(int(row.Tier), outcome)),
+				}); err != nil {
+					return err
+				}
+			}
+			start = end
+		}
+		return nil
+	})
+	return closed, err
+}
+
+func (s *leagueService) RunScheduler(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		closed, err := s.CloseWeeks(context.Background())
+		if err != nil {
+			slog.Error("Error closing league weeks", "error", err)
+		} else if closed > 0 {
+			slog.Info("Closed league weeks", "weeks", closed)
+		}
+		<-ticker.C
+	}
+}
+
+// leagueTier returns the tier the user plays this week in; new players
+// start at the bottom.
+func leagueTier(ctx context.Context, db database.Querier, userID uuid.UUID) (int32, error) {
+	tier, err := db.GetLeagueTier(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return tier, err
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/league"
+	"github.com/google/uuid"
+)
+
+type leagueMockQuerier struct {
+	database.Querier
+	tiers   map[uuid.UUID]int32
+	weeks   map[time.Time]bool // closed
+	members []database.LeagueMember
+	points  map[uuid.UUID]int64
+}
+
+func newLeagueMockQuerier() *leagueMockQuerier {
+	return &leagueMockQuerier{
+		tiers:  make(map[uuid.UUID]int32),
+		weeks:  make(map[time.Time]bool),
+		points: make(map[uuid.UUID]int64),
+	}
+}
+
+func (m *leagueMockQuerier) GetLeagueTier(ctx context.Context, userID uuid.UUID) (int32, error) {
+	tier, ok := m.tiers[userID]
+	if !ok {
+		return 0, sql.ErrNoRows
+	}
+	return tier, nil
+}
+
+func (m *leagueMockQuerier) SetLeagueTier(ctx context.Context, arg database.SetLeagueTierParams) error {
+	m.tiers[arg.UserID] = arg.Tier
+	return nil
+}
+
+func (m *leagueMockQuerier) CreateLeagueWeek(ctx context.Context, week time.Time) error {
+	if _, ok := m.weeks[week]; !ok {
+		m.weeks[week] = false
+	}
+	return nil
+}
+
+func (m *leagueMockQuerier) GetLeagueMember(ctx context.Context, arg database.GetLeagueMemberParams) (database.LeagueMember, error) {
+	for _, member := range m.members {
+		if member.UserID == arg.UserID && member.WeekStart.Equal(arg.WeekStart) {
+			return member, nil
+		}
+	}
+	return database.LeagueMember{}, sql.ErrNoRows
+}
+
+func (m *leagueMockQuerier) bucketSizes(week time.Time, tier int32) map[int32]int {
+	sizes := make(map[int32]int)
+	for _, member := range m.members {
+		if member.WeekStart.Equal(week) && member.Tier == tier {
+			sizes[member.Bucket]++
+		}
+	}
+	return sizes
+}
+
+func (m *leagueMockQuerier) FindOpenLeagueBucket(ctx context.Context, arg database.FindOpenLeagueBucketParams) (int32, error) {
+	best := int32(0)
+	for bucket, size := range m.bucketSizes(arg.WeekStart, arg.Tier) {
+		if size < int(arg.BucketSize) && (best == 0 || bucket < best) {
+			best = bucket
+		}
+	}
+	if best == 0 {
+		return 0, sql.ErrNoRows
+	}
+	return best, nil
+}
+
+func (m *leagueMockQuerier) NextLeagueBucket(ctx context.Context, arg database.NextLeagueBucketParams) (int32, error) {
+	next := int32(1)
+	for bucket := range m.bucketSizes(arg.WeekStart, arg.Tier) {
+		next = max(next, bucket+1)
+	}
+	return next, nil
+}
+
+func (m *leagueMockQuerier) JoinLeague(ctx context.Context, arg database.JoinLeagueParams) error {
+	m.members = append(m.members, database.LeagueMember{
+		UserID:    arg.UserID,
+		WeekStart: arg.WeekStart,
+		Tier:      arg.Tier,
+		Bucket:    arg.Bucket,
+		JoinedAt:  time.Unix(int64(len(m.members)), 0),
+	})
+	return nil
+}
+
+func (m *leagueMockQuerier) standings(week time.Time) []database.LeagueMember {
+	var rows []database.LeagueMember
+	for _, member := range m.members {
+		if member.WeekStart.Equal(week) {
+			rows = append(rows, member)
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.Tier != b.Tier {
+			return a.Tier < b.Tier
+		}
+		if a.Bucket != b.Bucket {
+			return a.Bucket < b.Bucket
+		}
+		return m.points[a.UserID] > m.points[b.UserID]
+	})
+	return rows
+}
+
+func (m *leagueMockQuerier) GetLeagueBucketStandings(ctx context.Context, arg database.GetLeagueBucketStandingsParams) ([]database.GetLeagueBucketStandingsRow, error) {
+	var rows []database.GetLeagueBucketStandingsRow
+	for _, member := range m.standings(arg.WeekStart) {
+		if member.Tier == arg.Tier && member.Bucket == arg.Bucket {
+			rows = append(rows, database.GetLeagueBucketStandingsRow{UserID: member.UserID, Username: member.UserID.String()[:8], Points: m.points[member.UserID]})
+		}
+	}
+	return rows, nil
+}
+
+func (m *leagueMockQuerier) ListOpenLeagueWeeks(ctx context.Context, before time.Time) ([]time.Time, error) {
+	var weeks []time.Time
+	for week, closed := range m.weeks {
+		if !closed && week.Before(before) {
+			weeks = append(weeks, week)
+		}
+	}
+	return weeks, nil
+}
+
+func (m *leagueMockQuerier) CloseLeagueWeek(ctx context.Context, week time.Time) (int64, error) {
+	if closed, ok := m.weeks[week]; !ok || closed {
+		return 0, nil
+	}
+	m.weeks[week] = true
+	return 1, nil
+}
+
+func (m *leagueMockQuerier) ListLeagueWeekStandings(ctx context.Context, arg database.ListLeagueWeekStandingsParams) ([]database.ListLeagueWeekStandingsRow, error) {
+	var rows []database.ListLeagueWeekStandingsRow
+	for _, member := range m.standings(arg.WeekStart) {
+		rows = append(rows, database.ListLeagueWeekStandingsRow{UserID: member.UserID, Tier: member.Tier, Bucket: member.Bucket, Points: m.points[member.UserID]})
+	}
+	return rows, nil
+}
+
+func (m *leagueMockQuerier) SetLeagueOutcome(ctx context.Context, arg database.SetLeagueOutcomeParams) error {
+	for i, member := range m.members {
+		if member.UserID == arg.UserID && member.WeekStart.Equal(arg.WeekStart) {
+			m.members[i].Points, m.members[i].Outcome = arg.Points, arg.Outcome
+		}
+	}
+	return nil
+}
+
+func TestLeagueService_EnrollFillsBuckets(t *testing.T) {
+	db := newLeagueMockQuerier()
+	svc := NewLeagueService(&MockTxManager{db: db}, db, league.Rules{BucketSize: 2, Promote: 1, Relegate: 1}).(*leagueService)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 21, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	users := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	for _, id := range users {
+		if err := svc.Enroll(ctx, id); err != nil {
+			t.Fatalf("Enroll: %v", err)
+		}
+	}
+	// Enrolling twice keeps the user's bucket
+	if err := svc.Enroll(ctx, users[0]); err != nil {
+		t.Fatalf("Enroll: %v", err)
+	}
+	if len(db.members) != 3 || db.members[1].Bucket != 1 || db.members[2].Bucket != 2 {
+		t.Fatalf("Expected buckets 1, 1, 2, got %+v", db.members)
+	}
+
+	db.points[users[0]], db.points[users[1]] = 10, 50
+	res, err := svc.Get(ctx, users[0])
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !res.Enrolled || res.Bucket != 1 || res.TierName != "Bronze" || !res.WeekStart.Equal(league.WeekStart(now)) {
+		t.Errorf("Unexpected league %+v", res)
+	}
+	if len(res.Standings) != 2 || res.Standings[0].UserID != users[1] || !res.Standings[1].IsMe {
+		t.Fatalf("Unexpected standings %+v", res.Standings)
+	}
+	if res.Standings[0].Outcome != string(league.OutcomePromoted) || res.Standings[1].Outcome != string(league.OutcomeStayed) {
+		t.Errorf("Expected the leader promoted and nobody relegated from Bronze, got %+v", res.Standings)
+	}
+
+	stranger, err := svc.Get(ctx, uuid.New())
+	if err != nil || stranger.Enrolled || len(stranger.Standings) != 0 {
+		t.Errorf("Expected an empty league for a user without points, got %+v, %v", stranger, err)
+	}
+}
+
+func TestLeagueService_CloseWeeks(t *testing.T) {
+	db := newLeagueMockQuerier()
+	svc := NewLeagueService(&MockTxManager{db: db}, db, league.Rules{BucketSize: 30, Promote: 1, Relegate: 1}).(*leagueService)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 21, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	top, middle, bottom := uuid.New(), uuid.New(), uuid.New()
+	for _, id := range []uuid.UUID{top, middle, bottom} {
+		db.tiers[id] = 2
+		if err := svc.Enroll(ctx, id); err != nil {
+			t.Fatalf("Enroll: %v", err)
+		}
+	}
+	db.points[top], db.points[middle], db.points[bottom] = 300, 200, 100
+
+	// The week isn't over yet
+	if closed, err := svc.CloseWeeks(ctx); err != nil || closed != 0 {
+		t.Fatalf("Expected nothing to close, got %d, %v", closed, err)
+	}
+
+	now = now.AddDate(0, 0, 7)
+	if closed, err := svc.CloseWeeks(ctx); err != nil || closed != 1 {
+		t.Fatalf("Expected one week closed, got %d, %v", closed, err)
+	}
+	if db.tiers[top] != 3 || db.tiers[middle] != 2 || db.tiers[bottom] != 1 {
+		t.Errorf("Unexpected tiers %v", db.tiers)
+	}
+	for _, m := range db.members {
+		if !m.Outcome.Valid || m.Points.Int64 != db.points[m.UserID] {
+			t.Errorf("Expected the final standing stored, got %+v", m)
+		}
+	}
+
+	// Closing is idempotent
+	if closed, err := svc.CloseWeeks(ctx); err != nil || closed != 0 {
+		t.Errorf("Expected nothing left to close, got %d, %v", closed, err)
+	}
+
+	// Next week, players start from their new tier
+	if err := svc.Enroll(ctx, top); err != nil {
+		t.Fatalf("Enroll: %v", err)
+	}
+	if res, _ := svc.Get(ctx, top); res.Tier != 3 {
+		t.Errorf("Expected tier 3, got %d", res.Tier)
+	}
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/achievements"
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/handwriting"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/google/uuid"
+)
+
+// Sessions are posted when they end; older start times are rejected.
+const maxSessionLength = 24 * time.Hour
+
+var (
+	ErrInvalidSessionTime   = errors.New("started_at must be within the last 24 hours")
+	ErrInvalidAnswerTime    = errors.New("offset_ms must place the answer between started_at and now")
+	ErrHandwritingDirection = errors.New("drawn answers need a direction answered in kana")
+)
+
+type PracticeService interface {
+	RecordSession(ctx context.Context, userID uuid.UUID, params dto.PracticeSessionRequest) (dto.PracticeSessionResponse, error)
+	CharacterStats(ctx context.Context, userID uuid.UUID, since time.Time) ([]dto.CharacterStat, error)
+	Confusions(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]dto.Confusion, error)
+	Activity(ctx context.Context, userID uuid.UUID, since time.Time, loc *time.Location) ([]dto.ActivityDay, error)
+}
+
+type practiceService struct {
+	txManager    database.TxManager
+	db           database.Querier
+	achievements AchievementRecorder
+	xp           XPRecorder
+	curriculum   CurriculumRecorder
+	now          func() time.Time
+}
+
+func NewPracticeService(txManager database.TxManager, db database.Querier, achievements AchievementRecorder, xp XPRecorder, curriculum CurriculumRecorder) PracticeService {
+	return &practiceService{
+		txManager:    txManager,
+		db:           db,
+		achievements: achievements,
+		xp:           xp,
+		curriculum:   curriculum,
+		now:          time.Now,
+	}
+}
+
+func (s *practiceService) RecordSession(ctx context.Context, userID uuid.UUID, params dto.PracticeSessionRequest) (dto.PracticeSessionResponse, error) {
+	now := s.now()
+	if params.StartedAt.After(now) || now.Sub(params.StartedAt) > maxSessionLength {
+		return dto.PracticeSessionResponse{}, ErrInvalidSessionTime
+	}
+	direction, err := kana.ParseDirection(params.Direction)
+	if err != nil {
+		return dto.PracticeSessionResponse{}, err
+	}
+
+	// Deck entries take precedence over the catalog for their prompts
+	decks, err := loadGroupEntries(ctx, s.db, params.Groups)
+	if err != nil {
+		return dto.PracticeSessionResponse{}, err
+	}
+	deckChars := make(map[string]kana.Char)
+	for _, entries := range decks {
+		for _, c := range entries {
+			deckChars[c.Kana] = c
+		}
+	}
+	systems, err := userRomajiSystems(ctx, s.db, userID)
+	if err != nil {
+		return dto.PracticeSessionResponse{}, err
+	}
+
+	// Expected answers and correctness are decided here, not by the client
+	n := len(params.Answers)
+	answers := database.CreatePracticeAnswersParams{
+		UserID:     userID,
+		Kana:       make([]string, n),
+		Expected:   make([]string, n),
+		Given:      make([]string, n),
+		Correct:    make([]bool, n),
+		LatencyMs:  make([]int32, n),
+		AnsweredAt: make([]time.Time, n),
+	}
+	correct := 0
+	for i, a := range params.Answers {
+		c, ok := deckChars[a.Kana]
+		if !ok {
+			c, ok = kana.Lookup(a.Kana)
+		}
+		if !ok {
+			return dto.PracticeSessionResponse{}, fmt.Errorf("%w: %s", ErrUnknownKana, a.Kana)
+		}
+		answers.Kana[i] = c.Kana
+		answers.Expected[i] = direction.Expected(c)
+		if len(a.Strokes) > 0 {
+			if !direction.AnswersInKana() {
+				return dto.PracticeSessionResponse{}, ErrHandwritingDirection
+			}
+			best, err := handwriting.Best(a.Strokes, c.Kana)
+			if err != nil {
+				return dto.PracticeSessionResponse{}, fmt.Errorf("%w: %s", err, c.Kana)
+			}
+			answers.Given[i] = best.Kana
+			answers.Correct[i] = best.Kana == c.Kana && best.Confidence >= handwriting.AcceptConfidence
+		} else {
+			answers.Given[i] = strings.ToLower(strings.TrimSpace(a.Answer))
+			answers.Correct[i] = direction.IsCorrect(c, a.Answer, systems...)
+		}
+		answers.LatencyMs[i] = int32(a.LatencyMs)
+		answers.AnsweredAt[i] = params.StartedAt.Add(time.Duration(a.OffsetMs) * time.Millisecond)
+		if answers.AnsweredAt[i].After(now) {
+			return dto.PracticeSessionResponse{}, ErrInvalidAnswerTime
+		}
+		if answers.Correct[i] {
+			correct++
+		}
+	}
+
+	groups := params.Groups
+	if groups == nil {
+		groups = []string{}
+	}
+
+	var session database.PracticeSession
+	err = s.txManager.ExecTx(ctx, func(qtx database.Querier) error {
+		var err error
+		session, err = qtx.CreatePracticeSession(ctx, database.CreatePracticeSessionParams{
+			UserID:     userID,
+			Groups:     groups,
+			StartedAt:  params.StartedAt,
+			FinishedAt: now,
+			Direction:  string(direction),
+		})
+		if err != nil {
+			return err
+		}
+
+		answers.SessionID = session.ID
+		return qtx.CreatePracticeAnswers(ctx, answers)
+	})
+	if err != nil {
+		return dto.PracticeSessionResponse{}, err
+	}
+
+	if s.achievements != nil {
+		recorded := make([]achievements.Answer, n)
+		for i := range recorded {
+			recorded[i] = achievements.Answer{Kana: answers.Kana[i], Correct: answers.Correct[i]}
+		}
+		// The session is saved; a failure here only delays achievements
+		if err := s.achievements.RecordPractice(ctx, userID, recorded); err != nil {
+			slog.Error("Error recording practice achievements", "error", err, "user", userID)
+		}
+	}
+	if s.xp != nil {
+		if err := s.xp.RecordPractice(ctx, userID, session.ID, correct, n); err != nil {
+			slog.Error("Error awarding practice XP", "error", err, "user", userID)
+		}
+	}
+	var unlocked []string
+	if s.curriculum != nil {
+		unlocked, err = s.curriculum.RecordPractice(ctx, userID)
+		if err != nil {
+			slog.Error("Error advancing curriculum", "error", err, "user", userID)
+		}
+	}
+
+	return dto.PracticeSessionResponse{
+		ID:       session.ID,
+		Total:    n,
+		Correct:  correct,
+		Accuracy: float64(correct) / float64(n),
+		Unlocked: unlocked,
+	}, nil
+}
+
+func (s *practiceService) CharacterStats(ctx context.Context, userID uuid.UUID, since time.Time) ([]dto.CharacterStat, error) {
+	rows, err := s.db.GetCharacterStats(ctx, database.GetCharacterStatsParams{
+		UserID:     userID,
+		AnsweredAt: since,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]dto.CharacterStat, len(rows))
+	for i, row := range rows {
+		c, _ := kana.Lookup(row.Kana)
+		stats[i] = dto.CharacterStat{
+			Kana:            row.Kana,
+			Romanji:         c.Romanji,
+			Attempts:        int(row.Attempts),
+			Correct:         int(row.Correct),
+			Accuracy:        float64(row.Correct) / float64(row.Attempts),
+			MedianLatencyMs: int(math.Round(row.MedianLatencyMs)),
+		}
+	}
+	return stats, nil
+}
+
+func (s *practiceService) Confusions(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]dto.Confusion, error) {
+	rows, err := s.db.GetConfusionMatrix(ctx, database.GetConfusionMatrixParams{
+		UserID:     userID,
+		AnsweredAt: since,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	confusions := make([]dto.Confusion, len(rows))
+	for i, row := range rows {
+		confusions[i] = dto.Confusion{
+			Kana:     row.Kana,
+			Expected: row.Expected,
+			Given:    row.Given,
+			Count:    int(row.Count),
+		}
+	}
+	return confusions, nil
+}
+
+func (s *practiceService) Activity(ctx context.Context, userID uuid.UUID, since time.Time, loc *time.Location) ([]dto.ActivityDay, error) {
+	rows, err := s.db.GetActivityHeatmap(ctx, database.GetActivityHeatmapParams{
+		TimeZone: loc.String(),
+		UserID:   userID,
+		Since:    since,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]dto.ActivityDay, len(rows))
+	for i, row := range rows {
+		days[i] = dto.ActivityDay{
+			Date:    row.Day.Format("2006-01-02"),
+			Answers: int(row.Answers),
+			Correct: int(row.Correct),
+		}
+	}
+	return days, nil
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/handwriting"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/google/uuid"
+)
+
+type practiceMockQuerier struct {
+	database.Querier
+	sessions      []database.CreatePracticeSessionParams
+	answers       []database.CreatePracticeAnswersParams
+	romajiSystems []string
+}
+
+func (m *practiceMockQuerier) GetUserByID(ctx context.Context, id uuid.UUID) (database.User, error) {
+	return database.User{ID: id, RomajiSystems: m.romajiSystems}, nil
+}
+
+func (m *practiceMockQuerier) CreatePracticeSession(ctx context.Context, arg database.CreatePracticeSessionParams) (database.PracticeSession, error) {
+	m.sessions = append(m.sessions, arg)
+	return database.PracticeSession{ID: uuid.New(), UserID: arg.UserID, Groups: arg.Groups, StartedAt: arg.StartedAt, FinishedAt: arg.FinishedAt}, nil
+}
+
+func (m *practiceMockQuerier) CreatePracticeAnswers(ctx context.Context, arg database.CreatePracticeAnswersParams) error {
+	m.answers = append(m.answers, arg)
+	return nil
+}
+
+func (m *practiceMockQuerier) GetCharacterStats(ctx context.Context, arg database.GetCharacterStatsParams) ([]database.GetCharacterStatsRow, error) {
+	return []database.GetCharacterStatsRow{
+		{Kana: "シ", Attempts: 4, Correct: 1, MedianLatencyMs: 1840.5},
+	}, nil
+}
+
+func TestPracticeService_RecordSession(t *testing.T) {
+	db := &practiceMockQuerier{}
+	svc := NewPracticeService(&MockTxManager{db: db}, db, nil, nil, nil).(*practiceService)
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	userID := uuid.New()
+
+	response, err := svc.RecordSession(context.Background(), userID, dto.PracticeSessionRequest{
+		StartedAt: now.Add(-5 * time.Minute),
+		Answers: []dto.PracticeAnswerRequest{
+			{Kana: "シ", Answer: " TSU ", LatencyMs: 2100, OffsetMs: 2100},
+			{Kana: "ツ", Answer: "tsu", LatencyMs: 900, OffsetMs: 3000},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RecordSession: %v", err)
+	}
+	if response.Total != 2 || response.Correct != 1 || response.Accuracy != 0.5 {
+		t.Errorf("Unexpected summary: %+v", response)
+	}
+
+	if len(db.answers) != 1 {
+		t.Fatalf("Expected one batch insert, got %d", len(db.answers))
+	}
+	a := db.answers[0]
+	if a.Expected[0] != "shi" || a.Given[0] != "tsu" || a.Correct[0] {
+		t.Errorf("Expected シ answered as tsu to be recorded as wrong, got %q/%q/%v", a.Expected[0], a.Given[0], a.Correct[0])
+	}
+	if !a.Correct[1] || a.LatencyMs[1] != 900 {
+		t.Errorf("Expected ツ to be correct with 900ms, got %v/%d", a.Correct[1], a.LatencyMs[1])
+	}
+	if want := now.Add(-5*time.Minute + 3*time.Second); !a.AnsweredAt[1].Equal(want) {
+		t.Errorf("Expected ツ answered at %v, got %v", want, a.AnsweredAt[1])
+	}
+	if db.sessions[0].Groups == nil {
+		t.Error("Groups should default to an empty array")
+	}
+}
+
+func TestPracticeService_RecordSessionUsesRomajiSystems(t *testing.T) {
+	answers := []dto.PracticeAnswerRequest{
+		{Kana: "し", Answer: "si"},
+		{Kana: "ちゃ", Answer: "tya"},
+		{Kana: "つ", Answer: "tsu"},
+	}
+
+	tests := []struct {
+		systems []string
+		correct int
+	}{
+		{[]string{"hepburn", "kunrei", "nihon"}, 3},
+		{[]string{"kunrei"}, 3},
+		{[]string{"hepburn"}, 1},
+	}
+	for _, tt := range tests {
+		db := &practiceMockQuerier{romajiSystems: tt.systems}
+		svc := NewPracticeService(&MockTxManager{db: db}, db, nil, nil, nil)
+		response, err := svc.RecordSession(context.Background(), uuid.New(), dto.PracticeSessionRequest{
+			StartedAt: time.Now().Add(-time.Minute),
+			Answers:   answers,
+		})
+		if err != nil {
+			t.Fatalf("%v: RecordSession: %v", tt.systems, err)
+		}
+		if response.Correct != tt.correct {
+			t.Errorf("%v: expected %d correct, got %d", tt.systems, tt.correct, response.Correct)
+		}
+	}
+}
+
+func TestPracticeService_RecordSessionToKana(t *testing.T) {
+	db := &practiceMockQuerier{}
+	svc := NewPracticeService(&MockTxManager{db: db}, db, nil, nil, nil)
+
+	response, err := svc.RecordSession(context.Background(), uuid.New(), dto.PracticeSessionRequest{
+		Direction: "romaji_to_kana",
+		StartedAt: time.Now().Add(-time.Minute),
+		Answers: []dto.PracticeAnswerRequest{
+			{Kana: "シ", Answer: "shi"},
+			{Kana: "つ", Answer: "つ"},
+			{Kana: "ツ", Answer: "つ"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RecordSession: %v", err)
+	}
+	if response.Correct != 2 {
+		t.Errorf("Expected 2 correct, got %+v", response)
+	}
+	if a := db.answers[0]; a.Expected[0] != "シ" || db.sessions[0].Direction != "romaji_to_kana" {
+		t.Errorf("Expected kana answers in a romaji_to_kana session, got %q/%q", a.Expected[0], db.sessions[0].Direction)
+	}
+
+	_, err = svc.RecordSession(context.Background(), uuid.New(), dto.PracticeSessionRequest{
+		Direction: "sideways",
+		StartedAt: time.Now().Add(-time.Minute),
+		Answers:   []dto.PracticeAnswerRequest{{Kana: "あ", Answer: "a"}},
+	})
+	if !errors.Is(err, kana.ErrUnknownDirection) {
+		t.Errorf("Expected ErrUnknownDirection, got %v", err)
+	}
+}
+
+func TestPracticeService_RecordSessionHandwriting(t *testing.T) {
+	db := &practiceMockQuerier{}
+	svc := NewPracticeService(&MockTxManager{db: db}, db, nil, nil, nil)
+	no := []handwriting.Stroke{{{X: 75, Y: 15}, {X: 55, Y: 60}, {X: 20, Y: 88}}}
+
+	response, err := svc.RecordSession(context.Background(), uuid.New(), dto.PracticeSessionRequest{
+		Direction: "romaji_to_kana",
+		StartedAt: time.Now().Add(-time.Minute),
+		Answers: []dto.PracticeAnswerRequest{
+			{Kana: "ノ", Strokes: no},
+			{Kana: "ニ", Strokes: no},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RecordSession: %v", err)
+	}
+	if a := db.answers[0]; response.Correct != 1 || !a.Correct[0] || a.Given[1] != "ノ" {
+		t.Errorf("Expected ノ recognized for both drawings, got %+v", a)
+	}
+
+	_, err = svc.RecordSession(context.Background(), uuid.New(), dto.PracticeSessionRequest{
+		StartedAt: time.Now().Add(-time.Minute),
+		Answers:   []dto.PracticeAnswerRequest{{Kana: "ノ", Strokes: no}},
+	})
+	if !errors.Is(err, ErrHandwritingDirection) {
+		t.Errorf("Expected ErrHandwritingDirection, got %v", err)
+	}
+}
+
+func TestPracticeService_RecordSessionRejects(t *testing.T) {
+	db := &practiceMockQuerier{}
+	svc := NewPracticeService(&MockTxManager{db: db}, db, nil, nil, nil)
+	now := time.Now()
+
+	_, err := svc.RecordSession(context.Background(), uuid.New(), dto.PracticeSessionRequest{
+		StartedAt: now.Add(-time.Minute),
+		Answers:   []dto.PracticeAnswerRequest{{Kana: "?", Answer: "a"}},
+	})
+	if !errors.Is(err, ErrUnknownKana) {
+		t.Errorf("Expected ErrUnknownKana, got %v", err)
+	}
+
+	_, err = svc.RecordSession(context.Background(), uuid.New(), dto.PracticeSessionRequest{
+		StartedAt: now.Add(time.Hour),
+		Answers:   []dto.PracticeAnswerRequest{{Kana: "あ", Answer: "a"}},
+	})
+	if !errors.Is(err, ErrInvalidSessionTime) {
+		t.Errorf("Expected ErrInvalidSessionTime for a future start, got %v", err)
+	}
+
+	_, err = svc.RecordSession(context.Background(), uuid.New(), dto.PracticeSessionRequest{
+		StartedAt: now.Add(-time.Minute),
+		Answers:   []dto.PracticeAnswerRequest{{Kana: "あ", Answer: "a", OffsetMs: int64(time.Hour / time.Millisecond)}},
+	})
+	if !errors.Is(err, ErrInvalidAnswerTime) {
+		t.Errorf("Expected ErrInvalidAnswerTime for an answer after now, got %v", err)
+	}
+
+	if len(db.sessions) != 0 {
+		t.Error("Rejected sessions must not be stored")
+	}
+}
+
+func TestPracticeService_CharacterStats(t *testing.T) {
+	db := &practiceMockQuerier{}
+	svc := NewPracticeService(&MockTxManager{db: db}, db, nil, nil, nil)
+
+	stats, err := svc.CharacterStats(context.Background(), uuid.New(), time.Now().AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("CharacterStats: %v", err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("Expected 1 stat, got %d", len(stats))
+	}
+	if s := stats[0]; s.Romanji != "shi" || s.Accuracy != 0.25 || s.MedianLatencyMs != 1841 {
+		t.Errorf("Unexpected stat: %+v", s)
+	}
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/Cadimodev/haiji/backend/internal/progression"
+	"github.com/Cadimodev/haiji/backend/internal/romaji"
+	"github.com/Cadimodev/haiji/backend/internal/shop"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidTimeZone     = errors.New("time_zone must be an IANA time zone name, e.g. Europe/Madrid")
+	ErrInvalidRomajiSystem = errors.New("romaji_systems must list at least one of hepburn, kunrei and nihon")
+)
+
+// XPRecorder is the part of ProgressionService other services report
+// activity to.
+type XPRecorder interface {
+	RecordPractice(ctx context.Context, userID, sessionID uuid.UUID, correct, total int) error
+}
+
+type ProgressionService interface {
+	XPRecorder
+	// Battles earn XP as the hub reports finished games
+	game.ResultStore
+
+	UpdateSettings(ctx context.Context, userID uuid.UUID, params dto.UpdateSettingsRequest) (dto.UserResponse, error)
+}
+
+type progressionService struct {
+	txManager    database.TxManager
+	db           database.Querier
+	curve        progression.Curve
+	notifier     UserNotifier
+	leagues      LeagueEnroller
+	achievements AchievementRecorder
+	now          func() time.Time
+}
+
+func NewProgressionService(txManager database.TxManager, db database.Querier, curve progression.Curve, notifier UserNotifier, leagues LeagueEnroller, achievements AchievementRecorder) ProgressionService {
+	return &progressionService{
+		txManager:    txManager,
+		db:           db,
+		curve:        curve,
+		notifier:     notifier,
+		leagues:      leagues,
+		achievements: achievements,
+		now:          time.Now,
+	}
+}
+
+func (s *progressionService) RecordPractice(ctx context.Context, userID, sessionID uuid.UUID, correct, total int) error {
+	return s.award(ctx, userID, progression.PracticeXP(correct, total), 0, progression.SourcePractice, sessionID.String())
+}
+
+// SaveRun pays for battles against other people. Solo games, ghost races,
+// presenter quizzes and raids earn nothing here, so they can't be replayed
+// for XP and coins.
+func (s *progressionService) SaveRun(ctx context.Context, run game.RecordedRun) error {
+	if run.Mode != game.ModeBattle || run.Players < 2 {
+		return nil
+	}
+	xp := progression.BattleXP(run.Placement, run.Players)
+	return s.award(ctx, run.UserID, xp, shop.BattleCoins(run.Placement, run.Players), progression.SourceBattle, run.RoomCode)
+}
+
+// SaveGhostResult is a no-op: a ghost isn't an opponent, so ghost races earn
+// nothing.
+func (s *progressionService) SaveGhostResult(ctx context.Context, result game.GhostResult) error {
+	return nil
+}
+
+// award appends to the XP ledger and updates the user's total and streak in
+// the same transaction, so the total always matches the ledger. Coins for
+// the activity and for a streak day kept are paid in the same transaction.
+func (s *progressionService) award(ctx context.Context, userID uuid.UUID, amount, coins int64, source, reference string) error {
+	if amount <= 0 {
+		return nil
+	}
+
+	var before, after progression.Level
+	var streak progression.Streak
+	var newDay bool
+	err := s.txManager.ExecTx(ctx, func(qtx database.Querier) error {
+		user, err := qtx.GetUserForUpdate(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := qtx.CreateXPLedgerEntry(ctx, database.CreateXPLedgerEntryParams{
+			UserID:    userID,
+			Amount:    int32(amount),
+			Source:    source,
+			Reference: reference,
+		}); err != nil {
+			return err
+		}
+
+		today := progression.Day(s.now(), progression.Location(user.TimeZone))
+		streak = userStreak(user).Extend(today)
+		xp := user.Xp + amount
+		before, after = s.curve.Level(user.Xp), s.curve.Level(xp)
+
+		if err := rewardCoins(ctx, qtx, userID, coins, shop.TransferBattle, reference); err != nil {
+			return err
+		}
+		// The first activity of the day keeps the streak going
+		newDay = !streak.LastDay.Equal(user.StreakLastDay.Time)
+		if newDay {
+			day := streak.LastDay.Format(time.DateOnly)
+			if err := rewardCoins(ctx, qtx, userID, shop.StreakCoins(streak.Current), shop.TransferStreak, day); err != nil {
+				return err
+			}
+		}
+
+		return qtx.UpdateUserProgress(ctx, database.UpdateUserProgressParams{
+			ID:            userID,
+			Xp:            xp,
+			StreakCurrent: int32(streak.Current),
+			StreakLongest: int32(streak.Longest),
+			StreakLastDay: sql.NullTime{Time: streak.LastDay, Valid: true},
+			StreakFreezes: int32(streak.Freezes),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if after.Level > before.Level {
+		slog.Info("Level up", "user", userID, "level", after.Level)
+		s.notifyLevelUp(userID, after.Level)
+	}
+
+	// Streak achievements are counted from this streak; the XP is kept even
+	// if recording them fails
+	if newDay && s.achievements != nil {
+		if err := s.achievements.RecordStreak(ctx, userID, streak.Longest); err != nil {
+			slog.Error("Error recording streak achievements", "error", err, "user", userID)
+		}
+	}
+
+	// League points are counted from the ledger; the XP is kept even if
+	// joining the week's league fails
+	if s.leagues != nil {
+		if err := s.leagues.Enroll(ctx, userID); err != nil {
+			slog.Error("Error enrolling in league", "error", err, "user", userID)
+		}
+	}
+	return nil
+}
+
+func (s *progressionService) notifyLevelUp(userID uuid.UUID, level int) {
+	if s.notifier == nil {
+		return
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"type":  "LEVEL_UP",
+		"level": level,
+	})
+	if err != nil {
+		slog.Error("Error marshalling level up", "error", err)
+		return
+	}
+	s.notifier.NotifyUser(userID, payload)
+}
+
+func (s *progressionService) UpdateSettings(ctx context.Context, userID uuid.UUID, params dto.UpdateSettingsRequest) (dto.UserResponse, error) {
+	update := database.UpdateUserSettingsParams{ID: userID}
+
+	if params.TimeZone != nil {
+		timeZone := *params.TimeZone
+		// "Local" is the server's zone, not the user's
+		if timeZone == "" || timeZone == "Local" {
+			return dto.UserResponse{}, ErrInvalidTimeZone
+		}
+		if _, err := time.LoadLocation(timeZone); err != nil {
+			return dto.UserResponse{}, ErrInvalidTimeZone
+		}
+		update.TimeZone = sql.NullString{String: timeZone, Valid: true}
+	}
+
+	if params.RomajiSystems != nil {
+		systems, err := romaji.ParseSystems(params.RomajiSystems)
+		if err != nil || len(systems) == 0 {
+			return dto.UserResponse{}, ErrInvalidRomajiSystem
+		}
+		for _, system := range systems {
+			update.RomajiSystems = append(update.RomajiSystems, string(system))
+		}
+	}
+
+	user, err := s.db.UpdateUserSettings(ctx, update)
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+	return userToResponse(user, s.curve, s.now()), nil
+}
+
+// userRomajiSystems returns the romanization systems the user's answers are
+// graded against.
+func userRomajiSystems(ctx context.Context, db database.Querier, userID uuid.UUID) ([]romaji.System, error) {
+	user, err := db.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return romaji.ParseSystems(user.RomajiSystems)
+}
+
+func userStreak(u database.User) progression.Streak {
+	return progression.Streak{
+		Current: int(u.StreakCurrent),
+		Longest: int(u.StreakLongest),
+		LastDay: u.StreakLastDay.Time,
+		Freezes: int(u.StreakFreezes),
+	}
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/Cadimodev/haiji/backend/internal/progression"
+	"github.com/Cadimodev/haiji/backend/internal/shop"
+	"github.com/google/uuid"
+)
+
+type progressionMockQuerier struct {
+	coinMockQuerier
+	user   database.User
+	ledger []database.CreateXPLedgerEntryParams
+}
+
+func (m *progressionMockQuerier) GetUserForUpdate(ctx context.Context, id uuid.UUID) (database.User, error) {
+	return m.user, nil
+}
+
+func (m *progressionMockQuerier) CreateXPLedgerEntry(ctx context.Context, arg database.CreateXPLedgerEntryParams) error {
+	m.ledger = append(m.ledger, arg)
+	return nil
+}
+
+func (m *progressionMockQuerier) UpdateUserProgress(ctx context.Context, arg database.UpdateUserProgressParams) error {
+	m.user.Xp = arg.Xp
+	m.user.StreakCurrent = arg.StreakCurrent
+	m.user.StreakLongest = arg.StreakLongest
+	m.user.StreakLastDay = arg.StreakLastDay
+	m.user.StreakFreezes = arg.StreakFreezes
+	return nil
+}
+
+func (m *progressionMockQuerier) UpdateUserSettings(ctx context.Context, arg database.UpdateUserSettingsParams) (database.User, error) {
+	if arg.TimeZone.Valid {
+		m.user.TimeZone = arg.TimeZone.String
+	}
+	if arg.RomajiSystems != nil {
+		m.user.RomajiSystems = arg.RomajiSystems
+	}
+	return m.user, nil
+}
+
+func newProgressionTest(notifier UserNotifier) (*progressionMockQuerier, *progressionService) {
+	db := &progressionMockQuerier{coinMockQuerier: newCoinMockQuerier(), user: database.User{ID: uuid.New(), TimeZone: "UTC"}}
+	curve := progression.Curve{Base: 100, Exponent: 1}
+	svc := NewProgressionService(&MockTxManager{db: db}, db, curve, notifier, nil, nil).(*progressionService)
+	return db, svc
+}
+
+func TestProgressionService_AwardsXPAndLevelsUp(t *testing.T) {
+	notifier := &mockNotifier{}
+	db, svc := newProgressionTest(notifier)
+	ctx := context.Background()
+
+	// 30 correct answers: 90 XP, still level 1
+	if err := svc.RecordPractice(ctx, db.user.ID, uuid.New(), 30, 30); err != nil {
+		t.Fatalf("RecordPractice: %v", err)
+	}
+	if db.user.Xp != 90 || len(notifier.messages) != 0 {
+		t.Fatalf("Expected 90 XP without a level up, got %d and %v", db.user.Xp, notifier.messages)
+	}
+
+	// Runs that weren't battles against other people pay nothing
+	for _, run := range []game.RecordedRun{
+		{UserID: db.user.ID, RoomCode: "SOLO01", Mode: game.ModeBattle, Placement: 1, Players: 1},
+		{UserID: db.user.ID, RoomCode: "QUIZ01", Mode: game.ModePresenter},
+		{UserID: db.user.ID, RoomCode: "RAID01", Mode: game.ModeRaid},
+	} {
+		if err := svc.SaveRun(ctx, run); err != nil {
+			t.Fatalf("SaveRun: %v", err)
+		}
+	}
+	if len(db.ledger) != 1 {
+		t.Fatalf("Expected no XP for solo, presenter and raid runs, got %+v", db.ledger)
+	}
+
+	win := game.RecordedRun{UserID: db.user.ID, RoomCode: "ABC123", Mode: game.ModeBattle, Placement: 1, Players: 3}
+	if err := svc.SaveRun(ctx, win); err != nil {
+		t.Fatalf("SaveRun: %v", err)
+	}
+	if len(db.ledger) != 2 || db.ledger[1].Source != progression.SourceBattle || db.ledger[1].Reference != "ABC123" {
+		t.Errorf("Unexpected ledger: %+v", db.ledger)
+	}
+	if len(notifier.messages) != 1 || notifier.messages[0]["type"] != "LEVEL_UP" || notifier.messages[0]["level"] != float64(2) {
+		t.Errorf("Expected a level 2 notification, got %v", notifier.messages)
+	}
+
+	var total int64
+	for _, e := range db.ledger {
+		total += int64(e.Amount)
+	}
+	if total != db.user.Xp {
+		t.Errorf("Ledger total %d doesn't match user XP %d", total, db.user.Xp)
+	}
+
+	// One streak day and a battle win, both on the same day
+	want := shop.StreakCoins(1) + shop.BattleCoins(1, 3)
+	if balance, _ := db.GetCoinBalance(ctx, uuid.NullUUID{UUID: db.user.ID, Valid: true}); balance != want {
+		t.Errorf("Expected %d coins, got %d", want, balance)
+	}
+}
+
+type streakRecorder struct {
+	AchievementRecorder
+	longest []int
+}
+
+func (m *streakRecorder) RecordStreak(ctx context.Context, userID uuid.UUID, longest int) error {
+	m.longest = append(m.longest, longest)
+	return nil
+}
+
+func TestProgressionService_StreakUsesUserTimeZone(t *testing.T) {
+	db, svc := newProgressionTest(nil)
+	db.user.TimeZone = "America/Los_Angeles"
+	recorder := &streakRecorder{}
+	svc.achievements = recorder
+	ctx := context.Background()
+
+	// 23:30 and 01:30 in Los Angeles: the same day in UTC, but two days
+	// there. 02:00 is the same day again.
+	first := time.Date(2024, 5, 1, 6, 30, 0, 0, time.UTC)
+	for _, at := range []time.Time{first, first.Add(2 * time.Hour), first.Add(150 * time.Minute)} {
+		svc.now = func() time.Time { return at }
+		if err := svc.RecordPractice(ctx, db.user.ID, uuid.New(), 1, 1); err != nil {
+			t.Fatalf("RecordPractice: %v", err)
+		}
+	}
+
+	if db.user.StreakCurrent != 2 {
+		t.Errorf("Expected a 2 day streak, got %d", db.user.StreakCurrent)
+	}
+	want := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	if !db.user.StreakLastDay.Time.Equal(want) {
+		t.Errorf("Expected last day %v, got %v", want, db.user.StreakLastDay.Time)
+	}
+
+	resp := userToResponse(db.user, svc.curve, first.Add(24*time.Hour))
+	if resp.Streak != 2 || resp.LongestStreak != 2 {
+		t.Errorf("Unexpected response streak: %+v", resp)
+	}
+
+	// Streak achievements hear about each new day once
+	if !reflect.DeepEqual(recorder.longest, []int{1, 2}) {
+		t.Errorf("Expected streaks 1 and 2 recorded, got %v", recorder.longest)
+	}
+}
+
+func TestProgressionService_UpdateSettings(t *testing.T) {
+	db, svc := newProgressionTest(nil)
+	db.user.RomajiSystems = []string{"hepburn", "kunrei", "nihon"}
+	ctx := context.Background()
+
+	for _, tz := range []string{"", "Local", "Mars/Olympus_Mons"} {
+		if _, err := svc.UpdateSettings(ctx, db.user.ID, dto.UpdateSettingsRequest{TimeZone: &tz}); !errors.Is(err, ErrInvalidTimeZone) {
+			t.Errorf("%q: expected ErrInvalidTimeZone, got %v", tz, err)
+		}
+	}
+	for _, systems := range [][]string{{}, {"wapuro"}} {
+		if _, err := svc.UpdateSettings(ctx, db.user.ID, dto.UpdateSettingsRequest{RomajiSystems: systems}); !errors.Is(err, ErrInvalidRomajiSystem) {
+			t.Errorf("%v: expected ErrInvalidRomajiSystem, got %v", systems, err)
+		}
+	}
+
+	db.user.Xp = 150
+	db.user.StreakLastDay = sql.NullTime{Time: progression.Day(time.Now(), time.UTC), Valid: true}
+	db.user.StreakCurrent = 3
+	tz := "Asia/Tokyo"
+	resp, err := svc.UpdateSettings(ctx, db.user.ID, dto.UpdateSettingsRequest{TimeZone: &tz})
+	if err != nil {
+		t.Fatalf("UpdateSettings: %v", err)
+	}
+	if resp.TimeZone != "Asia/Tokyo" || resp.Level != 2 || resp.LevelXP != 50 || resp.NextLevelXP != 200 {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+	if resp.Streak != 3 {
+		t.Errorf("Expected the streak to survive the move, got %d", resp.Streak)
+	}
+	if len(resp.RomajiSystems) != 3 {
+		t.Errorf("Expected the romaji systems to be untouched, got %v", resp.RomajiSystems)
+	}
+
+	resp, err = svc.UpdateSettings(ctx, db.user.ID, dto.UpdateSettingsRequest{RomajiSystems: []string{"Kunrei", "kunrei", "hepburn"}})
+	if err != nil {
+		t.Fatalf("UpdateSettings: %v", err)
+	}
+	if len(resp.RomajiSystems) != 2 || resp.RomajiSystems[0] != "kunrei" || resp.TimeZone != "Asia/Tokyo" {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/quiz"
+	"github.com/google/uuid"
+)
+
+var ErrEmptyQuiz = errors.New("the groups have no characters to ask")
+
+// Mistakes older than this don't pick distractors any more.
+const (
+	quizConfusionWindow = 90 * 24 * time.Hour
+	quizConfusionLimit  = 500
+)
+
+type QuizService interface {
+	// Generate returns n questions on the characters of the catalog, deck
+	// and kanji groups, with distractors drawn from the user's mistakes
+	Generate(ctx context.Context, userID uuid.UUID, groups []string, n, choices int, direction kana.Direction) ([]quiz.Question, error)
+}
+
+type quizService struct {
+	db  database.Querier
+	now func() time.Time
+}
+
+func NewQuizService(db database.Querier) QuizService {
+	return &quizService{
+		db:  db,
+		now: time.Now,
+	}
+}
+
+func (s *quizService) Generate(ctx context.Context, userID uuid.UUID, groups []string, n, choices int, direction kana.Direction) ([]quiz.Question, error) {
+	pool, err := groupChars(ctx, s.db, groups)
+	if err != nil {
+		return nil, err
+	}
+	// Kanji levels are empty until imported
+	if len(pool) == 0 {
+		return nil, ErrEmptyQuiz
+	}
+
+	rows, err := s.db.GetConfusionMatrix(ctx, database.GetConfusionMatrixParams{
+		UserID:     userID,
+		AnsweredAt: s.now().Add(-quizConfusionWindow),
+		Limit:      quizConfusionLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Rows come most frequent first
+	confusions := make(map[string][]string)
+	for _, row := range rows {
+		confusions[row.Kana] = append(confusions[row.Kana], row.Given)
+	}
+
+	rng := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	return quiz.Generate(pool, quiz.Options{
+		Count:      n,
+		Choices:    choices,
+		Direction:  direction,
+		Confusions: confusions,
+	}, rng), nil
+}
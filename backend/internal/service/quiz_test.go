@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/google/uuid"
+)
+
+type quizMockQuerier struct {
+	kanjiMockQuerier
+	confusions []database.GetConfusionMatrixRow
+}
+
+func (m *quizMockQuerier) GetConfusionMatrix(ctx context.Context, arg database.GetConfusionMatrixParams) ([]database.GetConfusionMatrixRow, error) {
+	return m.confusions, nil
+}
+
+func TestQuizService_Generate(t *testing.T) {
+	db := &quizMockQuerier{
+		kanjiMockQuerier: *newKanjiMockQuerier(),
+		confusions: []database.GetConfusionMatrixRow{
+			{Kana: "シ", Expected: "shi", Given: "shu", Count: 3},
+		},
+	}
+	svc := NewQuizService(db)
+
+	questions, err := svc.Generate(context.Background(), uuid.New(), []string{"ksingle", "ks", "kt"}, 60, 2, kana.DirectionToRomaji)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if len(questions) != 60 {
+		t.Fatalf("Expected 60 questions, got %d", len(questions))
+	}
+	for _, q := range questions {
+		if q.Kana == "シ" && !slices.Contains(q.Choices, "shu") {
+			t.Errorf("Expected the user's mistake as the distractor, got %v", q.Choices)
+		}
+	}
+
+	if _, err := svc.Generate(context.Background(), uuid.New(), []string{"nope"}, 10, 4, kana.DirectionToRomaji); !errors.Is(err, kana.ErrUnknownGroup) {
+		t.Errorf("Expected ErrUnknownGroup, got %v", err)
+	}
+	// No kanji imported
+	if _, err := svc.Generate(context.Background(), uuid.New(), []string{"kanji:jlpt4"}, 10, 4, kana.DirectionToRomaji); !errors.Is(err, ErrEmptyQuiz) {
+		t.Errorf("Expected ErrEmptyQuiz, got %v", err)
+	}
+}
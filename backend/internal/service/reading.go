@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/reading"
+	"github.com/Cadimodev/haiji/backend/internal/romaji"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrReadingItemNotFound = errors.New("reading item not found")
+	ErrInvalidReadingKind  = errors.New("kind must be word or sentence")
+)
+
+type ReadingService interface {
+	// Next returns up to count random items of the kind, or of both kinds if
+	// kind is empty, readable with the groups of the curriculum lessons the
+	// user has mastered
+	Next(ctx context.Context, userID uuid.UUID, kind string, count int) ([]dto.ReadingItemResponse, error)
+	Check(ctx context.Context, userID uuid.UUID, params dto.ReadingAnswerRequest) (dto.ReadingAnswerResponse, error)
+	// Import loads the readable words of a JMdict file and sentences of a
+	// Tatoeba TSV export; either may be nil
+	Import(ctx context.Context, jmdict, tatoeba io.Reader) (ReadingImportResult, error)
+}
+
+type ReadingImportResult struct {
+	Words     int
+	Sentences int
+}
+
+type readingService struct {
+	txManager database.TxManager
+	db        database.Querier
+	mastery   MasteryReader
+}
+
+func NewReadingService(txManager database.TxManager, db database.Querier, mastery MasteryReader) ReadingService {
+	return &readingService{
+		txManager: txManager,
+		db:        db,
+		mastery:   mastery,
+	}
+}
+
+func (s *readingService) Next(ctx context.Context, userID uuid.UUID, kind string, count int) ([]dto.ReadingItemResponse, error) {
+	kinds := []string{string(reading.KindWord), string(reading.KindSentence)}
+	switch reading.Kind(kind) {
+	case "":
+	case reading.KindWord, reading.KindSentence:
+		kinds = []string{kind}
+	default:
+		return nil, ErrInvalidReadingKind
+	}
+
+	groups, err := s.mastery.MasteredGroups(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return []dto.ReadingItemResponse{}, nil
+	}
+
+	rows, err := s.db.ListReadableItems(ctx, database.ListReadableItemsParams{
+		Groups: groups,
+		Kinds:  kinds,
+		Count:  int32(count),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.ReadingItemResponse, len(rows))
+	for i, row := range rows {
+		items[i] = dto.ReadingItemResponse{
+			ID:      row.ID,
+			Kind:    row.Kind,
+			Text:    row.Text,
+			Written: row.Written,
+			Meaning: row.Meaning,
+			Groups:  row.Groups,
+		}
+	}
+	return items, nil
+}
+
+func (s *readingService) Check(ctx context.Context, userID uuid.UUID, params dto.ReadingAnswerRequest) (dto.ReadingAnswerResponse, error) {
+	item, err := s.db.GetReadingItem(ctx, params.ItemID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.ReadingAnswerResponse{}, ErrReadingItemNotFound
+	}
+	if err != nil {
+		return dto.ReadingAnswerResponse{}, err
+	}
+	systems, err := userRomajiSystems(ctx, s.db, userID)
+	if err != nil {
+		return dto.ReadingAnswerResponse{}, err
+	}
+
+	var correct bool
+	if reading.Kind(item.Kind) == reading.KindSentence {
+		correct = romaji.MatchesSentence(item.Text, params.Answer, systems...)
+	} else {
+		correct = romaji.Matches(item.Text, params.Answer, systems...)
+	}
+	return dto.ReadingAnswerResponse{Correct: correct, Expected: hepburnText(item.Text)}, nil
+}
+
+// hepburnText romanizes text word by word, where it has spaces between
+// words. は and へ standing alone are particles, read "wa" and "e".
+func hepburnText(text string) string {
+	var words []string
+	for _, field := range strings.Fields(text) {
+		switch reading.Kana(field) {
+		case "は":
+			field = "わ"
+		case "へ":
+			field = "え"
+		}
+		if word, ok := romaji.Romanize(reading.Kana(field), romaji.Hepburn); ok && word != "" {
+			words = append(words, word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func (s *readingService) Import(ctx context.Context, jmdict, tatoeba io.Reader) (ReadingImportResult, error) {
+	var result ReadingImportResult
+	err := s.txManager.ExecTx(ctx, func(qtx database.Querier) error {
+		upsert := func(item reading.Item) error {
+			return qtx.UpsertReadingItem(ctx, database.UpsertReadingItemParams{
+				Kind:     string(item.Kind),
+				Source:   item.Source,
+				SourceID: item.SourceID,
+				Text:     item.Text,
+				Written:  item.Written,
+				Meaning:  item.Meaning,
+				Groups:   item.Groups,
+			})
+		}
+		if jmdict != nil {
+			err := reading.ParseJMdict(jmdict, func(item reading.Item) error {
+				result.Words++
+				return upsert(item)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if tatoeba != nil {
+			return reading.ParseTatoeba(tatoeba, func(item reading.Item) error {
+				result.Sentences++
+				return upsert(item)
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return ReadingImportResult{}, err
+	}
+	return result, nil
+}
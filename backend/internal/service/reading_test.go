@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/google/uuid"
+)
+
+type readingMockQuerier struct {
+	practiceMockQuerier
+	items []database.ReadingItem
+}
+
+// masteredGroups stands in for the curriculum.
+type masteredGroups []string
+
+func (m masteredGroups) MasteredGroups(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	return m, nil
+}
+
+func (m *readingMockQuerier) UpsertReadingItem(ctx context.Context, arg database.UpsertReadingItemParams) error {
+	m.items = append(m.items, database.ReadingItem{
+		ID:       int64(len(m.items) + 1),
+		Kind:     arg.Kind,
+		Source:   arg.Source,
+		SourceID: arg.SourceID,
+		Text:     arg.Text,
+		Written:  arg.Written,
+		Meaning:  arg.Meaning,
+		Groups:   arg.Groups,
+	})
+	return nil
+}
+
+func (m *readingMockQuerier) GetReadingItem(ctx context.Context, id int64) (database.ReadingItem, error) {
+	for _, item := range m.items {
+		if item.ID == id {
+			return item, nil
+		}
+	}
+	return database.ReadingItem{}, sql.ErrNoRows
+}
+
+func (m *readingMockQuerier) ListReadableItems(ctx context.Context, arg database.ListReadableItemsParams) ([]database.ReadingItem, error) {
+	var items []database.ReadingItem
+	for _, item := range m.items {
+		readable := slices.Contains(arg.Kinds, item.Kind)
+		for _, g := range item.Groups {
+			readable = readable && slices.Contains(arg.Groups, g)
+		}
+		if readable && len(items) < int(arg.Count) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func TestReadingService_NextFiltersByMasteredGroups(t *testing.T) {
+	db := &readingMockQuerier{}
+	// The vowels and the k row; ね's row isn't mastered yet
+	svc := NewReadingService(&MockTxManager{db: db}, db, masteredGroups{"hsingle", "hk"})
+	tatoeba := "1\tjpn\tあかい え。\n2\tjpn\tねこ が いる。\n"
+	if _, err := svc.Import(context.Background(), nil, strings.NewReader(tatoeba)); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	items, err := svc.Next(context.Background(), uuid.New(), "", 10)
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if len(items) != 1 || items[0].Text != "あかい え。" {
+		t.Errorf("Expected only the sentence readable with mastered groups, got %+v", items)
+	}
+
+	if items, _ := svc.Next(context.Background(), uuid.New(), "word", 10); len(items) != 0 {
+		t.Errorf("Expected no words, got %+v", items)
+	}
+	if _, err := svc.Next(context.Background(), uuid.New(), "poem", 10); !errors.Is(err, ErrInvalidReadingKind) {
+		t.Errorf("Expected ErrInvalidReadingKind, got %v", err)
+	}
+}
+
+func TestReadingService_CheckAcceptsAlternativeRomanizations(t *testing.T) {
+	db := &readingMockQuerier{}
+	svc := NewReadingService(&MockTxManager{db: db}, db, masteredGroups{})
+	jmdict := `<JMdict><entry><ent_seq>1</ent_seq><k_ele><keb>東京</keb></k_ele>` +
+		`<r_ele><reb>とうきょう</reb></r_ele><sense><gloss>Tokyo</gloss></sense></entry></JMdict>`
+	tatoeba := "2\tjpn\tわたし は がくせい です。\n"
+	if _, err := svc.Import(context.Background(), strings.NewReader(jmdict), strings.NewReader(tatoeba)); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	cases := []struct {
+		id      int64
+		answer  string
+		correct bool
+	}{
+		{1, "toukyou", true},
+		{1, "Tōkyō", true},
+		{1, "tokyo", false},
+		{2, "watashi wa gakusei desu", true},
+		{2, "watasi ha gakusei desu.", true},
+		{2, "watashi wa sensei desu", false},
+	}
+	for _, tc := range cases {
+		response, err := svc.Check(context.Background(), uuid.New(), dto.ReadingAnswerRequest{ItemID: tc.id, Answer: tc.answer})
+		if err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+		if response.Correct != tc.correct {
+			t.Errorf("%d %q: expected correct=%v", tc.id, tc.answer, tc.correct)
+		}
+	}
+
+	response, _ := svc.Check(context.Background(), uuid.New(), dto.ReadingAnswerRequest{ItemID: 2, Answer: "x"})
+	if response.Expected != "watashi wa gakusei desu" {
+		t.Errorf("Unexpected expected answer %q", response.Expected)
+	}
+	if _, err := svc.Check(context.Background(), uuid.New(), dto.ReadingAnswerRequest{ItemID: 9}); !errors.Is(err, ErrReadingItemNotFound) {
+		t.Errorf("Expected ErrReadingItemNotFound, got %v", err)
+	}
+}
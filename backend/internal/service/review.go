@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/srs"
+	"github.com/google/uuid"
+)
+
+// NewCardsPerDay caps how many unseen kana are introduced per user and UTC day.
+const NewCardsPerDay = 15
+
+var ErrUnknownKana = errors.New("unknown kana")
+
+type ReviewService interface {
+	// Due returns the cards to review now, topped up with unseen kana from
+	// groups (every group when empty) while the daily allowance lasts.
+	Due(ctx context.Context, userID uuid.UUID, groups []string, limit int) (dto.ReviewQueueResponse, error)
+	Submit(ctx context.Context, userID uuid.UUID, params dto.SubmitReviewsRequest) (dto.SubmitReviewsResponse, error)
+}
+
+type reviewService struct {
+	txManager database.TxManager
+	db        database.Querier
+	scheduler srs.Scheduler
+	now       func() time.Time
+}
+
+func NewReviewService(txManager database.TxManager, db database.Querier, scheduler srs.Scheduler) ReviewService {
+	return &reviewService{
+		txManager: txManager,
+		db:        db,
+		scheduler: scheduler,
+		now:       time.Now,
+	}
+}
+
+func (s *reviewService) Due(ctx context.Context, userID uuid.UUID, groups []string, limit int) (dto.ReviewQueueResponse, error) {
+	now := s.now()
+
+	due, err := s.db.ListDueSRSCards(ctx, database.ListDueSRSCardsParams{
+		UserID: userID,
+		DueAt:  now,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return dto.ReviewQueueResponse{}, err
+	}
+	dueCount, err := s.db.CountDueSRSCards(ctx, database.CountDueSRSCardsParams{
+		UserID: userID,
+		DueAt:  now,
+	})
+	if err != nil {
+		return dto.ReviewQueueResponse{}, err
+	}
+
+	response := dto.ReviewQueueResponse{
+		Cards:    make([]dto.ReviewCard, 0, limit),
+		DueCount: int(dueCount),
+	}
+	for _, row := range due {
+		response.Cards = append(response.Cards, cardToResponse(row.Kana, cardFromRow(row)))
+	}
+
+	if len(response.Cards) >= limit {
+		return response, nil
+	}
+
+	newCards, err := s.newCards(ctx, userID, groups, now, limit-len(response.Cards))
+	if err != nil {
+		return dto.ReviewQueueResponse{}, err
+	}
+	for _, c := range newCards {
+		response.Cards = append(response.Cards, cardToResponse(c.Kana, srs.NewCard(now)))
+	}
+	response.NewCount = len(newCards)
+	return response, nil
+}
+
+// newCards picks up to n kana the user has never reviewed, in catalog order.
+func (s *reviewService) newCards(ctx context.Context, userID uuid.UUID, groups []string, now time.Time, n int) ([]kana.Char, error) {
+	if len(groups) == 0 {
+		groups = kana.GroupIDs()
+	}
+	chars, err := kana.CharsFor(groups)
+	if err != nil {
+		return nil, err
+	}
+
+	introduced, err := s.db.CountSRSCardsCreatedSince(ctx, database.CountSRSCardsCreatedSinceParams{
+		UserID:    userID,
+		CreatedAt: now.UTC().Truncate(24 * time.Hour),
+	})
+	if err != nil {
+		return nil, err
+	}
+	n = min(n, NewCardsPerDay-int(introduced))
+	if n <= 0 {
+		return nil, nil
+	}
+
+	seen, err := s.db.ListSRSCardKana(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(seen))
+	for _, k := range seen {
+		known[k] = true
+	}
+
+	var picked []kana.Char
+	for _, c := range chars {
+		if len(picked) == n {
+			break
+		}
+		if known[c.Kana] {
+			continue
+		}
+		// Some kana appear in more than one group
+		known[c.Kana] = true
+		picked = append(picked, c)
+	}
+	return picked, nil
+}
+
+func (s *reviewService) Submit(ctx context.Context, userID uuid.UUID, params dto.SubmitReviewsRequest) (dto.SubmitReviewsResponse, error) {
+	kanaList := make([]string, 0, len(params.Reviews))
+	for _, r := range params.Reviews {
+		if _, ok := kana.Lookup(r.Kana); !ok {
+			return dto.SubmitReviewsResponse{}, fmt.Errorf("%w: %s", ErrUnknownKana, r.Kana)
+		}
+		kanaList = append(kanaList, r.Kana)
+	}
+
+	now := s.now()
+	var response dto.SubmitReviewsResponse
+
+	err := s.txManager.ExecTx(ctx, func(qtx database.Querier) error {
+		rows, err := qtx.GetSRSCards(ctx, database.GetSRSCardsParams{
+			UserID: userID,
+			Kana:   kanaList,
+		})
+		if err != nil {
+			return err
+		}
+		cards := make(map[string]srs.Card, len(rows))
+		for _, row := range rows {
+			cards[row.Kana] = cardFromRow(row)
+		}
+
+		// Answers are applied in order, so a kana repeated in the batch
+		// builds on its previous answer
+		var order []string
+		for _, r := range params.Reviews {
+			card, ok := cards[r.Kana]
+			if !ok {
+				card = srs.NewCard(now)
+			}
+			if !slices.Contains(order, r.Kana) {
+				order = append(order, r.Kana)
+			}
+
+			grade := srs.AdjustForLatency(srs.Grade(r.Grade), r.ResponseMs)
+			if err := qtx.CreateSRSReviewLog(ctx, database.CreateSRSReviewLogParams{
+				UserID:     userID,
+				Kana:       r.Kana,
+				Grade:      int16(grade),
+				ResponseMs: int32(r.ResponseMs),
+				State:      int16(card.State),
+				ReviewedAt: now,
+			}); err != nil {
+				return err
+			}
+			cards[r.Kana] = s.scheduler.Review(card, grade, now)
+		}
+
+		for _, k := range order {
+			if err := qtx.UpsertSRSCard(ctx, cardToParams(userID, k, cards[k])); err != nil {
+				return err
+			}
+			response.Cards = append(response.Cards, cardToResponse(k, cards[k]))
+		}
+		return nil
+	})
+	if err != nil {
+		return dto.SubmitReviewsResponse{}, err
+	}
+
+	return response, nil
+}
+
+func cardFromRow(row database.SrsCard) srs.Card {
+	return srs.Card{
+		State:        srs.State(row.State),
+		Stability:    row.Stability,
+		Difficulty:   row.Difficulty,
+		EaseFactor:   row.EaseFactor,
+		IntervalDays: int(row.IntervalDays),
+		Reps:         int(row.Reps),
+		Lapses:       int(row.Lapses),
+		Due:          row.DueAt,
+		LastReview:   row.LastReviewAt.Time,
+	}
+}
+
+func cardToParams(userID uuid.UUID, k string, card srs.Card) database.UpsertSRSCardParams {
+	return database.UpsertSRSCardParams{
+		UserID:       userID,
+		Kana:         k,
+		State:        int16(card.State),
+		Stability:    card.Stability,
+		Difficulty:   card.Difficulty,
+		EaseFactor:   card.EaseFactor,
+		IntervalDays: int32(card.IntervalDays),
+		Reps:         int32(card.Reps),
+		Lapses:       int32(card.Lapses),
+		DueAt:        card.Due,
+		LastReviewAt: sql.NullTime{Time: card.LastReview, Valid: !card.LastReview.IsZero()},
+	}
+}
+
+func cardToResponse(k string, card srs.Card) dto.ReviewCard {
+	c, _ := kana.Lookup(k)
+	return dto.ReviewCard{
+		Kana:    k,
+		Romanji: c.Romanji,
+		State:   card.State,
+		Due:     card.Due,
+		Reps:    card.Reps,
+		Lapses:  card.Lapses,
+	}
+}
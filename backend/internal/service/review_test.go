@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/srs"
+	"github.com/google/uuid"
+)
+
+type reviewMockQuerier struct {
+	database.Querier
+	cards map[string]database.SrsCard
+	logs  []database.CreateSRSReviewLogParams
+	now   time.Time
+}
+
+func newReviewMockQuerier(now time.Time) *reviewMockQuerier {
+	return &reviewMockQuerier{cards: make(map[string]database.SrsCard), now: now}
+}
+
+func (m *reviewMockQuerier) dueCards(due time.Time) []database.SrsCard {
+	var cards []database.SrsCard
+	for _, c := range m.cards {
+		if !c.DueAt.After(due) {
+			cards = append(cards, c)
+		}
+	}
+	sort.Slice(cards, func(i, j int) bool { return cards[i].DueAt.Before(cards[j].DueAt) })
+	return cards
+}
+
+func (m *reviewMockQuerier) ListDueSRSCards(ctx context.Context, arg database.ListDueSRSCardsParams) ([]database.SrsCard, error) {
+	cards := m.dueCards(arg.DueAt)
+	if len(cards) > int(arg.Limit) {
+		cards = cards[:arg.Limit]
+	}
+	return cards, nil
+}
+
+func (m *reviewMockQuerier) CountDueSRSCards(ctx context.Context, arg database.CountDueSRSCardsParams) (int64, error) {
+	return int64(len(m.dueCards(arg.DueAt))), nil
+}
+
+func (m *reviewMockQuerier) CountSRSCardsCreatedSince(ctx context.Context, arg database.CountSRSCardsCreatedSinceParams) (int64, error) {
+	var n int64
+	for _, c := range m.cards {
+		if !c.CreatedAt.Before(arg.CreatedAt) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *reviewMockQuerier) ListSRSCardKana(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	var kana []string
+	for k := range m.cards {
+		kana = append(kana, k)
+	}
+	return kana, nil
+}
+
+func (m *reviewMockQuerier) GetSRSCards(ctx context.Context, arg database.GetSRSCardsParams) ([]database.SrsCard, error) {
+	var cards []database.SrsCard
+	for _, k := range arg.Kana {
+		if c, ok := m.cards[k]; ok {
+			cards = append(cards, c)
+		}
+	}
+	return cards, nil
+}
+
+func (m *reviewMockQuerier) UpsertSRSCard(ctx context.Context, arg database.UpsertSRSCardParams) error {
+	created := m.now
+	if c, ok := m.cards[arg.Kana]; ok {
+		created = c.CreatedAt
+	}
+	m.cards[arg.Kana] = database.SrsCard{
+		UserID:       arg.UserID,
+		Kana:         arg.Kana,
+		State:        arg.State,
+		Stability:    arg.Stability,
+		Difficulty:   arg.Difficulty,
+		EaseFactor:   arg.EaseFactor,
+		IntervalDays: arg.IntervalDays,
+		Reps:         arg.Reps,
+		Lapses:       arg.Lapses,
+		DueAt:        arg.DueAt,
+		LastReviewAt: arg.LastReviewAt,
+		CreatedAt:    created,
+	}
+	return nil
+}
+
+func (m *reviewMockQuerier) CreateSRSReviewLog(ctx context.Context, arg database.CreateSRSReviewLogParams) error {
+	m.logs = append(m.logs, arg)
+	return nil
+}
+
+func newTestReviewService(db *reviewMockQuerier) *reviewService {
+	svc := NewReviewService(&MockTxManager{db: db}, db, srs.NewFSRS()).(*reviewService)
+	svc.now = func() time.Time { return db.now }
+	return svc
+}
+
+func TestReviewService_DueIntroducesNewKana(t *testing.T) {
+	db := newReviewMockQuerier(time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC))
+	svc := newTestReviewService(db)
+	userID := uuid.New()
+
+	queue, err := svc.Due(context.Background(), userID, []string{"hsingle"}, 3)
+	if err != nil {
+		t.Fatalf("Due: %v", err)
+	}
+	if queue.NewCount != 3 || len(queue.Cards) != 3 || queue.DueCount != 0 {
+		t.Fatalf("Expected 3 new cards, got %+v", queue)
+	}
+	if queue.Cards[0].Kana != "あ" || queue.Cards[0].Romanji != "a" {
+		t.Errorf("Expected new kana in catalog order, got %+v", queue.Cards[0])
+	}
+
+	// The daily allowance counts kana already introduced today
+	for i := 0; i < NewCardsPerDay-1; i++ {
+		db.cards[string(rune('a'+i))] = database.SrsCard{CreatedAt: db.now, DueAt: db.now.Add(time.Hour)}
+	}
+	queue, err = svc.Due(context.Background(), userID, []string{"hsingle"}, 10)
+	if err != nil {
+		t.Fatalf("Due: %v", err)
+	}
+	if queue.NewCount != 1 {
+		t.Errorf("Expected 1 new card left today, got %d", queue.NewCount)
+	}
+}
+
+func TestReviewService_SubmitSchedulesCards(t *testing.T) {
+	db := newReviewMockQuerier(time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC))
+	svc := newTestReviewService(db)
+	userID := uuid.New()
+
+	response, err := svc.Submit(context.Background(), userID, dto.SubmitReviewsRequest{
+		Reviews: []dto.ReviewAnswer{
+			{Kana: "あ", Grade: int(srs.Easy), ResponseMs: 700},
+			{Kana: "い", Grade: int(srs.Again), ResponseMs: 2000},
+			{Kana: "い", Grade: int(srs.Good), ResponseMs: 1500},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if len(response.Cards) != 2 || len(db.logs) != 3 {
+		t.Fatalf("Expected 2 cards and 3 logs, got %d and %d", len(response.Cards), len(db.logs))
+	}
+
+	a := db.cards["あ"]
+	if srs.State(a.State) != srs.StateReview || !a.DueAt.After(db.now.Add(24*time.Hour-time.Minute)) {
+		t.Errorf("Expected あ to graduate to review, got %+v", a)
+	}
+	i := db.cards["い"]
+	if i.Reps != 2 {
+		t.Errorf("Expected repeated kana to build on the previous answer, got %d reps", i.Reps)
+	}
+	if db.logs[2].State != int16(srs.StateLearning) {
+		t.Errorf("Expected second answer logged from learning state, got %d", db.logs[2].State)
+	}
+
+	// Reviewed cards come back once due
+	db.now = db.now.Add(30 * 24 * time.Hour)
+	queue, err := svc.Due(context.Background(), userID, []string{"hsingle"}, 2)
+	if err != nil {
+		t.Fatalf("Due: %v", err)
+	}
+	if queue.DueCount != 2 || !slices.ContainsFunc(queue.Cards, func(c dto.ReviewCard) bool { return c.Kana == "あ" }) {
+		t.Errorf("Expected both reviewed kana due, got %+v", queue)
+	}
+}
+
+func TestReviewService_SubmitUnknownKana(t *testing.T) {
+	db := newReviewMockQuerier(time.Now())
+	svc := newTestReviewService(db)
+
+	_, err := svc.Submit(context.Background(), uuid.New(), dto.SubmitReviewsRequest{
+		Reviews: []dto.ReviewAnswer{{Kana: "X", Grade: 3}},
+	})
+	if !errors.Is(err, ErrUnknownKana) {
+		t.Errorf("Expected ErrUnknownKana, got %v", err)
+	}
+	if len(db.logs) != 0 {
+		t.Error("Nothing should be stored for a rejected batch")
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/Cadimodev/haiji/backend/internal/sharecard"
+	"github.com/google/uuid"
+)
+
+// How long finished games can be shared
+const ShareTTL = 7 * 24 * time.Hour
+
+var ErrShareNotFound = errors.New("share not found")
+
+type ShareService interface {
+	game.ShareStore
+	// CardPath returns the path of the share's rendered card, rendering it
+	// on first use.
+	CardPath(ctx context.Context, id uuid.UUID) (string, error)
+	// RunCleanup deletes expired shares and their cards, checking every
+	// interval.
+	RunCleanup(interval time.Duration)
+}
+
+type shareService struct {
+	db       database.Querier
+	cacheDir string
+	now      func() time.Time
+}
+
+// NewShareService caches rendered cards in cacheDir.
+func NewShareService(db database.Querier, cacheDir string) ShareService {
+	return &shareService{
+		db:       db,
+		cacheDir: cacheDir,
+		now:      time.Now,
+	}
+}
+
+func (s *shareService) SaveStandings(ctx context.Context, standings game.Standings) error {
+	data, err := json.Marshal(standings.Players)
+	if err != nil {
+		return err
+	}
+	return s.db.CreateShareCard(ctx, database.CreateShareCardParams{
+		ID:        standings.ID,
+		RoomCode:  standings.RoomCode,
+		Groups:    standings.Groups,
+		Standings: data,
+		ExpiresAt: s.now().Add(ShareTTL),
+	})
+}
+
+func (s *shareService) CardPath(ctx context.Context, id uuid.UUID) (string, error) {
+	// Expired shares are gone even if their card is still cached
+	row, err := s.db.GetShareCard(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrShareNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	path := s.cardPath(id)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	var players []game.Standing
+	if err := json.Unmarshal(row.Standings, &players); err != nil {
+		return "", err
+	}
+	card := sharecard.Card{Groups: row.Groups, FinishedAt: row.CreatedAt}
+	for _, p := range players {
+		card.Standings = append(card.Standings, sharecard.Standing{
+			Username:  p.Username,
+			Score:     p.Score,
+			Placement: p.Placement,
+			Ghost:     p.Ghost,
+		})
+	}
+
+	if err := s.render(path, card); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// render writes the card to a temporary file first, so a card being
+// rendered is never served half written.
+func (s *shareService) render(path string, card sharecard.Card) error {
+	if err := os.MkdirAll(s.cacheDir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(s.cacheDir, "card-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := sharecard.Render(f, card); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func (s *shareService) RunCleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		ids, err := s.db.DeleteExpiredShareCards(context.Background())
+		if err != nil {
+			slog.Error("Error deleting expired shares", "error", err)
+		}
+		for _, id := range ids {
+			if err := os.Remove(s.cardPath(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				slog.Error("Error deleting share card", "error", err, "share", id)
+			}
+		}
+		<-ticker.C
+	}
+}
+
+func (s *shareService) cardPath(id uuid.UUID) string {
+	return filepath.Join(s.cacheDir, id.String()+".png")
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"image/png"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/game"
+	"github.com/google/uuid"
+)
+
+type shareMockQuerier struct {
+	database.Querier
+	cards map[uuid.UUID]database.ShareCard
+	now   time.Time
+}
+
+func (m *shareMockQuerier) CreateShareCard(ctx context.Context, arg database.CreateShareCardParams) error {
+	m.cards[arg.ID] = database.ShareCard{
+		ID:        arg.ID,
+		RoomCode:  arg.RoomCode,
+		Groups:    arg.Groups,
+		Standings: arg.Standings,
+		CreatedAt: m.now,
+		ExpiresAt: arg.ExpiresAt,
+	}
+	return nil
+}
+
+func (m *shareMockQuerier) GetShareCard(ctx context.Context, id uuid.UUID) (database.ShareCard, error) {
+	card, ok := m.cards[id]
+	if !ok || !card.ExpiresAt.After(m.now) {
+		return database.ShareCard{}, sql.ErrNoRows
+	}
+	return card, nil
+}
+
+func TestShareService_CardPath(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	db := &shareMockQuerier{cards: map[uuid.UUID]database.ShareCard{}, now: now}
+	svc := NewShareService(db, t.TempDir()).(*shareService)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	standings := game.Standings{
+		ID:       uuid.New(),
+		RoomCode: "ABC123",
+		Groups:   []string{"hsingle"},
+		Players: []game.Standing{
+			{UserID: uuid.New(), Username: "alice", Score: 300, Placement: 1},
+			{UserID: uuid.New(), Username: "bob", Score: 200, Placement: 2},
+		},
+	}
+	if err := svc.SaveStandings(ctx, standings); err != nil {
+		t.Fatalf("SaveStandings: %v", err)
+	}
+
+	path, err := svc.CardPath(ctx, standings.ID)
+	if err != nil {
+		t.Fatalf("CardPath: %v", err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Expected the card on disk: %v", err)
+	}
+	_, err = png.Decode(f)
+	f.Close()
+	if err != nil {
+		t.Fatalf("Expected a PNG card: %v", err)
+	}
+
+	// The cached card is served without rendering again
+	if err := os.WriteFile(path, []byte("cached"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if again, err := svc.CardPath(ctx, standings.ID); err != nil || again != path {
+		t.Fatalf("Expected the cached path, got %q, %v", again, err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "cached" {
+		t.Error("Expected the card not to be rendered again")
+	}
+
+	if _, err := svc.CardPath(ctx, uuid.New()); !errors.Is(err, ErrShareNotFound) {
+		t.Errorf("Expected ErrShareNotFound for an unknown share, got %v", err)
+	}
+	db.now = now.Add(ShareTTL)
+	if _, err := svc.CardPath(ctx, standings.ID); !errors.Is(err, ErrShareNotFound) {
+		t.Errorf("Expected ErrShareNotFound for an expired share, got %v", err)
+	}
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/shop"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownItem       = errors.New("unknown item")
+	ErrUnknownSlot       = errors.New("unknown cosmetic slot")
+	ErrItemOwned         = errors.New("item already owned")
+	ErrItemNotOwned      = errors.New("item not owned")
+	ErrInsufficientCoins = errors.New("not enough coins")
+)
+
+type ShopService interface {
+	Catalog(ctx context.Context, userID uuid.UUID) (dto.ShopResponse, error)
+	Purchase(ctx context.Context, userID uuid.UUID, itemID string) (dto.PurchaseResponse, error)
+	Equip(ctx context.Context, userID uuid.UUID, itemID string) (dto.EquippedResponse, error)
+	Unequip(ctx context.Context, userID uuid.UUID, slot string) (dto.EquippedResponse, error)
+	Wallet(ctx context.Context, userID uuid.UUID, limit int) (dto.WalletResponse, error)
+}
+
+type shopService struct {
+	txManager database.TxManager
+	db        database.Querier
+}
+
+func NewShopService(txManager database.TxManager, db database.Querier) ShopService {
+	return &shopService{
+		txManager: txManager,
+		db:        db,
+	}
+}
+
+func (s *shopService) Catalog(ctx context.Context, userID uuid.UUID) (dto.ShopResponse, error) {
+	balance, err := coinBalance(ctx, s.db, userID)
+	if err != nil {
+		return dto.ShopResponse{}, err
+	}
+	owned, equipped, err := s.inventory(ctx, userID)
+	if err != nil {
+		return dto.ShopResponse{}, err
+	}
+
+	res := dto.ShopResponse{Balance: balance, Items: make([]dto.ShopItem, len(shop.Items))}
+	for i, it := range shop.Items {
+		res.Items[i] = dto.ShopItem{Item: it, Owned: owned[it.ID], Equipped: equipped[it.Kind] == it.ID}
+	}
+	return res, nil
+}
+
+// Purchase pays for an item out of the user's coins. The user's account row
+// is locked for the transaction, so concurrent purchases are checked against
+// the balance one at a time and can't overdraw it.
+func (s *shopService) Purchase(ctx context.Context, userID uuid.UUID, itemID string) (dto.PurchaseResponse, error) {
+	item, ok := shop.GetItem(itemID)
+	if !ok {
+		return dto.PurchaseResponse{}, ErrUnknownItem
+	}
+
+	var balance int64
+	err := s.txManager.ExecTx(ctx, func(qtx database.Querier) error {
+		account, err := userCoinAccount(ctx, qtx, userID)
+		if err != nil {
+			return err
+		}
+		owned, err := qtx.IsCosmeticOwned(ctx, database.IsCosmeticOwnedParams{UserID: userID, ItemID: item.ID})
+		if err != nil {
+			return err
+		}
+		if owned {
+			return ErrItemOwned
+		}
+		if account.Balance < item.Price {
+			return ErrInsufficientCoins
+		}
+
+		shopID, err := qtx.GetSystemCoinAccountID(ctx, sql.NullString{String: shop.AccountShop, Valid: true})
+		if err != nil {
+			return err
+		}
+		transferID, err := transferCoins(ctx, qtx, account.ID, shopID, -item.Price, shop.TransferPurchase, item.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := qtx.AddOwnedCosmetic(ctx, database.AddOwnedCosmeticParams{
+			UserID:     userID,
+			ItemID:     item.ID,
+			TransferID: transferID,
+		}); err != nil {
+			return err
+		}
+		balance = account.Balance - item.Price
+		return nil
+	})
+	if err != nil {
+		return dto.PurchaseResponse{}, err
+	}
+
+	return dto.PurchaseResponse{
+		Item:    dto.ShopItem{Item: item, Owned: true},
+		Balance: balance,
+	}, nil
+}
+
+func (s *shopService) Equip(ctx context.Context, userID uuid.UUID, itemID string) (dto.EquippedResponse, error) {
+	item, ok := shop.GetItem(itemID)
+	if !ok {
+		return dto.EquippedResponse{}, ErrUnknownItem
+	}
+	owned, err := s.db.IsCosmeticOwned(ctx, database.IsCosmeticOwnedParams{UserID: userID, ItemID: item.ID})
+	if err != nil {
+		return dto.EquippedResponse{}, err
+	}
+	if !owned {
+		return dto.EquippedResponse{}, ErrItemNotOwned
+	}
+
+	if err := s.db.EquipCosmetic(ctx, database.EquipCosmeticParams{
+		UserID: userID,
+		Slot:   item.Kind,
+		ItemID: item.ID,
+	}); err != nil {
+		return dto.EquippedResponse{}, err
+	}
+	return s.equipped(ctx, userID)
+}
+
+func (s *shopService) Unequip(ctx context.Context, userID uuid.UUID, slot string) (dto.EquippedResponse, error) {
+	if !shop.ValidKind(slot) {
+		return dto.EquippedResponse{}, ErrUnknownSlot
+	}
+	if _, err := s.db.UnequipCosmetic(ctx, database.UnequipCosmeticParams{UserID: userID, Slot: slot}); err != nil {
+		return dto.EquippedResponse{}, err
+	}
+	return s.equipped(ctx, userID)
+}
+
+func (s *shopService) Wallet(ctx context.Context, userID uuid.UUID, limit int) (dto.WalletResponse, error) {
+	balance, err := coinBalance(ctx, s.db, userID)
+	if err != nil {
+		return dto.WalletResponse{}, err
+	}
+	rows, err := s.db.ListCoinEntries(ctx, database.ListCoinEntriesParams{
+		UserID: uuid.NullUUID{UUID: userID, Valid: true},
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return dto.WalletResponse{}, err
+	}
+
+	res := dto.WalletResponse{Balance: balance, Entries: make([]dto.WalletEntry, len(rows))}
+	for i, row := range rows {
+		res.Entries[i] = dto.WalletEntry{
+			Amount:    row.Amount,
+			Kind:      row.Kind,
+			Reference: row.Reference,
+			CreatedAt: row.CreatedAt,
+		}
+	}
+	return res, nil
+}
+
+func (s *shopService) equipped(ctx context.Context, userID uuid.UUID) (dto.EquippedResponse, error) {
+	equipped, err := EquippedCosmetics(ctx, s.db, userID)
+	if err != nil {
+		return dto.EquippedResponse{}, err
+	}
+	return dto.EquippedResponse{Equipped: equipped}, nil
+}
+
+func (s *shopService) inventory(ctx context.Context, userID uuid.UUID) (map[string]bool, map[string]string, error) {
+	rows, err := s.db.ListOwnedCosmetics(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	owned := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		owned[row.ItemID] = true
+	}
+	equipped, err := EquippedCosmetics(ctx, s.db, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return owned, equipped, nil
+}
+
+// EquippedCosmetics maps every slot the user has equipped to its item ID.
+func EquippedCosmetics(ctx context.Context, db database.Querier, userID uuid.UUID) (map[string]string, error) {
+	rows, err := db.ListEquippedCosmetics(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	equipped := make(map[string]string, len(rows))
+	for _, row := range rows {
+		equipped[row.Slot] = row.ItemID
+	}
+	return equipped, nil
+}
+
+// rewardCoins pays coins out of the rewards account. It must run in the
+// caller's transaction.
+func rewardCoins(ctx context.Context, qtx database.Querier, userID uuid.UUID, amount int64, kind, reference string) error {
+	if amount <= 0 {
+		return nil
+	}
+	account, err := userCoinAccount(ctx, qtx, userID)
+	if err != nil {
+		return err
+	}
+	rewardsID, err := qtx.GetSystemCoinAccountID(ctx, sql.NullString{String: shop.AccountRewards, Valid: true})
+	if err != nil {
+		return err
+	}
+	_, err = transferCoins(ctx, qtx, account.ID, rewardsID, amount, kind, reference)
+	return err
+}
+
+// userCoinAccount returns the user's account, opening it on first use, and
+// locks it until the transaction ends.
+func userCoinAccount(ctx context.Context, qtx database.Querier, userID uuid.UUID) (database.CoinAccount, error) {
+	id := uuid.NullUUID{UUID: userID, Valid: true}
+	if err := qtx.EnsureCoinAccount(ctx, id); err != nil {
+		return database.CoinAccount{}, err
+	}
+	return qtx.GetCoinAccountForUpdate(ctx, id)
+}
+
+// transferCoins records a transfer between a user's account and a system
+// account as two balancing entries, and returns the transfer's ID. amount is
+// what the user receives, negative when they pay. Only the user's balance is
+// kept up to date: a system account's is the sum of its entries, so payouts
+// and purchases don't all wait on the same row lock.
+func transferCoins(ctx context.Context, qtx database.Querier, userAccount, systemAccount, amount int64, kind, reference string) (int64, error) {
+	transferID, err := qtx.CreateCoinTransfer(ctx, database.CreateCoinTransferParams{Kind: kind, Reference: reference})
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range []struct{ account, amount int64 }{{userAccount, amount}, {systemAccount, -amount}} {
+		if err := qtx.CreateCoinEntry(ctx, database.CreateCoinEntryParams{
+			TransferID: transferID,
+			AccountID:  e.account,
+			Amount:     e.amount,
+		}); err != nil {
+			return 0, err
+		}
+	}
+	if err := qtx.AddCoinBalance(ctx, database.AddCoinBalanceParams{ID: userAccount, Balance: amount}); err != nil {
+		return 0, err
+	}
+	return transferID, nil
+}
+
+func coinBalance(ctx context.Context, db database.Querier, userID uuid.UUID) (int64, error) {
+	balance, err := db.GetCoinBalance(ctx, uuid.NullUUID{UUID: userID, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return balance, err
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/shop"
+	"github.com/google/uuid"
+)
+
+// coinMockQuerier keeps the coin ledger and cosmetics in memory.
+type coinMockQuerier struct {
+	database.Querier
+	accounts  []database.CoinAccount
+	transfers []database.CreateCoinTransferParams
+	entries   []database.CreateCoinEntryParams
+	owned     []database.AddOwnedCosmeticParams
+	equipped  map[string]string
+}
+
+func newCoinMockQuerier() coinMockQuerier {
+	return coinMockQuerier{
+		accounts: []database.CoinAccount{
+			{ID: 1, SystemName: sql.NullString{String: shop.AccountRewards, Valid: true}},
+			{ID: 2, SystemName: sql.NullString{String: shop.AccountShop, Valid: true}},
+		},
+		equipped: map[string]string{},
+	}
+}
+
+func (m *coinMockQuerier) account(userID uuid.NullUUID) *database.CoinAccount {
+	for i := range m.accounts {
+		if m.accounts[i].UserID == userID {
+			return &m.accounts[i]
+		}
+	}
+	return nil
+}
+
+func (m *coinMockQuerier) EnsureCoinAccount(ctx context.Context, userID uuid.NullUUID) error {
+	if m.account(userID) == nil {
+		m.accounts = append(m.accounts, database.CoinAccount{ID: int64(len(m.accounts) + 1), UserID: userID})
+	}
+	return nil
+}
+
+func (m *coinMockQuerier) GetCoinAccountForUpdate(ctx context.Context, userID uuid.NullUUID) (database.CoinAccount, error) {
+	if a := m.account(userID); a != nil {
+		return *a, nil
+	}
+	return database.CoinAccount{}, sql.ErrNoRows
+}
+
+func (m *coinMockQuerier) GetCoinBalance(ctx context.Context, userID uuid.NullUUID) (int64, error) {
+	if a := m.account(userID); a != nil {
+		return a.Balance, nil
+	}
+	return 0, sql.ErrNoRows
+}
+
+func (m *coinMockQuerier) GetSystemCoinAccountID(ctx context.Context, name sql.NullString) (int64, error) {
+	for _, a := range m.accounts {
+		if a.SystemName == name {
+			return a.ID, nil
+		}
+	}
+	return 0, sql.ErrNoRows
+}
+
+func (m *coinMockQuerier) CreateCoinTransfer(ctx context.Context, arg database.CreateCoinTransferParams) (int64, error) {
+	m.transfers = append(m.transfers, arg)
+	return int64(len(m.transfers)), nil
+}
+
+func (m *coinMockQuerier) CreateCoinEntry(ctx context.Context, arg database.CreateCoinEntryParams) error {
+	m.entries = append(m.entries, arg)
+	return nil
+}
+
+func (m *coinMockQuerier) AddCoinBalance(ctx context.Context, arg database.AddCoinBalanceParams) error {
+	a := &m.accounts[arg.ID-1]
+	if a.SystemName.Valid {
+		return errors.New("system account balances are derived from their entries")
+	}
+	a.Balance += arg.Balance
+	return nil
+}
+
+// systemBalance sums the entries of a system account.
+func (m *coinMockQuerier) systemBalance(id int64) int64 {
+	var sum int64
+	for _, e := range m.entries {
+		if e.AccountID == id {
+			sum += e.Amount
+		}
+	}
+	return sum
+}
+
+func (m *coinMockQuerier) IsCosmeticOwned(ctx context.Context, arg database.IsCosmeticOwnedParams) (bool, error) {
+	for _, o := range m.owned {
+		if o.UserID == arg.UserID && o.ItemID == arg.ItemID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *coinMockQuerier) AddOwnedCosmetic(ctx context.Context, arg database.AddOwnedCosmeticParams) (int64, error) {
+	m.owned = append(m.owned, arg)
+	return 1, nil
+}
+
+func (m *coinMockQuerier) ListOwnedCosmetics(ctx context.Context, userID uuid.UUID) ([]database.UserCosmetic, error) {
+	var rows []database.UserCosmetic
+	for _, o := range m.owned {
+		if o.UserID == userID {
+			rows = append(rows, database.UserCosmetic{UserID: o.UserID, ItemID: o.ItemID, TransferID: o.TransferID})
+		}
+	}
+	return rows, nil
+}
+
+func (m *coinMockQuerier) EquipCosmetic(ctx context.Context, arg database.EquipCosmeticParams) error {
+	m.equipped[arg.Slot] = arg.ItemID
+	return nil
+}
+
+func (m *coinMockQuerier) UnequipCosmetic(ctx context.Context, arg database.UnequipCosmeticParams) (int64, error) {
+	delete(m.equipped, arg.Slot)
+	return 1, nil
+}
+
+func (m *coinMockQuerier) ListEquippedCosmetics(ctx context.Context, userID uuid.UUID) ([]database.EquippedCosmetic, error) {
+	var rows []database.EquippedCosmetic
+	for slot, item := range m.equipped {
+		rows = append(rows, database.EquippedCosmetic{UserID: userID, Slot: slot, ItemID: item})
+	}
+	return rows, nil
+}
+
+func newShopTest(t *testing.T, coins int64) (*coinMockQuerier, ShopService, uuid.UUID) {
+	t.Helper()
+	db := newCoinMockQuerier()
+	userID := uuid.New()
+	if err := rewardCoins(context.Background(), &db, userID, coins, shop.TransferBattle, "ABC123"); err != nil {
+		t.Fatalf("rewardCoins: %v", err)
+	}
+	return &db, NewShopService(&MockTxManager{db: &db}, &db), userID
+}
+
+func TestShopService_Purchase(t *testing.T) {
+	db, svc, userID := newShopTest(t, 100)
+	ctx := context.Background()
+
+	res, err := svc.Purchase(ctx, userID, "color_sakura")
+	if err != nil {
+		t.Fatalf("Purchase: %v", err)
+	}
+	if res.Balance != 50 || !res.Item.Owned {
+		t.Errorf("Expected 50 coins left, got %+v", res)
+	}
+
+	// Every transfer balances, and the shop holds what the user spent
+	var sum int64
+	for _, e := range db.entries {
+		sum += e.Amount
+	}
+	if sum != 0 || db.systemBalance(2) != 50 || db.systemBalance(1) != -100 {
+		t.Errorf("Unbalanced ledger: %+v %+v", db.entries, db.accounts)
+	}
+
+	if _, err := svc.Purchase(ctx, userID, "color_sakura"); !errors.Is(err, ErrItemOwned) {
+		t.Errorf("Expected ErrItemOwned, got %v", err)
+	}
+	if _, err := svc.Purchase(ctx, userID, "color_gold"); !errors.Is(err, ErrInsufficientCoins) {
+		t.Errorf("Expected ErrInsufficientCoins, got %v", err)
+	}
+	if _, err := svc.Purchase(ctx, userID, "color_plaid"); !errors.Is(err, ErrUnknownItem) {
+		t.Errorf("Expected ErrUnknownItem, got %v", err)
+	}
+	if len(db.transfers) != 2 {
+		t.Errorf("Expected failed purchases to move no coins, got %+v", db.transfers)
+	}
+}
+
+func TestShopService_Equip(t *testing.T) {
+	_, svc, userID := newShopTest(t, 200)
+	ctx := context.Background()
+
+	if _, err := svc.Equip(ctx, userID, "frame_wave"); !errors.Is(err, ErrItemNotOwned) {
+		t.Errorf("Expected ErrItemNotOwned, got %v", err)
+	}
+	if _, err := svc.Purchase(ctx, userID, "frame_wave"); err != nil {
+		t.Fatalf("Purchase: %v", err)
+	}
+	res, err := svc.Equip(ctx, userID, "frame_wave")
+	if err != nil {
+		t.Fatalf("Equip: %v", err)
+	}
+	if res.Equipped[shop.KindFrame] != "frame_wave" {
+		t.Errorf("Expected the frame equipped, got %v", res.Equipped)
+	}
+
+	catalog, err := svc.Catalog(ctx, userID)
+	if err != nil {
+		t.Fatalf("Catalog: %v", err)
+	}
+	for _, it := range catalog.Items {
+		if (it.ID == "frame_wave") != (it.Owned && it.Equipped) {
+			t.Errorf("Unexpected catalog item %+v", it)
+		}
+	}
+
+	if _, err := svc.Unequip(ctx, userID, "hat"); !errors.Is(err, ErrUnknownSlot) {
+		t.Errorf("Expected ErrUnknownSlot, got %v", err)
+	}
+	if res, err := svc.Unequip(ctx, userID, shop.KindFrame); err != nil || len(res.Equipped) != 0 {
+		t.Errorf("Expected nothing equipped, got %v, %v", res, err)
+	}
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/sprint"
+	"github.com/google/uuid"
+)
+
+// Runs shown in a group set's history
+const sprintHistoryLimit = 30
+
+var ErrSprintAlreadyFinished = errors.New("sprint already finished")
+
+type SprintService interface {
+	Start(ctx context.Context, userID uuid.UUID, params dto.SprintStartRequest) (dto.SprintStartResponse, error)
+	Finish(ctx context.Context, userID uuid.UUID, params dto.SprintFinishRequest) (dto.SprintResultResponse, error)
+	Stats(ctx context.Context, userID uuid.UUID, groups []string, duration int) (dto.SprintStatsResponse, error)
+}
+
+type sprintService struct {
+	db     database.Querier
+	secret string
+	now    func() time.Time
+}
+
+// NewSprintService returns a service signing sprint challenges with secret.
+// Nothing is stored until a sprint is finished.
+func NewSprintService(db database.Querier, secret string) SprintService {
+	return &sprintService{
+		db:     db,
+		secret: secret,
+		now:    time.Now,
+	}
+}
+
+func (s *sprintService) Start(ctx context.Context, userID uuid.UUID, params dto.SprintStartRequest) (dto.SprintStartResponse, error) {
+	if !sprint.ValidDuration(params.Duration) {
+		return dto.SprintStartResponse{}, sprint.ErrInvalidDuration
+	}
+	pool, err := kana.CharsFor(params.Groups)
+	if err != nil {
+		return dto.SprintStartResponse{}, err
+	}
+
+	challenge := sprint.Challenge{
+		UserID:   userID,
+		Groups:   params.Groups,
+		Duration: params.Duration,
+		Seed:     rand.Int64(),
+		// Signed tokens keep whole seconds
+		StartedAt: s.now().Truncate(time.Second),
+	}
+	token, err := sprint.Sign(challenge, s.secret)
+	if err != nil {
+		return dto.SprintStartResponse{}, err
+	}
+
+	return dto.SprintStartResponse{
+		Challenge: token,
+		Groups:    challenge.Groups,
+		Duration:  challenge.Duration,
+		StartedAt: challenge.StartedAt,
+		EndsAt:    challenge.EndsAt(),
+		Sequence:  sprint.Sequence(pool, challenge.Seed, challenge.Length()),
+	}, nil
+}
+
+// Finish grades a sprint submitted before its challenge expired. Each
+// challenge scores once.
+func (s *sprintService) Finish(ctx context.Context, userID uuid.UUID, params dto.SprintFinishRequest) (dto.SprintResultResponse, error) {
+	challenge, err := sprint.Verify(params.Challenge, userID, s.secret)
+	if err != nil {
+		return dto.SprintResultResponse{}, err
+	}
+	pool, err := kana.CharsFor(challenge.Groups)
+	if err != nil {
+		return dto.SprintResultResponse{}, err
+	}
+	systems, err := userRomajiSystems(ctx, s.db, userID)
+	if err != nil {
+		return dto.SprintResultResponse{}, err
+	}
+	correct, answered := sprint.Grade(sprint.Sequence(pool, challenge.Seed, challenge.Length()), params.Answers, systems...)
+
+	groupSet := sprint.GroupSet(challenge.Groups)
+	previous, err := s.db.GetSprintBest(ctx, database.GetSprintBestParams{
+		UserID:   userID,
+		GroupSet: groupSet,
+		Duration: int32(challenge.Duration),
+	})
+	if err != nil {
+		return dto.SprintResultResponse{}, err
+	}
+
+	_, err = s.db.CreateSprintRun(ctx, database.CreateSprintRunParams{
+		UserID:    userID,
+		GroupSet:  groupSet,
+		Duration:  int32(challenge.Duration),
+		Seed:      challenge.Seed,
+		Correct:   int32(correct),
+		Answered:  int32(answered),
+		StartedAt: challenge.StartedAt,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.SprintResultResponse{}, ErrSprintAlreadyFinished
+	}
+	if err != nil {
+		return dto.SprintResultResponse{}, err
+	}
+
+	best := max(int(previous.Best), correct)
+	percentile, err := s.percentile(ctx, groupSet, challenge.Duration, best)
+	if err != nil {
+		return dto.SprintResultResponse{}, err
+	}
+
+	return dto.SprintResultResponse{
+		Groups:     challenge.Groups,
+		Duration:   challenge.Duration,
+		Correct:    correct,
+		Answered:   answered,
+		Best:       best,
+		NewBest:    previous.Runs == 0 || correct > int(previous.Best),
+		Percentile: percentile,
+	}, nil
+}
+
+func (s *sprintService) Stats(ctx context.Context, userID uuid.UUID, groups []string, duration int) (dto.SprintStatsResponse, error) {
+	if !sprint.ValidDuration(duration) {
+		return dto.SprintStatsResponse{}, sprint.ErrInvalidDuration
+	}
+	if _, err := kana.CharsFor(groups); err != nil {
+		return dto.SprintStatsResponse{}, err
+	}
+	groupSet := sprint.GroupSet(groups)
+
+	best, err := s.db.GetSprintBest(ctx, database.GetSprintBestParams{
+		UserID:   userID,
+		GroupSet: groupSet,
+		Duration: int32(duration),
+	})
+	if err != nil {
+		return dto.SprintStatsResponse{}, err
+	}
+	rows, err := s.db.ListSprintHistory(ctx, database.ListSprintHistoryParams{
+		UserID:   userID,
+		GroupSet: groupSet,
+		Duration: int32(duration),
+		Limit:    sprintHistoryLimit,
+	})
+	if err != nil {
+		return dto.SprintStatsResponse{}, err
+	}
+
+	res := dto.SprintStatsResponse{
+		Groups:   strings.Split(groupSet, ","),
+		Duration: duration,
+		Best:     int(best.Best),
+		Runs:     int(best.Runs),
+		History:  make([]dto.SprintRunResponse, len(rows)),
+	}
+	for i, row := range rows {
+		res.History[i] = dto.SprintRunResponse{
+			Correct:    int(row.Correct),
+			Answered:   int(row.Answered),
+			FinishedAt: row.FinishedAt,
+		}
+	}
+	// Oldest first, for the trend
+	slices.Reverse(res.History)
+
+	if best.Runs > 0 {
+		percentile, err := s.percentile(ctx, groupSet, duration, res.Best)
+		if err != nil {
+			return dto.SprintStatsResponse{}, err
+		}
+		res.Percentile = &percentile
+	}
+	return res, nil
+}
+
+func (s *sprintService) percentile(ctx context.Context, groupSet string, duration, best int) (float64, error) {
+	row, err := s.db.GetSprintPercentile(ctx, database.GetSprintPercentileParams{
+		GroupSet: groupSet,
+		Duration: int32(duration),
+		Best:     int32(best),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return sprint.Percentile(int(row.Below), int(row.Players)), nil
+}
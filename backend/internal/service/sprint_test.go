@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+	"github.com/Cadimodev/haiji/backend/internal/dto"
+	"github.com/Cadimodev/haiji/backend/internal/sprint"
+	"github.com/google/uuid"
+)
+
+type sprintMockQuerier struct {
+	database.Querier
+	runs []database.SprintRun
+}
+
+func (m *sprintMockQuerier) GetUserByID(ctx context.Context, id uuid.UUID) (database.User, error) {
+	return database.User{ID: id}, nil
+}
+
+func (m *sprintMockQuerier) CreateSprintRun(ctx context.Context, arg database.CreateSprintRunParams) (database.SprintRun, error) {
+	for _, run := range m.runs {
+		if run.UserID == arg.UserID && run.Seed == arg.Seed {
+			return database.SprintRun{}, sql.ErrNoRows
+		}
+	}
+	run := database.SprintRun{
+		ID:         int64(len(m.runs) + 1),
+		UserID:     arg.UserID,
+		GroupSet:   arg.GroupSet,
+		Duration:   arg.Duration,
+		Seed:       arg.Seed,
+		Correct:    arg.Correct,
+		Answered:   arg.Answered,
+		StartedAt:  arg.StartedAt,
+		FinishedAt: arg.StartedAt.Add(time.Duration(len(m.runs)) * time.Minute),
+	}
+	m.runs = append(m.runs, run)
+	return run, nil
+}
+
+func (m *sprintMockQuerier) bests(groupSet string, duration int32) map[uuid.UUID]int32 {
+	bests := make(map[uuid.UUID]int32)
+	for _, run := range m.runs {
+		if run.GroupSet == groupSet && run.Duration == duration {
+			if best, ok := bests[run.UserID]; !ok || run.Correct > best {
+				bests[run.UserID] = run.Correct
+			}
+		}
+	}
+	return bests
+}
+
+func (m *sprintMockQuerier) GetSprintBest(ctx context.Context, arg database.GetSprintBestParams) (database.GetSprintBestRow, error) {
+	var row database.GetSprintBestRow
+	for _, run := range m.runs {
+		if run.UserID == arg.UserID && run.GroupSet == arg.GroupSet && run.Duration == arg.Duration {
+			row.Runs++
+			row.Best = max(row.Best, run.Correct)
+		}
+	}
+	return row, nil
+}
+
+func (m *sprintMockQuerier) ListSprintHistory(ctx context.Context, arg database.ListSprintHistoryParams) ([]database.SprintRun, error) {
+	var runs []database.SprintRun
+	for i := len(m.runs) - 1; i >= 0 && len(runs) < int(arg.Limit); i-- {
+		run := m.runs[i]
+		if run.UserID == arg.UserID && run.GroupSet == arg.GroupSet && run.Duration == arg.Duration {
+			runs = append(runs, run)
+		}
+	}
+	return runs, nil
+}
+
+func (m *sprintMockQuerier) GetSprintPercentile(ctx context.Context, arg database.GetSprintPercentileParams) (database.GetSprintPercentileRow, error) {
+	var row database.GetSprintPercentileRow
+	for _, best := range m.bests(arg.GroupSet, arg.Duration) {
+		row.Players++
+		if best < arg.Best {
+			row.Below++
+		}
+	}
+	return row, nil
+}
+
+func TestSprintService_FinishGradesAndRanks(t *testing.T) {
+	db := &sprintMockQuerier{}
+	svc := NewSprintService(db, "secret")
+	ctx := context.Background()
+	userID := uuid.New()
+
+	// Someone else already ran the same groups, in another order
+	db.runs = append(db.runs, database.SprintRun{UserID: uuid.New(), GroupSet: "hk,hsingle", Duration: 60, Correct: 3})
+
+	started, err := svc.Start(ctx, userID, dto.SprintStartRequest{Groups: []string{"hsingle", "hk"}, Duration: 60})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if len(started.Sequence) != 60*4 || !started.EndsAt.Equal(started.StartedAt.Add(time.Minute)) {
+		t.Fatalf("Unexpected sprint %d chars ending %v", len(started.Sequence), started.EndsAt)
+	}
+
+	answers := []string{started.Sequence[0].Romanji, started.Sequence[1].Romanji, "x", started.Sequence[3].Romanji, started.Sequence[4].Romanji}
+	result, err := svc.Finish(ctx, userID, dto.SprintFinishRequest{Challenge: started.Challenge, Answers: answers})
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if result.Correct != 4 || result.Answered != 5 || !result.NewBest || result.Best != 4 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if result.Percentile != 100 {
+		t.Errorf("Expected to beat the other player, got %v", result.Percentile)
+	}
+
+	// A challenge scores once
+	if _, err := svc.Finish(ctx, userID, dto.SprintFinishRequest{Challenge: started.Challenge, Answers: answers}); !errors.Is(err, ErrSprintAlreadyFinished) {
+		t.Errorf("Expected ErrSprintAlreadyFinished, got %v", err)
+	}
+
+	stats, err := svc.Stats(ctx, userID, []string{"hk", "hsingle"}, 60)
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.Best != 4 || stats.Runs != 1 || len(stats.History) != 1 || stats.Percentile == nil || *stats.Percentile != 100 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+func TestSprintService_RejectsLateAndForeignChallenges(t *testing.T) {
+	db := &sprintMockQuerier{}
+	svc := NewSprintService(db, "secret").(*sprintService)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	svc.now = func() time.Time { return time.Now().Add(-2 * time.Minute) }
+	started, err := svc.Start(ctx, userID, dto.SprintStartRequest{Groups: []string{"hsingle"}, Duration: 60})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if _, err := svc.Finish(ctx, userID, dto.SprintFinishRequest{Challenge: started.Challenge}); !errors.Is(err, sprint.ErrExpiredChallenge) {
+		t.Errorf("Expected ErrExpiredChallenge, got %v", err)
+	}
+
+	svc.now = time.Now
+	started, _ = svc.Start(ctx, userID, dto.SprintStartRequest{Groups: []string{"hsingle"}, Duration: 60})
+	if _, err := svc.Finish(ctx, uuid.New(), dto.SprintFinishRequest{Challenge: started.Challenge}); !errors.Is(err, sprint.ErrInvalidChallenge) {
+		t.Errorf("Expected ErrInvalidChallenge, got %v", err)
+	}
+	if len(db.runs) != 0 {
+		t.Errorf("Expected nothing stored, got %d runs", len(db.runs))
+	}
+
+	if _, err := svc.Start(ctx, userID, dto.SprintStartRequest{Groups: []string{"hsingle"}, Duration: 90}); !errors.Is(err, sprint.ErrInvalidDuration) {
+		t.Errorf("Expected ErrInvalidDuration, got %v", err)
+	}
+}
@@ -0,0 +1,245 @@
+// Package sharecard renders the results of a finished game as a PNG image
+// for players to share.
+package sharecard
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// M+ 1p covers kana and kanji as well as Latin text; see
+// assets/LICENSE-mplus.txt.
+//
+//go:embed assets/mplus-1p-regular.ttf
+var fontData []byte
+
+// A copy of frontend/src/assets/HaijiLogo.jpeg. Keep both in sync.
+//
+//go:embed assets/haiji-logo.jpeg
+var logoData []byte
+
+// Cards are sized for link previews.
+const (
+	Width  = 1200
+	Height = 630
+
+	margin   = 60
+	logoSize = 140
+	rowH     = 50
+	// Rows in the standings panel; further players are summed up in the last
+	maxRows = 6
+)
+
+// Colours of the logo.
+var (
+	green = color.RGBA{16, 94, 72, 255}
+	cream = color.RGBA{249, 247, 236, 255}
+	tan   = color.RGBA{226, 182, 140, 255}
+	brown = color.RGBA{74, 46, 42, 255}
+	muted = color.RGBA{176, 206, 194, 255}
+
+	medals = []color.RGBA{{212, 175, 55, 255}, {170, 170, 178, 255}, {176, 110, 60, 255}}
+)
+
+var regular = func() *opentype.Font {
+	f, err := opentype.Parse(fontData)
+	if err != nil {
+		panic(fmt.Sprintf("sharecard: parsing font: %v", err))
+	}
+	return f
+}()
+
+// The logo, scaled once to the size it's drawn at
+var logo = func() image.Image {
+	src, _, err := image.Decode(bytes.NewReader(logoData))
+	if err != nil {
+		panic(fmt.Sprintf("sharecard: decoding logo: %v", err))
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, logoSize, logoSize))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Src, nil)
+	return dst
+}()
+
+// Card is what a share card shows.
+type Card struct {
+	Groups     []string
+	Standings  []Standing // best first
+	FinishedAt time.Time
+}
+
+type Standing struct {
+	Username  string
+	Score     int
+	Placement int
+	Ghost     bool
+}
+
+// Render draws the card as a PNG.
+func Render(w io.Writer, c Card) error {
+	faces, err := newFaces()
+	if err != nil {
+		return err
+	}
+	defer faces.close()
+
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	fill(img, img.Bounds(), green)
+
+	// Branding
+	draw.Draw(img, image.Rect(margin, margin-20, margin+logoSize, margin-20+logoSize), logo, image.Point{}, draw.Over)
+	textX := margin + logoSize + 30
+	drawText(img, faces.title, cream, textX, 110, "Haiji")
+	drawText(img, faces.body, tan, textX, 160, "Battle results · 対戦結果")
+
+	groups := make([]string, len(c.Groups))
+	for i, g := range c.Groups {
+		groups[i] = GroupLabel(g)
+	}
+	drawText(img, faces.small, muted, margin, 230, ellipsize(faces.small, strings.Join(groups, " · "), Width-2*margin))
+
+	// Standings
+	panel := image.Rect(margin, 260, Width-margin, 260+maxRows*rowH+20)
+	fill(img, panel, cream)
+	rows := c.Standings
+	more := 0
+	if len(rows) > maxRows {
+		more = len(rows) - (maxRows - 1)
+		rows = rows[:maxRows-1]
+	}
+	for i, s := range rows {
+		baseline := panel.Min.Y + 10 + i*rowH + 36
+		drawPlacement(img, faces.body, panel.Min.X+40, baseline, s.Placement)
+
+		name := s.Username
+		if s.Ghost {
+			name += " (ghost)"
+		}
+		scoreText := strconv.Itoa(s.Score)
+		scoreW := font.MeasureString(faces.body, scoreText).Ceil()
+		drawText(img, faces.body, brown, panel.Min.X+100, baseline, ellipsize(faces.body, name, panel.Dx()-160-scoreW))
+		drawText(img, faces.body, brown, panel.Max.X-30-scoreW, baseline, scoreText)
+	}
+	if more > 0 {
+		baseline := panel.Min.Y + 10 + len(rows)*rowH + 36
+		drawText(img, faces.body, brown, panel.Min.X+100, baseline, fmt.Sprintf("+%d more", more))
+	}
+
+	if !c.FinishedAt.IsZero() {
+		drawText(img, faces.small, muted, margin, Height-22, c.FinishedAt.UTC().Format("2 January 2006"))
+	}
+
+	return png.Encode(w, img)
+}
+
+// GroupLabel names a group the way players see it.
+func GroupLabel(id string) string {
+	if g, ok := kana.GetGroup(id); ok {
+		return g.Label
+	}
+	if code, ok := kana.DeckCode(id); ok {
+		return "Deck " + code
+	}
+	if level, ok := kana.KanjiLevel(id); ok {
+		return fmt.Sprintf("JLPT %d kanji", level)
+	}
+	return id
+}
+
+type faces struct {
+	title, body, small font.Face
+}
+
+// newFaces returns the faces of a render; faces aren't safe for concurrent
+// use, so renders don't share them.
+func newFaces() (*faces, error) {
+	var f faces
+	for _, face := range []struct {
+		dst  *font.Face
+		size float64
+	}{{&f.title, 72}, {&f.body, 30}, {&f.small, 24}} {
+		var err error
+		*face.dst, err = opentype.NewFace(regular, &opentype.FaceOptions{Size: face.size, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			f.close()
+			return nil, err
+		}
+	}
+	return &f, nil
+}
+
+func (f *faces) close() {
+	for _, face := range []font.Face{f.title, f.body, f.small} {
+		if face != nil {
+			face.Close()
+		}
+	}
+}
+
+// drawPlacement draws a placement, on a medal for the podium.
+func drawPlacement(img *image.RGBA, face font.Face, x, baseline, placement int) {
+	text := strconv.Itoa(placement)
+	textW := font.MeasureString(face, text).Ceil()
+	col := brown
+	if placement >= 1 && placement <= len(medals) {
+		cx, cy, r := x, baseline-11, 20
+		fillCircle(img, cx, cy, r, medals[placement-1])
+		col = cream
+	}
+	drawText(img, face, col, x-textW/2, baseline, text)
+}
+
+func drawText(img *image.RGBA, face font.Face, col color.Color, x, baseline int, text string) {
+	d := font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(col),
+		Face: face,
+		Dot:  fixed.P(x, baseline),
+	}
+	d.DrawString(text)
+}
+
+// ellipsize shortens text to fit in width pixels.
+func ellipsize(face font.Face, text string, width int) string {
+	if font.MeasureString(face, text).Ceil() <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		short := string(runes) + "…"
+		if font.MeasureString(face, short).Ceil() <= width {
+			return short
+		}
+	}
+	return ""
+}
+
+func fill(img *image.RGBA, r image.Rectangle, col color.Color) {
+	draw.Draw(img, r, image.NewUniform(col), image.Point{}, draw.Src)
+}
+
+func fillCircle(img *image.RGBA, cx, cy, r int, col color.RGBA) {
+	for y := -r; y <= r; y++ {
+		for x := -r; x <= r; x++ {
+			if x*x+y*y <= r*r {
+				img.SetRGBA(cx+x, cy+y, col)
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package sharecard
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+
+	"golang.org/x/image/font"
+)
+
+func TestRender(t *testing.T) {
+	c := Card{
+		Groups: []string{"hsingle", "deck:ABC123", "kanji:jlpt4"},
+		Standings: []Standing{
+			{Username: "alice", Score: 1200, Placement: 1},
+			{Username: "bob", Score: 900, Placement: 2},
+			{Username: "carol", Score: 900, Placement: 2, Ghost: true},
+		},
+		FinishedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+	}
+	for i := 0; i < 6; i++ {
+		c.Standings = append(c.Standings, Standing{Username: "player", Score: 100, Placement: 4})
+	}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, c); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Expected a PNG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != Width || b.Dy() != Height {
+		t.Errorf("Expected %dx%d, got %v", Width, Height, b)
+	}
+}
+
+func TestGroupLabel(t *testing.T) {
+	for id, want := range map[string]string{
+		"hsingle":     "あ-row",
+		"deck:ABC123": "Deck ABC123",
+		"kanji:jlpt2": "JLPT 2 kanji",
+	} {
+		if got := GroupLabel(id); got != want {
+			t.Errorf("GroupLabel(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestEllipsize(t *testing.T) {
+	f, _ := newFaces()
+	defer f.close()
+	if got := ellipsize(f.body, "short", 1000); got != "short" {
+		t.Errorf("Expected text that fits unchanged, got %q", got)
+	}
+	long := "averyveryverylongusernamethatdoesnotfit"
+	if got := ellipsize(f.body, long, 200); len(got) >= len(long) || font.MeasureString(f.body, got).Ceil() > 200 {
+		t.Errorf("Expected %q shortened to 200px, got %q", long, got)
+	}
+}
@@ -0,0 +1,93 @@
+// Package shop declares the cosmetics catalog and how many coins activity
+// earns. Coins move between ledger accounts: rewards are paid out of the
+// rewards account and purchases are paid into the shop account.
+package shop
+
+// Cosmetic kinds. A user equips at most one item of each kind, so kinds are
+// also the equipment slots.
+const (
+	KindNameColor = "name_color"
+	KindFrame     = "frame"
+	KindEmote     = "emote"
+)
+
+// System ledger accounts, created by the migration.
+const (
+	AccountRewards = "rewards"
+	AccountShop    = "shop"
+)
+
+// Transfer kinds, recorded with every ledger transfer.
+const (
+	TransferBattle   = "battle"
+	TransferStreak   = "streak"
+	TransferPurchase = "purchase"
+)
+
+type Item struct {
+	ID    string `json:"id"`
+	Kind  string `json:"kind"`
+	Name  string `json:"name"`
+	Price int64  `json:"price"`
+	// What the client renders: a colour, a frame style or an emote
+	Value string `json:"value"`
+}
+
+// Items in display order.
+var Items = []Item{
+	{ID: "color_sakura", Kind: KindNameColor, Name: "Sakura Pink", Price: 50, Value: "#f4a7b9"},
+	{ID: "color_matcha", Kind: KindNameColor, Name: "Matcha Green", Price: 50, Value: "#8db255"},
+	{ID: "color_indigo", Kind: KindNameColor, Name: "Indigo", Price: 100, Value: "#3f51b5"},
+	{ID: "color_gold", Kind: KindNameColor, Name: "Gold", Price: 300, Value: "#d4af37"},
+	{ID: "frame_wave", Kind: KindFrame, Name: "Great Wave", Price: 150, Value: "wave"},
+	{ID: "frame_torii", Kind: KindFrame, Name: "Torii", Price: 200, Value: "torii"},
+	{ID: "frame_fuji", Kind: KindFrame, Name: "Mount Fuji", Price: 400, Value: "fuji"},
+	{ID: "emote_bow", Kind: KindEmote, Name: "Bow", Price: 75, Value: "🙇"},
+	{ID: "emote_fire", Kind: KindEmote, Name: "On Fire", Price: 75, Value: "🔥"},
+	{ID: "emote_cat", Kind: KindEmote, Name: "Lucky Cat", Price: 150, Value: "🐱"},
+}
+
+func GetItem(id string) (Item, bool) {
+	for _, it := range Items {
+		if it.ID == id {
+			return it, true
+		}
+	}
+	return Item{}, false
+}
+
+func ValidKind(kind string) bool {
+	return kind == KindNameColor || kind == KindFrame || kind == KindEmote
+}
+
+// Every finished battle earns coins; the podium earns a bonus on top.
+const CoinsPerBattle = 5
+
+var placementCoins = map[int]int64{1: 15, 2: 8, 3: 4}
+
+// Every day a streak is kept earns coins, and every full week a bonus.
+const (
+	CoinsPerStreakDay  = 2
+	CoinsPerStreakWeek = 25
+)
+
+// BattleCoins rewards a battle finish. Solo games don't earn a placement
+// bonus.
+func BattleCoins(placement, players int) int64 {
+	if players < 2 {
+		return CoinsPerBattle
+	}
+	return CoinsPerBattle + placementCoins[placement]
+}
+
+// StreakCoins rewards reaching day days of a streak.
+func StreakCoins(days int) int64 {
+	if days <= 0 {
+		return 0
+	}
+	coins := int64(CoinsPerStreakDay)
+	if days%7 == 0 {
+		coins += CoinsPerStreakWeek
+	}
+	return coins
+}
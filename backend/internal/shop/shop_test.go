@@ -0,0 +1,34 @@
+package shop
+
+import "testing"
+
+func TestCatalog(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, it := range Items {
+		if seen[it.ID] {
+			t.Errorf("Duplicate item %s", it.ID)
+		}
+		seen[it.ID] = true
+		if !ValidKind(it.Kind) || it.Price <= 0 || it.Value == "" {
+			t.Errorf("Invalid item %+v", it)
+		}
+	}
+	if _, ok := GetItem("color_gold"); !ok {
+		t.Error("Expected to find color_gold")
+	}
+}
+
+func TestCoins(t *testing.T) {
+	if got := BattleCoins(1, 1); got != CoinsPerBattle {
+		t.Errorf("Expected no bonus for a solo game, got %d", got)
+	}
+	if got := BattleCoins(1, 4); got != CoinsPerBattle+15 {
+		t.Errorf("Expected the winner's bonus, got %d", got)
+	}
+	if got := StreakCoins(6); got != CoinsPerStreakDay {
+		t.Errorf("Expected a day's coins, got %d", got)
+	}
+	if got := StreakCoins(14); got != CoinsPerStreakDay+CoinsPerStreakWeek {
+		t.Errorf("Expected the weekly bonus, got %d", got)
+	}
+}
@@ -0,0 +1,159 @@
+// Package sprint holds the rules of solo sprints: how a sprint's characters
+// are drawn, how it is graded, and the signed challenge that lets the server
+// time a sprint without keeping any state until it is finished.
+package sprint
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/Cadimodev/haiji/backend/internal/romaji"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Durations are the sprint lengths offered, in seconds.
+var Durations = []int{60, 120}
+
+const (
+	// Characters drawn per second of a sprint, more than anyone can answer
+	charsPerSecond = 4
+	// Grace is how late a sprint may be submitted, for the network
+	Grace = 5 * time.Second
+
+	issuer = "haiji-sprint"
+)
+
+var (
+	ErrInvalidDuration  = fmt.Errorf("duration must be one of %v", Durations)
+	ErrInvalidChallenge = errors.New("invalid sprint challenge")
+	ErrExpiredChallenge = errors.New("sprint submitted too late")
+)
+
+// Challenge is a started sprint. It is handed to the player signed and
+// handed back with the answers.
+type Challenge struct {
+	UserID    uuid.UUID
+	Groups    []string
+	Duration  int // seconds
+	Seed      int64
+	StartedAt time.Time
+}
+
+// Length is the number of characters drawn for a sprint.
+func (c Challenge) Length() int {
+	return c.Duration * charsPerSecond
+}
+
+// EndsAt is when the sprint's answers stop counting.
+func (c Challenge) EndsAt() time.Time {
+	return c.StartedAt.Add(time.Duration(c.Duration) * time.Second)
+}
+
+func ValidDuration(seconds int) bool {
+	return slices.Contains(Durations, seconds)
+}
+
+// GroupSet is the key personal bests are kept under: the groups, sorted and
+// without repeats, so the same selection in any order is the same set.
+func GroupSet(groups []string) string {
+	set := slices.Clone(groups)
+	slices.Sort(set)
+	return strings.Join(slices.Compact(set), ",")
+}
+
+// Sequence deterministically draws length characters from pool for a seed.
+func Sequence(pool []kana.Char, seed int64, length int) []kana.Char {
+	if len(pool) == 0 {
+		return nil
+	}
+	r := rand.New(rand.NewPCG(uint64(seed), 0))
+	seq := make([]kana.Char, 0, length)
+	for len(seq) < length {
+		c := pool[r.IntN(len(pool))]
+		// Avoid the same prompt twice in a row
+		if len(pool) > 1 && len(seq) > 0 && seq[len(seq)-1].Kana == c.Kana {
+			continue
+		}
+		seq = append(seq, c)
+	}
+	return seq
+}
+
+// Grade counts the right answers, given in the order of the sequence.
+func Grade(seq []kana.Char, answers []string, systems ...romaji.System) (correct, answered int) {
+	for i, answer := range answers {
+		if i >= len(seq) {
+			break
+		}
+		answered++
+		if kana.IsCorrect(seq[i], answer, systems...) {
+			correct++
+		}
+	}
+	return correct, answered
+}
+
+// Percentile is the share of the other players whose best is lower, in
+// percent. A player alone on a group set is at the top.
+func Percentile(below, players int) float64 {
+	if players <= 1 {
+		return 100
+	}
+	return 100 * float64(below) / float64(players-1)
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+	Groups   []string `json:"groups"`
+	Duration int      `json:"duration"`
+	Seed     int64    `json:"seed"`
+}
+
+// Sign returns the challenge as a token that expires Grace after the sprint
+// ends.
+func Sign(c Challenge, secret string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   c.UserID.String(),
+			IssuedAt:  jwt.NewNumericDate(c.StartedAt),
+			ExpiresAt: jwt.NewNumericDate(c.EndsAt().Add(Grace)),
+		},
+		Groups:   c.Groups,
+		Duration: c.Duration,
+		Seed:     c.Seed,
+	})
+	return token.SignedString([]byte(secret))
+}
+
+// Verify checks the token was signed by Sign for the user and hasn't
+// expired.
+func Verify(token string, userID uuid.UUID, secret string) (Challenge, error) {
+	var cl claims
+	_, err := jwt.ParseWithClaims(token, &cl,
+		func(token *jwt.Token) (interface{}, error) { return []byte(secret), nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithSubject(userID.String()),
+		jwt.WithIssuedAt(),
+	)
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return Challenge{}, ErrExpiredChallenge
+	}
+	if err != nil || cl.IssuedAt == nil || !ValidDuration(cl.Duration) {
+		return Challenge{}, ErrInvalidChallenge
+	}
+	return Challenge{
+		UserID:    userID,
+		Groups:    cl.Groups,
+		Duration:  cl.Duration,
+		Seed:      cl.Seed,
+		StartedAt: cl.IssuedAt.Time,
+	}, nil
+}
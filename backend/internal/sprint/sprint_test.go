@@ -0,0 +1,80 @@
+package sprint
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/kana"
+	"github.com/google/uuid"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	userID := uuid.New()
+	c := Challenge{
+		UserID:    userID,
+		Groups:    []string{"hsingle", "hdakuten"},
+		Duration:  60,
+		Seed:      42,
+		StartedAt: time.Now().Truncate(time.Second),
+	}
+	token, err := Sign(c, "secret")
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	got, err := Verify(token, userID, "secret")
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !reflect.DeepEqual(got.Groups, c.Groups) || got.Seed != c.Seed || !got.StartedAt.Equal(c.StartedAt) {
+		t.Errorf("Expected %+v, got %+v", c, got)
+	}
+
+	if _, err := Verify(token, uuid.New(), "secret"); !errors.Is(err, ErrInvalidChallenge) {
+		t.Errorf("Expected another user's challenge rejected, got %v", err)
+	}
+	if _, err := Verify(token, userID, "other"); !errors.Is(err, ErrInvalidChallenge) {
+		t.Errorf("Expected a forged challenge rejected, got %v", err)
+	}
+
+	// Submitted after the sprint and its grace period
+	c.StartedAt = time.Now().Add(-time.Minute - Grace - time.Second)
+	token, _ = Sign(c, "secret")
+	if _, err := Verify(token, userID, "secret"); !errors.Is(err, ErrExpiredChallenge) {
+		t.Errorf("Expected ErrExpiredChallenge, got %v", err)
+	}
+}
+
+func TestSequenceAndGrade(t *testing.T) {
+	pool := []kana.Char{{Kana: "あ", Romanji: "a"}, {Kana: "い", Romanji: "i"}, {Kana: "う", Romanji: "u"}}
+	seq := Sequence(pool, 7, 10)
+	if !reflect.DeepEqual(seq, Sequence(pool, 7, 10)) {
+		t.Fatal("Expected the same sequence for the same seed")
+	}
+
+	answers := []string{seq[0].Romanji, "x", seq[2].Romanji}
+	if correct, answered := Grade(seq, answers); correct != 2 || answered != 3 {
+		t.Errorf("Expected 2 of 3, got %d of %d", correct, answered)
+	}
+	// Answers past the sequence don't count
+	if _, answered := Grade(seq[:1], answers); answered != 1 {
+		t.Errorf("Expected 1 answer graded, got %d", answered)
+	}
+}
+
+func TestGroupSet(t *testing.T) {
+	if got := GroupSet([]string{"hsingle", "hdakuten", "hsingle"}); got != "hdakuten,hsingle" {
+		t.Errorf("Unexpected group set %q", got)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	if got := Percentile(0, 1); got != 100 {
+		t.Errorf("Expected a lone player at 100, got %v", got)
+	}
+	if got := Percentile(3, 5); got != 75 {
+		t.Errorf("Expected 75, got %v", got)
+	}
+}
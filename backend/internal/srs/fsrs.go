@@ -0,0 +1,162 @@
+package srs
+
+import (
+	"math"
+	"time"
+)
+
+// FSRS-4.5 forgetting curve constants.
+const (
+	fsrsDecay  = -0.5
+	fsrsFactor = 19.0 / 81.0
+)
+
+// DefaultWeights are the published FSRS-4.5 default parameters.
+var DefaultWeights = [17]float64{
+	0.4872, 1.4003, 3.7145, 13.8206, 5.1618, 1.2298, 0.8975, 0.031,
+	1.6474, 0.1367, 1.0461, 2.1072, 0.0793, 0.3246, 1.587, 0.2272, 2.8755,
+}
+
+// FSRS is the Free Spaced Repetition Scheduler (v4.5).
+type FSRS struct {
+	Weights          [17]float64
+	RequestRetention float64 // target probability of recall when a card is due
+	MaximumInterval  int     // days
+}
+
+func NewFSRS() *FSRS {
+	return &FSRS{
+		Weights:          DefaultWeights,
+		RequestRetention: 0.9,
+		MaximumInterval:  365,
+	}
+}
+
+func (f *FSRS) Review(card Card, grade Grade, now time.Time) Card {
+	// Cards already in review without a memory state were scheduled by
+	// SM-2; keep them there for this answer and seed FSRS from the result.
+	if card.State != StateNew && card.Stability <= 0 {
+		return f.seedFromSM2(SM2{}.Review(card, grade, now), grade)
+	}
+
+	next := card
+	next.Reps++
+	next.LastReview = now
+	next.EaseFactor = sm2Ease(card.EaseFactor, grade)
+
+	switch card.State {
+	case StateNew:
+		next.Stability = f.initStability(grade)
+		next.Difficulty = f.initDifficulty(grade)
+		switch grade {
+		case Again:
+			next.State = StateLearning
+			next.Due = now.Add(againStep)
+		case Hard:
+			next.State = StateLearning
+			next.Due = now.Add(hardStep)
+		case Good:
+			next.State = StateLearning
+			next.Due = now.Add(goodStep)
+		case Easy:
+			f.schedule(&next, now)
+		}
+
+	case StateLearning, StateRelearning:
+		// Same-day steps don't change the memory state in FSRS-4.5
+		next.Difficulty = f.nextDifficulty(card.Difficulty, grade)
+		switch grade {
+		case Again:
+			next.Due = now.Add(againStep)
+		case Hard:
+			next.Due = now.Add(hardStep)
+		default:
+			f.schedule(&next, now)
+		}
+
+	case StateReview:
+		elapsed := math.Max(0, now.Sub(card.LastReview).Hours()/24)
+		r := f.retrievability(elapsed, card.Stability)
+		next.Difficulty = f.nextDifficulty(card.Difficulty, grade)
+		if grade == Again {
+			next.Stability = f.forgetStability(card.Difficulty, card.Stability, r)
+			next.Lapses++
+			next.State = StateRelearning
+			next.IntervalDays = 0
+			next.Due = now.Add(goodStep)
+		} else {
+			next.Stability = f.recallStability(card.Difficulty, card.Stability, r, grade)
+			f.schedule(&next, now)
+		}
+	}
+
+	if !finite(next.Stability) || !finite(next.Difficulty) {
+		return f.seedFromSM2(SM2{}.Review(card, grade, now), grade)
+	}
+	return next
+}
+
+// schedule moves the card to review, due after the interval its stability gives.
+func (f *FSRS) schedule(card *Card, now time.Time) {
+	card.State = StateReview
+	card.IntervalDays = f.interval(card.Stability)
+	card.Due = addDays(now, card.IntervalDays)
+}
+
+// seedFromSM2 gives a card scheduled by SM-2 an FSRS memory state so its
+// next review goes through FSRS.
+func (f *FSRS) seedFromSM2(card Card, grade Grade) Card {
+	card.Stability = math.Max(float64(card.IntervalDays), f.initStability(grade))
+	card.Difficulty = f.initDifficulty(grade)
+	return card
+}
+
+func (f *FSRS) interval(stability float64) int {
+	days := stability / fsrsFactor * (math.Pow(f.RequestRetention, 1/fsrsDecay) - 1)
+	return min(max(int(math.Round(days)), 1), f.MaximumInterval)
+}
+
+func (f *FSRS) retrievability(elapsedDays, stability float64) float64 {
+	return math.Pow(1+fsrsFactor*elapsedDays/stability, fsrsDecay)
+}
+
+func (f *FSRS) initStability(grade Grade) float64 {
+	return math.Max(f.Weights[grade-1], 0.1)
+}
+
+func (f *FSRS) initDifficulty(grade Grade) float64 {
+	return clampDifficulty(f.Weights[4] - float64(grade-3)*f.Weights[5])
+}
+
+func (f *FSRS) nextDifficulty(d float64, grade Grade) float64 {
+	next := d - f.Weights[6]*float64(grade-3)
+	// Mean reversion towards the difficulty of a first Good answer
+	return clampDifficulty(f.Weights[7]*f.initDifficulty(Good) + (1-f.Weights[7])*next)
+}
+
+func (f *FSRS) recallStability(d, s, r float64, grade Grade) float64 {
+	w := f.Weights
+	hardPenalty, easyBonus := 1.0, 1.0
+	if grade == Hard {
+		hardPenalty = w[15]
+	}
+	if grade == Easy {
+		easyBonus = w[16]
+	}
+	return s * (1 + math.Exp(w[8])*(11-d)*math.Pow(s, -w[9])*(math.Exp((1-r)*w[10])-1)*hardPenalty*easyBonus)
+}
+
+func (f *FSRS) forgetStability(d, s, r float64) float64 {
+	w := f.Weights
+	next := w[11] * math.Pow(d, -w[12]) * (math.Pow(s+1, w[13]) - 1) * math.Exp((1-r)*w[14])
+	// Forgetting never makes a card more stable than it was
+	return math.Min(next, s)
+}
+
+func clampDifficulty(d float64) float64 {
+	return math.Min(math.Max(d, 1), 10)
+}
+
+func finite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
@@ -0,0 +1,73 @@
+package srs
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	defaultEase = 2.5
+	minEase     = 1.3
+)
+
+// SM2 is the classic SuperMemo-2 algorithm, with the four grades mapped to
+// qualities 1 (Again), 3 (Hard), 4 (Good) and 5 (Easy).
+type SM2 struct{}
+
+func (SM2) Review(card Card, grade Grade, now time.Time) Card {
+	next := card
+	next.Reps++
+	next.LastReview = now
+	next.EaseFactor = sm2Ease(card.EaseFactor, grade)
+
+	if grade == Again {
+		if card.State == StateReview {
+			next.Lapses++
+			next.State = StateRelearning
+		} else if card.State == StateNew {
+			next.State = StateLearning
+		}
+		next.IntervalDays = 0
+		next.Due = now.Add(againStep)
+		return next
+	}
+
+	switch {
+	case card.IntervalDays == 0:
+		next.IntervalDays = 1
+	case card.IntervalDays == 1:
+		next.IntervalDays = 6
+	default:
+		next.IntervalDays = int(math.Round(float64(card.IntervalDays) * next.EaseFactor))
+	}
+	if grade == Hard && card.IntervalDays > 1 {
+		// Hard grows the interval less than the ease factor would
+		next.IntervalDays = max(card.IntervalDays+1, int(math.Round(float64(card.IntervalDays)*1.2)))
+	}
+
+	next.State = StateReview
+	next.Due = addDays(now, next.IntervalDays)
+	return next
+}
+
+func sm2Quality(grade Grade) float64 {
+	switch grade {
+	case Again:
+		return 1
+	case Hard:
+		return 3
+	case Good:
+		return 4
+	default:
+		return 5
+	}
+}
+
+func sm2Ease(ease float64, grade Grade) float64 {
+	if ease == 0 {
+		ease = defaultEase
+	}
+	q := sm2Quality(grade)
+	ease += 0.1 - (5-q)*(0.08+(5-q)*0.02)
+	return math.Max(minEase, ease)
+}
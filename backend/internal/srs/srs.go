@@ -0,0 +1,130 @@
+// Package srs schedules kana reviews with spaced repetition.
+//
+// FSRS is the default scheduler. SM-2 is kept as a fallback: every card also
+// carries SM-2 state, FSRS hands cards it can't model over to SM-2, and the
+// whole service can be switched to SM-2 with SRS_ALGORITHM=sm2.
+package srs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type State int16
+
+const (
+	StateNew State = iota
+	StateLearning
+	StateReview
+	StateRelearning
+)
+
+func (s State) String() string {
+	switch s {
+	case StateNew:
+		return "new"
+	case StateLearning:
+		return "learning"
+	case StateReview:
+		return "review"
+	case StateRelearning:
+		return "relearning"
+	default:
+		return fmt.Sprintf("State(%d)", int16(s))
+	}
+}
+
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Grade is how well an answer was recalled, from Again (forgotten) to Easy.
+type Grade int
+
+const (
+	Again Grade = iota + 1
+	Hard
+	Good
+	Easy
+)
+
+var ErrInvalidGrade = errors.New("grade must be between 1 (again) and 4 (easy)")
+
+func (g Grade) Valid() bool {
+	return g >= Again && g <= Easy
+}
+
+// Answers slower than these are downgraded: a correct but hesitant recall
+// isn't as strong as an instant one.
+const (
+	slowGoodMs = 3000
+	slowHardMs = 8000
+)
+
+// AdjustForLatency caps the grade of slow answers. Failed answers are kept.
+func AdjustForLatency(g Grade, responseMs int) Grade {
+	switch {
+	case g > Hard && responseMs > slowHardMs:
+		return Hard
+	case g > Good && responseMs > slowGoodMs:
+		return Good
+	}
+	return g
+}
+
+// Card is the memory state of one kana for one user.
+type Card struct {
+	State State
+
+	// FSRS memory state, zero until the card is first reviewed by FSRS
+	Stability  float64 // days until retrievability drops to 90%
+	Difficulty float64 // 1 (easy) to 10 (hard)
+
+	// SM-2 state, kept up to date by both schedulers
+	EaseFactor   float64
+	IntervalDays int
+
+	Reps       int
+	Lapses     int
+	Due        time.Time
+	LastReview time.Time // zero if never reviewed
+}
+
+// NewCard returns an unseen card due immediately.
+func NewCard(now time.Time) Card {
+	return Card{
+		State:      StateNew,
+		EaseFactor: defaultEase,
+		Due:        now,
+	}
+}
+
+type Scheduler interface {
+	// Review returns the card's state after answering it with grade at now.
+	Review(card Card, grade Grade, now time.Time) Card
+}
+
+// New returns the scheduler for an algorithm name: "fsrs" or "sm2".
+func New(algorithm string) (Scheduler, error) {
+	switch algorithm {
+	case "", "fsrs":
+		return NewFSRS(), nil
+	case "sm2":
+		return SM2{}, nil
+	default:
+		return nil, fmt.Errorf("unknown SRS algorithm %q", algorithm)
+	}
+}
+
+// Learning steps shared by both schedulers. Kana are drilled in short
+// sessions, so a forgotten card comes back within the same session.
+const (
+	againStep = time.Minute
+	hardStep  = 5 * time.Minute
+	goodStep  = 10 * time.Minute
+)
+
+func addDays(t time.Time, days int) time.Time {
+	return t.Add(time.Duration(days) * 24 * time.Hour)
+}
@@ -0,0 +1,136 @@
+package srs
+
+import (
+	"testing"
+	"time"
+)
+
+var t0 = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestFSRS_NewCardSteps(t *testing.T) {
+	f := NewFSRS()
+
+	again := f.Review(NewCard(t0), Again, t0)
+	if again.State != StateLearning || !again.Due.Equal(t0.Add(againStep)) {
+		t.Errorf("Again on new card: got state %v due %v", again.State, again.Due)
+	}
+
+	easy := f.Review(NewCard(t0), Easy, t0)
+	if easy.State != StateReview || easy.IntervalDays < 1 {
+		t.Errorf("Easy on new card should graduate, got %+v", easy)
+	}
+	if easy.Stability <= again.Stability || easy.Difficulty >= again.Difficulty {
+		t.Errorf("Easy should start more stable and easier than Again: %+v vs %+v", easy, again)
+	}
+}
+
+func TestFSRS_IntervalsGrowOnSuccess(t *testing.T) {
+	f := NewFSRS()
+	card := f.Review(NewCard(t0), Good, t0)
+	card = f.Review(card, Good, card.Due)
+	if card.State != StateReview {
+		t.Fatalf("Expected card in review after learning, got %v", card.State)
+	}
+
+	prev := card.IntervalDays
+	for i := 0; i < 4; i++ {
+		card = f.Review(card, Good, card.Due)
+		if card.IntervalDays <= prev && card.IntervalDays < f.MaximumInterval {
+			t.Fatalf("Review %d: interval didn't grow (%d -> %d)", i, prev, card.IntervalDays)
+		}
+		prev = card.IntervalDays
+	}
+	if card.IntervalDays > f.MaximumInterval {
+		t.Errorf("Interval %d exceeds maximum %d", card.IntervalDays, f.MaximumInterval)
+	}
+}
+
+func TestFSRS_LapseRelearns(t *testing.T) {
+	f := NewFSRS()
+	card := f.Review(NewCard(t0), Easy, t0)
+	before := card.Stability
+
+	card = f.Review(card, Again, card.Due)
+	if card.State != StateRelearning || card.Lapses != 1 {
+		t.Errorf("Expected relearning with 1 lapse, got %v with %d", card.State, card.Lapses)
+	}
+	if card.Stability >= before {
+		t.Errorf("Stability should drop after a lapse: %v -> %v", before, card.Stability)
+	}
+}
+
+func TestFSRS_FallsBackToSM2WithoutMemoryState(t *testing.T) {
+	f := NewFSRS()
+	// A card scheduled by SM-2 has no FSRS stability
+	card := Card{State: StateReview, EaseFactor: 2.5, IntervalDays: 6, Reps: 2, LastReview: t0}
+
+	next := f.Review(card, Good, t0.Add(6*24*time.Hour))
+	if next.IntervalDays != 15 {
+		t.Errorf("Expected SM-2 interval 15, got %d", next.IntervalDays)
+	}
+	if next.Stability <= 0 || next.Difficulty <= 0 {
+		t.Errorf("Expected FSRS memory state to be seeded, got %+v", next)
+	}
+}
+
+func TestSM2_Review(t *testing.T) {
+	s := SM2{}
+	card := NewCard(t0)
+
+	var intervals []int
+	for i := 0; i < 3; i++ {
+		card = s.Review(card, Good, card.Due)
+		intervals = append(intervals, card.IntervalDays)
+	}
+	if intervals[0] != 1 || intervals[1] != 6 || intervals[2] != 15 {
+		t.Errorf("Expected intervals 1, 6, 15, got %v", intervals)
+	}
+
+	card = s.Review(card, Again, card.Due)
+	if card.State != StateRelearning || card.Lapses != 1 || card.IntervalDays != 0 {
+		t.Errorf("Expected lapse to reset the interval, got %+v", card)
+	}
+	if card.EaseFactor >= defaultEase {
+		t.Errorf("Expected ease to drop after a lapse, got %v", card.EaseFactor)
+	}
+}
+
+func TestAdjustForLatency(t *testing.T) {
+	tests := []struct {
+		grade Grade
+		ms    int
+		want  Grade
+	}{
+		{Easy, 800, Easy},
+		{Easy, 4000, Good},
+		{Good, 9000, Hard},
+		{Again, 9000, Again},
+		{Hard, 20000, Hard},
+	}
+	for _, tt := range tests {
+		if got := AdjustForLatency(tt.grade, tt.ms); got != tt.want {
+			t.Errorf("AdjustForLatency(%v, %d) = %v, want %v", tt.grade, tt.ms, got, tt.want)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("fsrs"); err != nil {
+		t.Errorf("fsrs: %v", err)
+	}
+	if _, ok := mustNew(t, "sm2").(SM2); !ok {
+		t.Error("sm2 should return the SM-2 scheduler")
+	}
+	if _, err := New("leitner"); err == nil {
+		t.Error("Expected error for unknown algorithm")
+	}
+}
+
+func mustNew(t *testing.T, algorithm string) Scheduler {
+	t.Helper()
+	s, err := New(algorithm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
@@ -0,0 +1,56 @@
+package tickets
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is the default in-process ticket store.
+type MemoryStore struct {
+	mu      sync.Mutex
+	tickets map[string]Ticket
+}
+
+func NewMemoryStore() *MemoryStore {
+	s := &MemoryStore{
+		tickets: make(map[string]Ticket),
+	}
+	go s.startCleanup()
+	return s
+}
+
+func (s *MemoryStore) startCleanup() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+		s.mu.Lock()
+		for token, t := range s.tickets {
+			if now.After(t.ExpiresAt) {
+				delete(s.tickets, token)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *MemoryStore) Save(ctx context.Context, token string, ticket Ticket) error {
+	s.mu.Lock()
+	s.tickets[token] = ticket
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryStore) Redeem(ctx context.Context, token string) (Ticket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ticket, ok := s.tickets[token]
+	if !ok {
+		return Ticket{}, ErrInvalidTicket
+	}
+	delete(s.tickets, token)
+	return ticket, nil
+}
@@ -0,0 +1,65 @@
+package tickets
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Cadimodev/haiji/backend/internal/database"
+)
+
+// PostgresStore shares tickets between backend instances, so a ticket issued
+// by one instance can be redeemed on another.
+type PostgresStore struct {
+	db database.Querier
+}
+
+func NewPostgresStore(db database.Querier) *PostgresStore {
+	s := &PostgresStore{db: db}
+	go s.startCleanup()
+	return s
+}
+
+func (s *PostgresStore) startCleanup() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := s.db.DeleteExpiredWSTickets(context.Background()); err != nil {
+			slog.Error("Error deleting expired websocket tickets", "error", err)
+		}
+	}
+}
+
+func hashToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+func (s *PostgresStore) Save(ctx context.Context, token string, ticket Ticket) error {
+	return s.db.CreateWSTicket(ctx, database.CreateWSTicketParams{
+		TokenHash: hashToken(token),
+		UserID:    ticket.UserID,
+		Ip:        sql.NullString{String: ticket.IP, Valid: ticket.IP != ""},
+		ExpiresAt: ticket.ExpiresAt,
+	})
+}
+
+func (s *PostgresStore) Redeem(ctx context.Context, token string) (Ticket, error) {
+	// DELETE ... RETURNING makes the redeem atomic across instances
+	row, err := s.db.RedeemWSTicket(ctx, hashToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Ticket{}, ErrInvalidTicket
+	}
+	if err != nil {
+		return Ticket{}, err
+	}
+	return Ticket{
+		UserID:    row.UserID,
+		IP:        row.Ip.String,
+		ExpiresAt: row.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package tickets
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTTL is how long a websocket ticket can wait before being redeemed.
+const DefaultTTL = 30 * time.Second
+
+var (
+	ErrInvalidTicket = errors.New("invalid or already used ticket")
+	ErrExpiredTicket = errors.New("expired ticket")
+	ErrIPMismatch    = errors.New("ticket was issued to a different address")
+)
+
+// Ticket is a one-time credential used to open the game websocket, so the
+// access JWT never travels in a URL.
+type Ticket struct {
+	UserID    uuid.UUID
+	IP        string // empty when the ticket isn't bound to an address
+	ExpiresAt time.Time
+}
+
+// Store keeps issued tickets until they are redeemed or expire.
+type Store interface {
+	Save(ctx context.Context, token string, ticket Ticket) error
+	// Redeem atomically removes the ticket and returns it. A second call with
+	// the same token returns ErrInvalidTicket.
+	Redeem(ctx context.Context, token string) (Ticket, error)
+}
+
+// Issue creates and stores a new ticket for the user.
+func Issue(ctx context.Context, store Store, userID uuid.UUID, ip string, ttl time.Duration) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(raw)
+
+	err := store.Save(ctx, token, Ticket{
+		UserID:    userID,
+		IP:        ip,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Redeem consumes the ticket and checks it is still valid for the caller's IP.
+func Redeem(ctx context.Context, store Store, token string, ip string) (uuid.UUID, error) {
+	ticket, err := store.Redeem(ctx, token)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if time.Now().After(ticket.ExpiresAt) {
+		return uuid.Nil, ErrExpiredTicket
+	}
+	if ticket.IP != "" && ticket.IP != ip {
+		return uuid.Nil, ErrIPMismatch
+	}
+	return ticket.UserID, nil
+}
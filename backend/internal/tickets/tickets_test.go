@@ -0,0 +1,82 @@
+package tickets
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestTicket_SingleUse(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	token, err := Issue(ctx, store, userID, "", DefaultTTL)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	got, err := Redeem(ctx, store, token, "10.0.0.1")
+	if err != nil {
+		t.Fatalf("Redeem: %v", err)
+	}
+	if got != userID {
+		t.Errorf("Expected user %v, got %v", userID, got)
+	}
+
+	// Replay must fail
+	if _, err := Redeem(ctx, store, token, "10.0.0.1"); !errors.Is(err, ErrInvalidTicket) {
+		t.Errorf("Expected ErrInvalidTicket on replay, got %v", err)
+	}
+}
+
+func TestTicket_ConcurrentRedeem(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	token, _ := Issue(ctx, store, uuid.New(), "", DefaultTTL)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	successes := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := Redeem(ctx, store, token, ""); err == nil {
+				mu.Lock()
+				successes++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if successes != 1 {
+		t.Errorf("Expected exactly 1 successful redeem, got %d", successes)
+	}
+}
+
+func TestTicket_Expired(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	token, _ := Issue(ctx, store, uuid.New(), "", -time.Second)
+	if _, err := Redeem(ctx, store, token, ""); !errors.Is(err, ErrExpiredTicket) {
+		t.Errorf("Expected ErrExpiredTicket, got %v", err)
+	}
+}
+
+func TestTicket_BoundIP(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	token, _ := Issue(ctx, store, uuid.New(), "10.0.0.1", DefaultTTL)
+	if _, err := Redeem(ctx, store, token, "10.0.0.2"); !errors.Is(err, ErrIPMismatch) {
+		t.Errorf("Expected ErrIPMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+-- name: IncrementUserCounter :one
+INSERT INTO user_counters (user_id, name, value)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, name) DO UPDATE
+SET value      = user_counters.value + EXCLUDED.value,
+    updated_at = now()
+RETURNING value;
+
+-- name: RaiseUserCounter :one
+INSERT INTO user_counters (user_id, name, value)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, name) DO UPDATE
+SET value      = GREATEST(user_counters.value, EXCLUDED.value),
+    updated_at = now()
+RETURNING value;
+
+-- name: ListUserCounters :many
+SELECT * FROM user_counters
+WHERE user_id = $1;
+
+-- name: CreateUserAchievement :execrows
+INSERT INTO user_achievements (user_id, achievement_id, unlocked_at)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING;
+
+-- name: ListUserAchievements :many
+SELECT * FROM user_achievements
+WHERE user_id = $1
+ORDER BY unlocked_at;
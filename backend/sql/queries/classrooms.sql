@@ -0,0 +1,96 @@
+-- name: SetUserRole :execrows
+UPDATE users
+SET role = $2, updated_at = now()
+WHERE username = $1;
+
+-- name: CreateClass :one
+INSERT INTO classes (teacher_id, name, join_code)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetClass :one
+SELECT * FROM classes
+WHERE id = $1;
+
+-- name: GetClassByJoinCode :one
+SELECT * FROM classes
+WHERE join_code = $1;
+
+-- name: ListClassesForUser :many
+SELECT c.*, u.username AS teacher_username,
+       (SELECT COUNT(*) FROM class_members m WHERE m.class_id = c.id) AS members
+FROM classes c
+JOIN users u ON u.id = c.teacher_id
+WHERE c.teacher_id = sqlc.arg(user_id)
+   OR EXISTS (SELECT 1 FROM class_members m WHERE m.class_id = c.id AND m.user_id = sqlc.arg(user_id))
+ORDER BY c.created_at DESC;
+
+-- name: AddClassMember :exec
+INSERT INTO class_members (class_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT (class_id, user_id) DO NOTHING;
+
+-- name: RemoveClassMember :execrows
+DELETE FROM class_members
+WHERE class_id = $1 AND user_id = $2;
+
+-- name: IsClassMember :one
+SELECT EXISTS (
+  SELECT 1 FROM class_members
+  WHERE class_id = $1 AND user_id = $2
+);
+
+-- name: ListClassMembers :many
+SELECT m.user_id, u.username, m.joined_at
+FROM class_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.class_id = $1
+ORDER BY lower(u.username);
+
+-- name: CreateAssignment :one
+INSERT INTO class_assignments (class_id, title, groups, kana, target_accuracy, due_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListAssignments :many
+SELECT * FROM class_assignments
+WHERE class_id = $1
+ORDER BY due_at, created_at;
+
+-- name: CreateClassBattle :exec
+INSERT INTO class_battles (class_id, room_code)
+VALUES ($1, $2);
+
+-- name: GetAssignmentProgress :many
+-- Practice answers to each assignment's characters between when it was set
+-- and when it's due, per member
+SELECT a.id AS assignment_id, m.user_id,
+       COUNT(pa.id) AS attempts,
+       COUNT(pa.id) FILTER (WHERE pa.correct) AS correct,
+       COUNT(DISTINCT pa.kana) AS kana_practiced
+FROM class_assignments a
+JOIN class_members m ON m.class_id = a.class_id
+LEFT JOIN practice_answers pa
+  ON pa.user_id = m.user_id
+ AND pa.kana = ANY(a.kana)
+ AND pa.answered_at >= a.created_at
+ AND pa.answered_at < a.due_at
+WHERE a.class_id = $1
+GROUP BY a.id, m.user_id;
+
+-- name: GetClassBattleStats :many
+-- Battles each member has played since joining the class
+SELECT m.user_id,
+       COUNT(br.id) AS battles,
+       COUNT(br.id) FILTER (WHERE EXISTS (
+         SELECT 1 FROM class_battles cb
+         WHERE cb.class_id = m.class_id AND cb.room_code = br.room_code AND cb.created_at <= br.created_at
+       )) AS class_battles,
+       COALESCE(MAX(br.final_score), 0)::int AS best_score,
+       COALESCE(AVG(br.final_score), 0)::float8 AS average_score
+FROM class_members m
+LEFT JOIN battle_runs br
+  ON br.user_id = m.user_id
+ AND br.created_at >= m.joined_at
+WHERE m.class_id = $1
+GROUP BY m.user_id, m.class_id;
@@ -0,0 +1,26 @@
+-- name: ListCurriculumProgress :many
+SELECT * FROM curriculum_progress
+WHERE user_id = $1;
+
+-- name: UnlockLesson :exec
+INSERT INTO curriculum_progress (user_id, lesson_id)
+VALUES ($1, $2)
+ON CONFLICT (user_id, lesson_id) DO NOTHING;
+
+-- name: MarkLessonMastered :execrows
+UPDATE curriculum_progress
+SET mastered_at = now()
+WHERE user_id = $1 AND lesson_id = $2 AND mastered_at IS NULL;
+
+-- name: GetRecentCharacterAccuracy :many
+SELECT kana,
+       COUNT(*) AS attempts,
+       COUNT(*) FILTER (WHERE correct) AS correct
+FROM (
+  SELECT kana, correct,
+         row_number() OVER (PARTITION BY kana ORDER BY answered_at DESC, id DESC) AS recent
+  FROM practice_answers
+  WHERE user_id = sqlc.arg(user_id) AND kana = ANY(sqlc.arg(kana)::text[])
+) answers
+WHERE recent <= sqlc.arg(last)::int
+GROUP BY kana;
@@ -0,0 +1,40 @@
+-- name: UpsertDailyChallenge :one
+INSERT INTO daily_challenges (challenge_date, seed, length)
+VALUES ($1, $2, $3)
+ON CONFLICT (challenge_date) DO UPDATE SET challenge_date = EXCLUDED.challenge_date
+RETURNING *;
+
+-- name: CreateDailyAttempt :one
+INSERT INTO daily_attempts (user_id, challenge_date, started_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, challenge_date) DO NOTHING
+RETURNING *;
+
+-- name: GetDailyAttempt :one
+SELECT * FROM daily_attempts
+WHERE user_id = $1 AND challenge_date = $2;
+
+-- name: FinishDailyAttempt :one
+UPDATE daily_attempts
+SET finished_at = $3, correct = $4, total = $5, duration_ms = $6
+WHERE user_id = $1
+  AND challenge_date = $2
+  AND finished_at IS NULL
+RETURNING *;
+
+-- name: GetDailyLeaderboard :many
+SELECT u.id AS user_id, u.username, a.correct, a.total, a.duration_ms, a.finished_at
+FROM daily_attempts a
+JOIN users u ON u.id = a.user_id
+WHERE a.challenge_date = $1
+  AND a.finished_at IS NOT NULL
+ORDER BY a.correct DESC, a.duration_ms ASC, a.finished_at ASC
+LIMIT $2;
+
+-- name: CountDailyAttemptsAhead :one
+SELECT COUNT(*)
+FROM daily_attempts a
+WHERE a.challenge_date = sqlc.arg(challenge_date)
+  AND a.finished_at IS NOT NULL
+  AND (a.correct > sqlc.arg(correct)
+       OR (a.correct = sqlc.arg(correct) AND a.duration_ms < sqlc.arg(duration_ms)));
@@ -0,0 +1,49 @@
+-- name: CreateDeck :one
+INSERT INTO decks (code, owner_id, name)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetDeckByCode :one
+SELECT d.id, d.code, d.owner_id, u.username AS owner_username, d.name, d.created_at, d.updated_at
+FROM decks d
+JOIN users u ON u.id = d.owner_id
+WHERE d.code = $1;
+
+-- name: ListDecksByOwner :many
+SELECT d.id, d.code, d.name, d.created_at, d.updated_at, COUNT(e.position) AS entry_count
+FROM decks d
+LEFT JOIN deck_entries e ON e.deck_id = d.id
+WHERE d.owner_id = $1
+GROUP BY d.id
+ORDER BY d.created_at DESC;
+
+-- name: RenameDeck :exec
+UPDATE decks SET name = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: DeleteDeck :exec
+DELETE FROM decks
+WHERE id = $1;
+
+-- name: CreateDeckEntries :exec
+INSERT INTO deck_entries (deck_id, position, prompt, answer)
+SELECT sqlc.arg(deck_id), e.position::integer, e.prompt, e.answer
+FROM unnest(sqlc.arg(prompts)::text[], sqlc.arg(answers)::text[])
+  WITH ORDINALITY AS e(prompt, answer, position);
+
+-- name: DeleteDeckEntries :exec
+DELETE FROM deck_entries
+WHERE deck_id = $1;
+
+-- name: ListDeckEntries :many
+SELECT prompt, answer
+FROM deck_entries
+WHERE deck_id = $1
+ORDER BY position;
+
+-- name: ListDeckEntriesByCodes :many
+SELECT d.code, e.prompt, e.answer
+FROM decks d
+JOIN deck_entries e ON e.deck_id = d.id
+WHERE d.code = ANY(sqlc.arg(codes)::text[])
+ORDER BY d.code, e.position;
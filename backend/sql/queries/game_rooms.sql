@@ -0,0 +1,28 @@
+-- name: ClaimGameRoom :execrows
+INSERT INTO game_rooms (code, instance_id, expires_at)
+VALUES (sqlc.arg(code), sqlc.arg(instance_id), now() + sqlc.arg(lease_seconds)::int * interval '1 second')
+ON CONFLICT (code) DO UPDATE
+SET instance_id = EXCLUDED.instance_id,
+    created_at  = now(),
+    expires_at  = EXCLUDED.expires_at
+WHERE game_rooms.expires_at <= now();
+
+-- name: GetGameRoomOwner :one
+SELECT instance_id FROM game_rooms
+WHERE code = $1 AND expires_at > now();
+
+-- name: RenewGameRooms :exec
+UPDATE game_rooms
+SET expires_at = now() + sqlc.arg(lease_seconds)::int * interval '1 second'
+WHERE instance_id = sqlc.arg(instance_id);
+
+-- name: DeleteGameRoom :exec
+DELETE FROM game_rooms
+WHERE code = $1;
+
+-- name: DeleteGameRoomsByInstance :exec
+DELETE FROM game_rooms
+WHERE instance_id = $1;
+
+-- name: Notify :exec
+SELECT pg_notify(sqlc.arg(channel)::text, sqlc.arg(payload)::text);
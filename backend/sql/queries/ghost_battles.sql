@@ -0,0 +1,44 @@
+-- name: CreateBattleRun :one
+INSERT INTO battle_runs (user_id, room_code, groups, duration_seconds, final_score, events, direction, choices, mode)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetBattleRun :one
+SELECT * FROM battle_runs
+WHERE id = $1;
+
+-- name: ListBattleRunsByUser :many
+SELECT * FROM battle_runs
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: CreateGhostChallenge :one
+INSERT INTO ghost_challenges (code, run_id, created_by)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetGhostChallenge :one
+SELECT c.code, c.created_at, r.id AS run_id, r.user_id, u.username,
+       r.groups, r.duration_seconds, r.final_score, r.events, r.direction, r.choices, r.mode
+FROM ghost_challenges c
+JOIN battle_runs r ON r.id = c.run_id
+JOIN users u ON u.id = r.user_id
+WHERE c.code = $1;
+
+-- name: CreateGhostResult :one
+INSERT INTO ghost_results (challenge_code, run_id, challenger_id, ghost_user_id, challenger_score, ghost_score)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListGhostResultsForUser :many
+SELECT g.id, g.challenge_code, g.run_id,
+       g.challenger_id, cu.username AS challenger_username, g.challenger_score,
+       g.ghost_user_id, gu.username AS ghost_username, g.ghost_score,
+       g.created_at
+FROM ghost_results g
+JOIN users cu ON cu.id = g.challenger_id
+JOIN users gu ON gu.id = g.ghost_user_id
+WHERE g.challenger_id = $1 OR g.ghost_user_id = $1
+ORDER BY g.created_at DESC
+LIMIT $2;
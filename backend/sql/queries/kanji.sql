@@ -0,0 +1,39 @@
+-- name: UpsertKanji :exec
+INSERT INTO kanji (literal, grade, jlpt, stroke_count, frequency, meanings, on_readings, kun_readings)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (literal) DO UPDATE
+SET grade = EXCLUDED.grade,
+    jlpt = EXCLUDED.jlpt,
+    stroke_count = EXCLUDED.stroke_count,
+    frequency = EXCLUDED.frequency,
+    meanings = EXCLUDED.meanings,
+    on_readings = EXCLUDED.on_readings,
+    kun_readings = EXCLUDED.kun_readings,
+    updated_at = NOW();
+
+-- name: UpdateKanjiStrokePaths :execrows
+UPDATE kanji SET stroke_paths = $2, updated_at = NOW()
+WHERE literal = $1;
+
+-- name: GetKanji :one
+SELECT * FROM kanji
+WHERE literal = $1;
+
+-- name: ListKanjiByJLPT :many
+SELECT * FROM kanji
+WHERE jlpt = $1
+ORDER BY frequency NULLS LAST, literal
+LIMIT $2 OFFSET $3;
+
+-- name: CountKanjiByJLPT :many
+SELECT jlpt::integer AS jlpt, COUNT(*) AS kanji
+FROM kanji
+WHERE jlpt IS NOT NULL
+GROUP BY jlpt
+ORDER BY jlpt DESC;
+
+-- name: ListKanjiReadingsByJLPT :many
+SELECT literal, jlpt::integer AS jlpt, on_readings, kun_readings
+FROM kanji
+WHERE jlpt = ANY(sqlc.arg(levels)::int[])
+ORDER BY frequency NULLS LAST, literal;
@@ -0,0 +1,76 @@
+-- name: GetLeagueTier :one
+SELECT tier FROM league_players
+WHERE user_id = $1;
+
+-- name: SetLeagueTier :exec
+INSERT INTO league_players (user_id, tier)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET tier = EXCLUDED.tier, updated_at = now();
+
+-- name: CreateLeagueWeek :exec
+INSERT INTO league_weeks (week_start)
+VALUES ($1)
+ON CONFLICT (week_start) DO NOTHING;
+
+-- name: GetLeagueMember :one
+SELECT * FROM league_members
+WHERE user_id = $1 AND week_start = $2;
+
+-- name: FindOpenLeagueBucket :one
+SELECT bucket
+FROM league_members
+WHERE week_start = sqlc.arg(week_start) AND tier = sqlc.arg(tier)
+GROUP BY bucket
+HAVING COUNT(*) < sqlc.arg(bucket_size)::int
+ORDER BY bucket
+LIMIT 1;
+
+-- name: NextLeagueBucket :one
+SELECT (COALESCE(MAX(bucket), 0) + 1)::int AS bucket
+FROM league_members
+WHERE week_start = $1 AND tier = $2;
+
+-- name: JoinLeague :exec
+INSERT INTO league_members (user_id, week_start, tier, bucket)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, week_start) DO NOTHING;
+
+-- name: GetLeagueBucketStandings :many
+SELECT m.user_id, u.username, COALESCE(SUM(x.amount), 0)::bigint AS points
+FROM league_members m
+JOIN users u ON u.id = m.user_id
+LEFT JOIN xp_ledger x ON x.user_id = m.user_id
+  AND x.source = ANY(sqlc.arg(sources)::text[])
+  AND x.created_at >= sqlc.arg(earned_from)
+  AND x.created_at < sqlc.arg(earned_until)
+WHERE m.week_start = sqlc.arg(week_start)
+  AND m.tier = sqlc.arg(tier)
+  AND m.bucket = sqlc.arg(bucket)
+GROUP BY m.user_id, u.username, m.joined_at
+ORDER BY points DESC, m.joined_at ASC;
+
+-- name: ListOpenLeagueWeeks :many
+SELECT week_start FROM league_weeks
+WHERE closed_at IS NULL AND week_start < $1
+ORDER BY week_start;
+
+-- name: CloseLeagueWeek :execrows
+UPDATE league_weeks
+SET closed_at = now()
+WHERE week_start = $1 AND closed_at IS NULL;
+
+-- name: ListLeagueWeekStandings :many
+SELECT m.user_id, m.tier, m.bucket, COALESCE(SUM(x.amount), 0)::bigint AS points
+FROM league_members m
+LEFT JOIN xp_ledger x ON x.user_id = m.user_id
+  AND x.source = ANY(sqlc.arg(sources)::text[])
+  AND x.created_at >= sqlc.arg(earned_from)
+  AND x.created_at < sqlc.arg(earned_until)
+WHERE m.week_start = sqlc.arg(week_start)
+GROUP BY m.user_id, m.tier, m.bucket, m.joined_at
+ORDER BY m.tier, m.bucket, points DESC, m.joined_at ASC;
+
+-- name: SetLeagueOutcome :exec
+UPDATE league_members
+SET points = $3, outcome = $4
+WHERE user_id = $1 AND week_start = $2;
@@ -0,0 +1,38 @@
+-- name: CreatePracticeSession :one
+INSERT INTO practice_sessions (user_id, groups, started_at, finished_at, direction)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: CreatePracticeAnswers :exec
+INSERT INTO practice_answers (session_id, user_id, kana, expected, given, correct, latency_ms, answered_at)
+SELECT sqlc.arg(session_id)::uuid, sqlc.arg(user_id)::uuid,
+       unnest(sqlc.arg(kana)::text[]), unnest(sqlc.arg(expected)::text[]), unnest(sqlc.arg(given)::text[]),
+       unnest(sqlc.arg(correct)::boolean[]), unnest(sqlc.arg(latency_ms)::integer[]),
+       unnest(sqlc.arg(answered_at)::timestamptz[]);
+
+-- name: GetCharacterStats :many
+SELECT kana,
+       COUNT(*) AS attempts,
+       COUNT(*) FILTER (WHERE correct) AS correct,
+       percentile_cont(0.5) WITHIN GROUP (ORDER BY latency_ms)::float8 AS median_latency_ms
+FROM practice_answers
+WHERE user_id = $1 AND answered_at >= $2
+GROUP BY kana
+ORDER BY COUNT(*) FILTER (WHERE correct)::float8 / COUNT(*), COUNT(*) DESC, kana;
+
+-- name: GetConfusionMatrix :many
+SELECT kana, expected, given, COUNT(*) AS count
+FROM practice_answers
+WHERE user_id = $1 AND answered_at >= $2 AND NOT correct
+GROUP BY kana, expected, given
+ORDER BY count DESC, kana, given
+LIMIT $3;
+
+-- name: GetActivityHeatmap :many
+SELECT (answered_at AT TIME ZONE sqlc.arg(time_zone)::text)::date AS day,
+       COUNT(*) AS answers,
+       COUNT(*) FILTER (WHERE correct) AS correct
+FROM practice_answers
+WHERE user_id = sqlc.arg(user_id) AND answered_at >= sqlc.arg(since)
+GROUP BY day
+ORDER BY day;
@@ -0,0 +1,25 @@
+-- name: GetUserForUpdate :one
+SELECT * FROM users
+WHERE id = $1
+FOR UPDATE;
+
+-- name: CreateXPLedgerEntry :exec
+INSERT INTO xp_ledger (user_id, amount, source, reference)
+VALUES ($1, $2, $3, $4);
+
+-- name: UpdateUserProgress :exec
+UPDATE users
+SET xp = $2,
+    streak_current = $3,
+    streak_longest = $4,
+    streak_last_day = $5,
+    streak_freezes = $6
+WHERE id = $1;
+
+-- name: UpdateUserSettings :one
+UPDATE users
+SET time_zone = COALESCE(sqlc.narg(time_zone), time_zone),
+    romaji_systems = COALESCE(sqlc.narg(romaji_systems)::text[], romaji_systems),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
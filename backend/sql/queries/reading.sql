@@ -0,0 +1,19 @@
+-- name: UpsertReadingItem :exec
+INSERT INTO reading_items (kind, source, source_id, text, written, meaning, groups)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (source, source_id) DO UPDATE
+SET kind = EXCLUDED.kind,
+    text = EXCLUDED.text,
+    written = EXCLUDED.written,
+    meaning = EXCLUDED.meaning,
+    groups = EXCLUDED.groups;
+
+-- name: GetReadingItem :one
+SELECT * FROM reading_items
+WHERE id = $1;
+
+-- name: ListReadableItems :many
+SELECT * FROM reading_items
+WHERE groups <@ sqlc.arg(groups)::text[] AND kind = ANY(sqlc.arg(kinds)::text[])
+ORDER BY random()
+LIMIT sqlc.arg(count);
@@ -0,0 +1,12 @@
+-- name: CreateShareCard :exec
+INSERT INTO share_cards (id, room_code, groups, standings, expires_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetShareCard :one
+SELECT * FROM share_cards
+WHERE id = $1 AND expires_at > now();
+
+-- name: DeleteExpiredShareCards :many
+DELETE FROM share_cards
+WHERE expires_at <= now()
+RETURNING id;
@@ -0,0 +1,68 @@
+-- name: EnsureCoinAccount :exec
+INSERT INTO coin_accounts (user_id)
+VALUES ($1)
+ON CONFLICT (user_id) DO NOTHING;
+
+-- name: GetCoinAccountForUpdate :one
+SELECT * FROM coin_accounts
+WHERE user_id = $1
+FOR UPDATE;
+
+-- name: GetSystemCoinAccountID :one
+SELECT id FROM coin_accounts
+WHERE system_name = $1;
+
+-- name: GetCoinBalance :one
+SELECT balance FROM coin_accounts
+WHERE user_id = $1;
+
+-- name: CreateCoinTransfer :one
+INSERT INTO coin_transfers (kind, reference)
+VALUES ($1, $2)
+RETURNING id;
+
+-- name: CreateCoinEntry :exec
+INSERT INTO coin_entries (transfer_id, account_id, amount)
+VALUES ($1, $2, $3);
+
+-- name: AddCoinBalance :exec
+UPDATE coin_accounts
+SET balance = balance + $2
+WHERE id = $1;
+
+-- name: ListCoinEntries :many
+SELECT e.amount, t.kind, t.reference, e.created_at
+FROM coin_entries e
+JOIN coin_accounts a ON a.id = e.account_id
+JOIN coin_transfers t ON t.id = e.transfer_id
+WHERE a.user_id = $1
+ORDER BY e.created_at DESC, e.id DESC
+LIMIT $2;
+
+-- name: AddOwnedCosmetic :execrows
+INSERT INTO user_cosmetics (user_id, item_id, transfer_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, item_id) DO NOTHING;
+
+-- name: IsCosmeticOwned :one
+SELECT EXISTS (
+  SELECT 1 FROM user_cosmetics WHERE user_id = $1 AND item_id = $2
+) AS owned;
+
+-- name: ListOwnedCosmetics :many
+SELECT * FROM user_cosmetics
+WHERE user_id = $1
+ORDER BY purchased_at;
+
+-- name: EquipCosmetic :exec
+INSERT INTO equipped_cosmetics (user_id, slot, item_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, slot) DO UPDATE SET item_id = EXCLUDED.item_id;
+
+-- name: UnequipCosmetic :execrows
+DELETE FROM equipped_cosmetics
+WHERE user_id = $1 AND slot = $2;
+
+-- name: ListEquippedCosmetics :many
+SELECT * FROM equipped_cosmetics
+WHERE user_id = $1;
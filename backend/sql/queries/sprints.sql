@@ -0,0 +1,27 @@
+-- name: CreateSprintRun :one
+INSERT INTO sprint_runs (user_id, group_set, duration, seed, correct, answered, started_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (user_id, seed) DO NOTHING
+RETURNING *;
+
+-- name: GetSprintBest :one
+SELECT COALESCE(MAX(correct), 0)::int AS best, COUNT(*) AS runs
+FROM sprint_runs
+WHERE user_id = $1 AND group_set = $2 AND duration = $3;
+
+-- name: ListSprintHistory :many
+SELECT * FROM sprint_runs
+WHERE user_id = $1 AND group_set = $2 AND duration = $3
+ORDER BY finished_at DESC
+LIMIT $4;
+
+-- name: GetSprintPercentile :one
+WITH bests AS (
+  SELECT user_id, MAX(correct) AS best
+  FROM sprint_runs
+  WHERE group_set = sqlc.arg(group_set) AND duration = sqlc.arg(duration)
+  GROUP BY user_id
+)
+SELECT COUNT(*) FILTER (WHERE best < sqlc.arg(best)::int) AS below,
+       COUNT(*) AS players
+FROM bests;
@@ -0,0 +1,43 @@
+-- name: GetSRSCards :many
+SELECT * FROM srs_cards
+WHERE user_id = sqlc.arg(user_id) AND kana = ANY(sqlc.arg(kana)::text[])
+FOR UPDATE;
+
+-- name: ListDueSRSCards :many
+SELECT * FROM srs_cards
+WHERE user_id = $1 AND due_at <= $2
+ORDER BY due_at
+LIMIT $3;
+
+-- name: CountDueSRSCards :one
+SELECT COUNT(*) FROM srs_cards
+WHERE user_id = $1 AND due_at <= $2;
+
+-- name: ListSRSCardKana :many
+SELECT kana FROM srs_cards
+WHERE user_id = $1;
+
+-- name: CountSRSCardsCreatedSince :one
+SELECT COUNT(*) FROM srs_cards
+WHERE user_id = $1 AND created_at >= $2;
+
+-- name: UpsertSRSCard :exec
+INSERT INTO srs_cards (
+  user_id, kana, state, stability, difficulty, ease_factor,
+  interval_days, reps, lapses, due_at, last_review_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (user_id, kana) DO UPDATE
+SET state          = EXCLUDED.state,
+    stability      = EXCLUDED.stability,
+    difficulty     = EXCLUDED.difficulty,
+    ease_factor    = EXCLUDED.ease_factor,
+    interval_days  = EXCLUDED.interval_days,
+    reps           = EXCLUDED.reps,
+    lapses         = EXCLUDED.lapses,
+    due_at         = EXCLUDED.due_at,
+    last_review_at = EXCLUDED.last_review_at;
+
+-- name: CreateSRSReviewLog :exec
+INSERT INTO srs_review_logs (user_id, kana, grade, response_ms, state, reviewed_at)
+VALUES ($1, $2, $3, $4, $5, $6);
@@ -0,0 +1,12 @@
+-- name: CreateWSTicket :exec
+INSERT INTO ws_tickets (token_hash, user_id, ip, expires_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: RedeemWSTicket :one
+DELETE FROM ws_tickets
+WHERE token_hash = $1
+RETURNING *;
+
+-- name: DeleteExpiredWSTickets :exec
+DELETE FROM ws_tickets
+WHERE expires_at < now();
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS game_rooms (
+  code        TEXT PRIMARY KEY,
+  instance_id TEXT        NOT NULL,             -- backend instance running the room loop
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- better performance when an instance releases all its rooms on shutdown
+CREATE INDEX IF NOT EXISTS ix_game_rooms_instance_id
+  ON game_rooms(instance_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_game_rooms_instance_id;
+
+DROP TABLE IF EXISTS game_rooms;
@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS ws_tickets (
+  token_hash  BYTEA PRIMARY KEY,                  -- SHA-256 of the ticket
+  user_id     UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  ip          TEXT,                               -- NULL when not bound to an address
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at  TIMESTAMPTZ NOT NULL
+);
+
+-- better performance for garbage collection
+CREATE INDEX IF NOT EXISTS ix_ws_tickets_expires_at
+  ON ws_tickets(expires_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_ws_tickets_expires_at;
+
+DROP TABLE IF EXISTS ws_tickets;
@@ -0,0 +1,39 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS daily_challenges (
+  challenge_date DATE PRIMARY KEY,                -- UTC day
+  seed           BIGINT      NOT NULL,
+  length         INTEGER     NOT NULL,
+  created_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT daily_challenges_length_positive CHECK (length > 0)
+);
+
+CREATE TABLE IF NOT EXISTS daily_attempts (
+  id             BIGSERIAL PRIMARY KEY,
+  user_id        UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  challenge_date DATE        NOT NULL REFERENCES daily_challenges(challenge_date) ON DELETE CASCADE,
+  started_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+  finished_at    TIMESTAMPTZ,
+  correct        INTEGER     NOT NULL DEFAULT 0,
+  total          INTEGER     NOT NULL DEFAULT 0,
+  duration_ms    INTEGER,                         -- server-timed, NULL until finished
+
+  CONSTRAINT daily_attempts_finished_after_started
+    CHECK (finished_at IS NULL OR finished_at >= started_at)
+);
+
+-- one scored attempt per user and day
+CREATE UNIQUE INDEX IF NOT EXISTS ux_daily_attempts_user_date
+  ON daily_attempts(user_id, challenge_date);
+
+-- better performance for the daily leaderboard
+CREATE INDEX IF NOT EXISTS ix_daily_attempts_leaderboard
+  ON daily_attempts(challenge_date, correct DESC, duration_ms ASC)
+  WHERE finished_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_daily_attempts_leaderboard;
+DROP INDEX IF EXISTS ux_daily_attempts_user_date;
+
+DROP TABLE IF EXISTS daily_attempts;
+DROP TABLE IF EXISTS daily_challenges;
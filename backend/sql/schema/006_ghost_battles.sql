@@ -0,0 +1,49 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS battle_runs (
+  id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id          UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  room_code        TEXT        NOT NULL,
+  groups           TEXT[]      NOT NULL,
+  duration_seconds INTEGER     NOT NULL,
+  final_score      INTEGER     NOT NULL,
+  events           JSONB       NOT NULL,          -- [{"offsetMs": 1200, "score": 1}, ...]
+  created_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- better performance when listing a user's runs
+CREATE INDEX IF NOT EXISTS ix_battle_runs_user_created
+  ON battle_runs(user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS ghost_challenges (
+  code        TEXT PRIMARY KEY,
+  run_id      UUID        NOT NULL REFERENCES battle_runs(id) ON DELETE CASCADE,
+  created_by  UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS ghost_results (
+  id               BIGSERIAL PRIMARY KEY,
+  challenge_code   TEXT        NOT NULL REFERENCES ghost_challenges(code) ON DELETE CASCADE,
+  run_id           UUID        NOT NULL REFERENCES battle_runs(id) ON DELETE CASCADE,
+  challenger_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  ghost_user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  challenger_score INTEGER     NOT NULL,
+  ghost_score      INTEGER     NOT NULL,
+  created_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- results are listed for both the challenger and the ghost owner
+CREATE INDEX IF NOT EXISTS ix_ghost_results_challenger
+  ON ghost_results(challenger_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS ix_ghost_results_ghost_user
+  ON ghost_results(ghost_user_id, created_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_ghost_results_ghost_user;
+DROP INDEX IF EXISTS ix_ghost_results_challenger;
+DROP TABLE IF EXISTS ghost_results;
+
+DROP TABLE IF EXISTS ghost_challenges;
+
+DROP INDEX IF EXISTS ix_battle_runs_user_created;
+DROP TABLE IF EXISTS battle_runs;
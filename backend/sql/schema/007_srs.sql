@@ -0,0 +1,44 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS srs_cards (
+  user_id        UUID             NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  kana           TEXT             NOT NULL,
+  state          SMALLINT         NOT NULL DEFAULT 0, -- 0 new, 1 learning, 2 review, 3 relearning
+  stability      DOUBLE PRECISION NOT NULL DEFAULT 0,
+  difficulty     DOUBLE PRECISION NOT NULL DEFAULT 0,
+  ease_factor    DOUBLE PRECISION NOT NULL DEFAULT 2.5,
+  interval_days  INTEGER          NOT NULL DEFAULT 0,
+  reps           INTEGER          NOT NULL DEFAULT 0,
+  lapses         INTEGER          NOT NULL DEFAULT 0,
+  due_at         TIMESTAMPTZ      NOT NULL,
+  last_review_at TIMESTAMPTZ,
+  created_at     TIMESTAMPTZ      NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (user_id, kana),
+  CONSTRAINT srs_cards_state_valid CHECK (state BETWEEN 0 AND 3)
+);
+
+-- better performance when building the review queue
+CREATE INDEX IF NOT EXISTS ix_srs_cards_user_due
+  ON srs_cards(user_id, due_at);
+
+CREATE TABLE IF NOT EXISTS srs_review_logs (
+  id          BIGSERIAL PRIMARY KEY,
+  user_id     UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  kana        TEXT        NOT NULL,
+  grade       SMALLINT    NOT NULL,
+  response_ms INTEGER     NOT NULL,
+  state       SMALLINT    NOT NULL,                 -- card state before the review
+  reviewed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT srs_review_logs_grade_valid CHECK (grade BETWEEN 1 AND 4)
+);
+
+CREATE INDEX IF NOT EXISTS ix_srs_review_logs_user_reviewed
+  ON srs_review_logs(user_id, reviewed_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_srs_review_logs_user_reviewed;
+DROP TABLE IF EXISTS srs_review_logs;
+
+DROP INDEX IF EXISTS ix_srs_cards_user_due;
+DROP TABLE IF EXISTS srs_cards;
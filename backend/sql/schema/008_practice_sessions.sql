@@ -0,0 +1,35 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS practice_sessions (
+  id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id     UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  groups      TEXT[]      NOT NULL DEFAULT '{}',
+  started_at  TIMESTAMPTZ NOT NULL,
+  finished_at TIMESTAMPTZ NOT NULL,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT practice_sessions_finished_after_started
+    CHECK (finished_at >= started_at)
+);
+
+CREATE TABLE IF NOT EXISTS practice_answers (
+  id          BIGSERIAL PRIMARY KEY,
+  session_id  UUID        NOT NULL REFERENCES practice_sessions(id) ON DELETE CASCADE,
+  user_id     UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  kana        TEXT        NOT NULL,
+  expected    TEXT        NOT NULL,                -- romaji
+  given       TEXT        NOT NULL,                -- what the user typed
+  correct     BOOLEAN     NOT NULL,
+  latency_ms  INTEGER     NOT NULL,
+  answered_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT practice_answers_latency_positive CHECK (latency_ms >= 0)
+);
+
+-- every statistic is per user over a time window
+CREATE INDEX IF NOT EXISTS ix_practice_answers_user_answered
+  ON practice_answers(user_id, answered_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_practice_answers_user_answered;
+DROP TABLE IF EXISTS practice_answers;
+DROP TABLE IF EXISTS practice_sessions;
@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS user_counters (
+  user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name       TEXT        NOT NULL,
+  value      BIGINT      NOT NULL DEFAULT 0,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS user_login_days (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  day     DATE NOT NULL,
+
+  PRIMARY KEY (user_id, day)
+);
+
+CREATE TABLE IF NOT EXISTS user_achievements (
+  user_id        UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  achievement_id TEXT        NOT NULL,               -- achievements.Rule ID
+  unlocked_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  -- awarding is idempotent
+  PRIMARY KEY (user_id, achievement_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_achievements;
+DROP TABLE IF EXISTS user_login_days;
+DROP TABLE IF EXISTS user_counters;
@@ -0,0 +1,46 @@
+-- +goose Up
+ALTER TABLE users
+  ADD COLUMN time_zone       TEXT    NOT NULL DEFAULT 'UTC',  -- IANA name
+  ADD COLUMN xp              BIGINT  NOT NULL DEFAULT 0,      -- sum of xp_ledger, kept in the same transaction
+  ADD COLUMN streak_current  INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN streak_longest  INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN streak_last_day DATE,                            -- in time_zone
+  ADD COLUMN streak_freezes  INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS xp_ledger (
+  id         BIGSERIAL PRIMARY KEY,
+  user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  amount     INTEGER     NOT NULL CHECK (amount > 0),
+  source     TEXT        NOT NULL,   -- progression.Source*
+  reference  TEXT        NOT NULL,   -- practice session ID or room code
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS xp_ledger_user_idx
+  ON xp_ledger (user_id, created_at);
+
+-- Entries are never changed; they only go away with their user
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION xp_ledger_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'xp_ledger is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER xp_ledger_no_update
+  BEFORE UPDATE ON xp_ledger
+  FOR EACH ROW EXECUTE FUNCTION xp_ledger_append_only();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS xp_ledger_no_update ON xp_ledger;
+DROP FUNCTION IF EXISTS xp_ledger_append_only();
+DROP TABLE IF EXISTS xp_ledger;
+
+ALTER TABLE users
+  DROP COLUMN IF EXISTS streak_freezes,
+  DROP COLUMN IF EXISTS streak_last_day,
+  DROP COLUMN IF EXISTS streak_longest,
+  DROP COLUMN IF EXISTS streak_current,
+  DROP COLUMN IF EXISTS xp,
+  DROP COLUMN IF EXISTS time_zone;
@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS decks (
+  id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  code       TEXT        NOT NULL UNIQUE,   -- used as the "deck:CODE" group
+  owner_id   UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name       TEXT        NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ix_decks_owner_created
+  ON decks(owner_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS deck_entries (
+  deck_id  UUID    NOT NULL REFERENCES decks(id) ON DELETE CASCADE,
+  position INTEGER NOT NULL,
+  prompt   TEXT    NOT NULL,   -- kana or kana word
+  answer   TEXT    NOT NULL,   -- romaji
+
+  PRIMARY KEY (deck_id, position)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS deck_entries;
+DROP INDEX IF EXISTS ix_decks_owner_created;
+DROP TABLE IF EXISTS decks;
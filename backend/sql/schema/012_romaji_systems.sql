@@ -0,0 +1,7 @@
+-- +goose Up
+-- Romanization systems accepted when grading typed answers (romaji.System)
+ALTER TABLE users
+  ADD COLUMN romaji_systems TEXT[] NOT NULL DEFAULT '{hepburn,kunrei,nihon}';
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS romaji_systems;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- kana.Direction the answers were given in
+ALTER TABLE battle_runs
+  ADD COLUMN direction TEXT NOT NULL DEFAULT 'kana_to_romaji';
+
+ALTER TABLE practice_sessions
+  ADD COLUMN direction TEXT NOT NULL DEFAULT 'kana_to_romaji';
+
+-- +goose Down
+ALTER TABLE practice_sessions DROP COLUMN IF EXISTS direction;
+ALTER TABLE battle_runs DROP COLUMN IF EXISTS direction;
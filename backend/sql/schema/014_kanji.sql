@@ -0,0 +1,20 @@
+-- +goose Up
+-- Imported from KANJIDIC2 and KanjiVG with `haiji import kanji`
+CREATE TABLE IF NOT EXISTS kanji (
+  literal      TEXT PRIMARY KEY,
+  grade        INTEGER,                      -- 1-6 kyouiku, 8 jouyou, 9-10 jinmeiyou
+  jlpt         INTEGER,                      -- old four-level JLPT, 4 is the easiest
+  stroke_count INTEGER     NOT NULL,
+  frequency    INTEGER,                      -- newspaper frequency rank
+  meanings     TEXT[]      NOT NULL,         -- English
+  on_readings  TEXT[]      NOT NULL,         -- katakana
+  kun_readings TEXT[]      NOT NULL,         -- hiragana, "." before okurigana
+  stroke_paths TEXT[]      NOT NULL DEFAULT '{}', -- KanjiVG SVG path data, in stroke order
+  updated_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ix_kanji_jlpt_frequency
+  ON kanji (jlpt, frequency);
+
+-- +goose Down
+DROP TABLE IF EXISTS kanji;
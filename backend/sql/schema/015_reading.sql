@@ -0,0 +1,24 @@
+-- +goose Up
+-- Imported from JMdict and Tatoeba with `haiji import reading`
+CREATE TABLE IF NOT EXISTS reading_items (
+  id         BIGSERIAL   PRIMARY KEY,
+  kind       TEXT        NOT NULL,         -- word or sentence
+  source     TEXT        NOT NULL,         -- jmdict or tatoeba
+  source_id  TEXT        NOT NULL,         -- JMdict ent_seq or Tatoeba sentence id
+  text       TEXT        NOT NULL,         -- kana, with punctuation for sentences
+  written    TEXT        NOT NULL DEFAULT '', -- the usual kanji spelling of a word
+  meaning    TEXT        NOT NULL DEFAULT '', -- English
+  groups     TEXT[]      NOT NULL,         -- kana groups needed to read the text
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT reading_items_source_unique UNIQUE (source, source_id),
+  CONSTRAINT reading_items_kind_valid CHECK (kind IN ('word', 'sentence'))
+);
+
+-- finds the items readable with a set of groups
+CREATE INDEX IF NOT EXISTS ix_reading_items_groups
+  ON reading_items USING GIN (groups);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_reading_items_groups;
+DROP TABLE IF EXISTS reading_items;
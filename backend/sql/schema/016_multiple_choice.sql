@@ -0,0 +1,7 @@
+-- +goose Up
+-- Choices per multiple-choice prompt, 0 when answers were typed
+ALTER TABLE battle_runs
+  ADD COLUMN choices INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE battle_runs DROP COLUMN IF EXISTS choices;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Lessons are defined in code (internal/curriculum); rows record a user's
+-- progress through them
+CREATE TABLE IF NOT EXISTS curriculum_progress (
+  user_id     UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  lesson_id   TEXT        NOT NULL,
+  unlocked_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  mastered_at TIMESTAMPTZ,
+
+  PRIMARY KEY (user_id, lesson_id)
+);
+
+-- mastery looks at each character's latest answers
+CREATE INDEX IF NOT EXISTS ix_practice_answers_user_kana_answered
+  ON practice_answers(user_id, kana, answered_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_practice_answers_user_kana_answered;
+DROP TABLE IF EXISTS curriculum_progress;
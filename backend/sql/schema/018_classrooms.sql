@@ -0,0 +1,75 @@
+-- +goose Up
+ALTER TABLE users
+  ADD COLUMN role TEXT NOT NULL DEFAULT 'student',
+  ADD CONSTRAINT users_role_valid CHECK (role IN ('student', 'teacher'));
+
+CREATE TABLE IF NOT EXISTS classes (
+  id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  teacher_id UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name       TEXT        NOT NULL,
+  join_code  TEXT        NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT classes_join_code_unique UNIQUE (join_code)
+);
+
+CREATE INDEX IF NOT EXISTS ix_classes_teacher
+  ON classes(teacher_id);
+
+CREATE TABLE IF NOT EXISTS class_members (
+  class_id  UUID        NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+  user_id   UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  joined_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (class_id, user_id)
+);
+
+-- lists the classes a student is in
+CREATE INDEX IF NOT EXISTS ix_class_members_user
+  ON class_members(user_id);
+
+CREATE TABLE IF NOT EXISTS class_assignments (
+  id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  class_id        UUID             NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+  title           TEXT             NOT NULL,
+  groups          TEXT[]           NOT NULL,
+  kana            TEXT[]           NOT NULL, -- the groups' characters when assigned
+  target_accuracy DOUBLE PRECISION NOT NULL, -- 0 to 1
+  due_at          TIMESTAMPTZ      NOT NULL,
+  created_at      TIMESTAMPTZ      NOT NULL DEFAULT now(),
+
+  CONSTRAINT class_assignments_target_valid CHECK (target_accuracy > 0 AND target_accuracy <= 1),
+  CONSTRAINT class_assignments_due_after_created CHECK (due_at > created_at)
+);
+
+CREATE INDEX IF NOT EXISTS ix_class_assignments_class_due
+  ON class_assignments(class_id, due_at);
+
+-- Battle rooms opened for a class; runs recorded in them count as class
+-- battles in reports
+CREATE TABLE IF NOT EXISTS class_battles (
+  id         BIGSERIAL   PRIMARY KEY,
+  class_id   UUID        NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+  room_code  TEXT        NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ix_class_battles_class
+  ON class_battles(class_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_class_battles_class;
+DROP TABLE IF EXISTS class_battles;
+
+DROP INDEX IF EXISTS ix_class_assignments_class_due;
+DROP TABLE IF EXISTS class_assignments;
+
+DROP INDEX IF EXISTS ix_class_members_user;
+DROP TABLE IF EXISTS class_members;
+
+DROP INDEX IF EXISTS ix_classes_teacher;
+DROP TABLE IF EXISTS classes;
+
+ALTER TABLE users
+  DROP CONSTRAINT IF EXISTS users_role_valid,
+  DROP COLUMN IF EXISTS role;
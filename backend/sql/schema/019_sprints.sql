@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS sprint_runs (
+  id          BIGSERIAL PRIMARY KEY,
+  user_id     UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  group_set   TEXT        NOT NULL,               -- sorted group IDs, comma separated
+  duration    INTEGER     NOT NULL,               -- seconds
+  seed        BIGINT      NOT NULL,
+  correct     INTEGER     NOT NULL,
+  answered    INTEGER     NOT NULL,
+  started_at  TIMESTAMPTZ NOT NULL,
+  finished_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT sprint_runs_duration_valid CHECK (duration IN (60, 120)),
+  CONSTRAINT sprint_runs_correct_valid CHECK (correct >= 0 AND correct <= answered)
+);
+
+-- a signed challenge scores once
+CREATE UNIQUE INDEX IF NOT EXISTS ux_sprint_runs_user_seed
+  ON sprint_runs(user_id, seed);
+
+-- better performance for personal bests, history and percentiles
+CREATE INDEX IF NOT EXISTS ix_sprint_runs_group_set
+  ON sprint_runs(group_set, duration, user_id, correct DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_sprint_runs_group_set;
+DROP INDEX IF EXISTS ux_sprint_runs_user_seed;
+
+DROP TABLE IF EXISTS sprint_runs;
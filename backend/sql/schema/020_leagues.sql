@@ -0,0 +1,37 @@
+-- +goose Up
+-- Tiers are defined in code (internal/league); 0 is the lowest
+CREATE TABLE IF NOT EXISTS league_players (
+  user_id    UUID        PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  tier       INTEGER     NOT NULL DEFAULT 0 CHECK (tier >= 0),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS league_weeks (
+  week_start DATE PRIMARY KEY,                    -- Monday, UTC
+  closed_at  TIMESTAMPTZ                          -- set once promotions are applied
+);
+
+-- Points are the practice and battle XP earned during the week, so they're
+-- only stored once the week is closed
+CREATE TABLE IF NOT EXISTS league_members (
+  user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  week_start DATE        NOT NULL REFERENCES league_weeks(week_start) ON DELETE CASCADE,
+  tier       INTEGER     NOT NULL,
+  bucket     INTEGER     NOT NULL,
+  joined_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+  points     BIGINT,
+  outcome    TEXT,                                -- league.Outcome*, set when the week closes
+
+  PRIMARY KEY (user_id, week_start)
+);
+
+-- better performance for bucket standings
+CREATE INDEX IF NOT EXISTS ix_league_members_bucket
+  ON league_members(week_start, tier, bucket);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_league_members_bucket;
+
+DROP TABLE IF EXISTS league_members;
+DROP TABLE IF EXISTS league_weeks;
+DROP TABLE IF EXISTS league_players;
@@ -0,0 +1,97 @@
+-- +goose Up
+-- Double-entry ledger: every transfer moves coins between accounts and its
+-- entries sum to zero. Users can't go below zero; system accounts can, and
+-- since 026 their balance is only the sum of their coin_entries.
+CREATE TABLE IF NOT EXISTS coin_accounts (
+  id          BIGSERIAL PRIMARY KEY,
+  user_id     UUID        UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+  system_name TEXT        UNIQUE,                 -- shop.Account*
+  balance     BIGINT      NOT NULL DEFAULT 0,     -- user accounts: sum of coin_entries, kept in the same transaction
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT coin_accounts_owner CHECK ((user_id IS NULL) <> (system_name IS NULL)),
+  CONSTRAINT coin_accounts_user_balance_non_negative CHECK (user_id IS NULL OR balance >= 0)
+);
+
+INSERT INTO coin_accounts (system_name) VALUES ('rewards'), ('shop')
+ON CONFLICT (system_name) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS coin_transfers (
+  id         BIGSERIAL PRIMARY KEY,
+  kind       TEXT        NOT NULL,              -- shop.Transfer*
+  reference  TEXT        NOT NULL,              -- room code, streak day or item ID
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS coin_entries (
+  id          BIGSERIAL PRIMARY KEY,
+  transfer_id BIGINT      NOT NULL REFERENCES coin_transfers(id) ON DELETE CASCADE,
+  account_id  BIGINT      NOT NULL REFERENCES coin_accounts(id) ON DELETE CASCADE,
+  amount      BIGINT      NOT NULL CHECK (amount <> 0),
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ix_coin_entries_account
+  ON coin_entries(account_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS ix_coin_entries_transfer
+  ON coin_entries(transfer_id);
+
+-- Entries are never changed (027 stops deletes too), and a transfer's
+-- entries must balance when its transaction commits
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION coin_entries_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'coin_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION coin_transfer_balanced() RETURNS trigger AS $$
+BEGIN
+  IF (SELECT SUM(amount) FROM coin_entries WHERE transfer_id = NEW.transfer_id) <> 0 THEN
+    RAISE EXCEPTION 'coin transfer % does not balance', NEW.transfer_id;
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER coin_entries_no_update
+  BEFORE UPDATE ON coin_entries
+  FOR EACH ROW EXECUTE FUNCTION coin_entries_append_only();
+
+CREATE CONSTRAINT TRIGGER coin_entries_balanced
+  AFTER INSERT ON coin_entries
+  DEFERRABLE INITIALLY DEFERRED
+  FOR EACH ROW EXECUTE FUNCTION coin_transfer_balanced();
+
+CREATE TABLE IF NOT EXISTS user_cosmetics (
+  user_id      UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  item_id      TEXT        NOT NULL,            -- shop.Items
+  transfer_id  BIGINT      NOT NULL REFERENCES coin_transfers(id),
+  purchased_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (user_id, item_id)
+);
+
+CREATE TABLE IF NOT EXISTS equipped_cosmetics (
+  user_id UUID NOT NULL,
+  slot    TEXT NOT NULL,                        -- the item's kind
+  item_id TEXT NOT NULL,
+
+  PRIMARY KEY (user_id, slot),
+  FOREIGN KEY (user_id, item_id) REFERENCES user_cosmetics(user_id, item_id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS equipped_cosmetics;
+DROP TABLE IF EXISTS user_cosmetics;
+
+DROP TRIGGER IF EXISTS coin_entries_balanced ON coin_entries;
+DROP TRIGGER IF EXISTS coin_entries_no_update ON coin_entries;
+DROP FUNCTION IF EXISTS coin_transfer_balanced();
+DROP FUNCTION IF EXISTS coin_entries_append_only();
+
+DROP TABLE IF EXISTS coin_entries;
+DROP TABLE IF EXISTS coin_transfers;
+DROP TABLE IF EXISTS coin_accounts;
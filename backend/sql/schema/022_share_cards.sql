@@ -0,0 +1,16 @@
+-- +goose Up
+-- Final standings of finished games, kept for a while for share cards
+CREATE TABLE IF NOT EXISTS share_cards (
+  id         UUID        PRIMARY KEY,
+  room_code  TEXT        NOT NULL,
+  groups     TEXT[]      NOT NULL,
+  standings  JSONB       NOT NULL,              -- []game.Standing, best first
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS ix_share_cards_expires_at
+  ON share_cards(expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS share_cards;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Owners renew their rooms while they're alive; a room whose lease ran out
+-- belongs to a dead instance and may be claimed again.
+ALTER TABLE game_rooms
+  ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ NOT NULL DEFAULT now() + interval '30 seconds';
+
+-- +goose Down
+ALTER TABLE game_rooms
+  DROP COLUMN IF EXISTS expires_at;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- Streak achievements follow the progression streak (users.streak_longest)
+-- instead of a separate record of login days.
+DROP TABLE IF EXISTS user_login_days;
+
+INSERT INTO user_counters (user_id, name, value)
+SELECT id, 'best_streak', streak_longest
+FROM users
+WHERE streak_longest > 0
+ON CONFLICT (user_id, name) DO UPDATE
+SET value      = EXCLUDED.value,
+    updated_at = now();
+
+DELETE FROM user_counters
+WHERE name = 'best_streak'
+  AND user_id IN (SELECT id FROM users WHERE streak_longest = 0);
+
+-- +goose Down
+CREATE TABLE IF NOT EXISTS user_login_days (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  day     DATE NOT NULL,
+
+  PRIMARY KEY (user_id, day)
+);
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Presenter games and raids are recorded too, but only battles are races:
+-- the others pay no placement and can't be raced as ghosts.
+ALTER TABLE battle_runs
+  ADD COLUMN mode TEXT NOT NULL DEFAULT 'battle'
+  CHECK (mode IN ('battle', 'presenter', 'raid'));
+
+-- +goose Down
+ALTER TABLE battle_runs DROP COLUMN IF EXISTS mode;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Only user accounts keep a running balance. Updating the rewards and shop
+-- rows on every transfer made all payouts and purchases wait on the same
+-- two row locks; a system account's balance is the sum of its coin_entries.
+UPDATE coin_accounts SET balance = 0 WHERE system_name IS NOT NULL;
+
+ALTER TABLE coin_accounts
+  ADD CONSTRAINT coin_accounts_system_balance_unused CHECK (system_name IS NULL OR balance = 0);
+
+-- +goose Down
+ALTER TABLE coin_accounts DROP CONSTRAINT IF EXISTS coin_accounts_system_balance_unused;
+
+UPDATE coin_accounts a
+SET balance = COALESCE((SELECT SUM(e.amount) FROM coin_entries e WHERE e.account_id = a.id), 0)
+WHERE a.system_name IS NOT NULL;
@@ -0,0 +1,22 @@
+-- +goose Up
+-- coin_entries_no_update only stopped updates, so history could still be
+-- deleted. Entries go only with the account or transfer they belong to.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION coin_entries_no_delete() RETURNS trigger AS $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM coin_accounts WHERE id = OLD.account_id)
+     AND EXISTS (SELECT 1 FROM coin_transfers WHERE id = OLD.transfer_id) THEN
+    RAISE EXCEPTION 'coin_entries is append-only';
+  END IF;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER coin_entries_no_delete
+  BEFORE DELETE ON coin_entries
+  FOR EACH ROW EXECUTE FUNCTION coin_entries_no_delete();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS coin_entries_no_delete ON coin_entries;
+DROP FUNCTION IF EXISTS coin_entries_no_delete();
@@ -17,4 +17,5 @@ export const ENDPOINTS = {
 
     // Game
     KANA_BATTLE: "/api/kana-battle",
+    WS_TICKET: "/api/ws-ticket",
 };
@@ -50,9 +50,14 @@ export function useApi() {
         });
     }, [authenticatedRequest]);
 
+    const getWsTicket = useCallback(() => {
+        return authenticatedRequest(ENDPOINTS.WS_TICKET, { method: "POST" });
+    }, [authenticatedRequest]);
+
     return {
         getUserProfile,
         updateUserProfile,
         createBattleRoom,
+        getWsTicket,
     };
 }
@@ -1,6 +1,7 @@
 import React, { useEffect, useState, useRef, useMemo } from "react";
 import { useParams, useNavigate } from "react-router-dom";
 import { useUser } from "../context/UserContext";
+import { useApi } from "../hooks/useApi";
 import { kanaCharGroups } from "../utils/kanaData";
 import { getRandomIndex } from "../utils/mathUtils";
 import "../styles/KanaBattleLandingPage.css"; // Reuse for now
@@ -11,6 +12,7 @@ function KanaBattlePage() {
     const { roomCode } = useParams();
     const { user, loadingUser } = useUser();
     const navigate = useNavigate();
+    const { getWsTicket } = useApi();
 
     // Game State
     const [gameState, setGameState] = useState("CONNECTING"); // CONNECTING, LOBBY, PLAYING, FINISHED
@@ -44,37 +46,53 @@ function KanaBattlePage() {
 
         if (socketRef.current) return;
 
-        // Connect
-        const protocol = window.location.protocol === "https:" ? "wss:" : "ws:";
-        const wsUrl = `${protocol}//${window.location.host}/api/ws?token=${user.token}`;
+        let cancelled = false;
+        let ws = null;
 
-        const ws = new WebSocket(wsUrl);
-        socketRef.current = ws;
+        // Connect with a one-time ticket so the JWT never ends up in a URL
+        const connect = async () => {
+            const res = await getWsTicket();
+            if (cancelled) return;
+            if (!res.ok || !res.data?.ticket) {
+                setError("Couldn't connect to the game server");
+                setGameState("ERROR");
+                return;
+            }
 
-        ws.onopen = () => {
-            console.log("Connected to WS");
-            // Join Room
-            const joinMsg = {
-                type: "JOIN_ROOM",
-                code: roomCode.toUpperCase()
+            const protocol = window.location.protocol === "https:" ? "wss:" : "ws:";
+            const wsUrl = `${protocol}//${window.location.host}/api/ws?ticket=${encodeURIComponent(res.data.ticket)}`;
+
+            ws = new WebSocket(wsUrl);
+            socketRef.current = ws;
+
+            ws.onopen = () => {
+                console.log("Connected to WS");
+                // Join Room
+                const joinMsg = {
+                    type: "JOIN_ROOM",
+                    code: roomCode.toUpperCase()
+                };
+                console.log("Sending JOIN_ROOM:", joinMsg);
+                ws.send(JSON.stringify(joinMsg));
             };
-            console.log("Sending JOIN_ROOM:", joinMsg);
-            ws.send(JSON.stringify(joinMsg));
-        };
 
-        ws.onmessage = (event) => {
-            console.log("WS Message received:", event.data);
-            const msg = JSON.parse(event.data);
-            handleMessage(msg);
-        };
+            ws.onmessage = (event) => {
+                console.log("WS Message received:", event.data);
+                const msg = JSON.parse(event.data);
+                handleMessage(msg);
+            };
 
-        ws.onclose = () => {
-            console.log("Disconnected");
-            // Optionally handle reconnect or show error
+            ws.onclose = () => {
+                console.log("Disconnected");
+                // Optionally handle reconnect or show error
+            };
         };
 
+        connect();
+
         return () => {
-            if (ws.readyState === WebSocket.OPEN) {
+            cancelled = true;
+            if (ws && ws.readyState === WebSocket.OPEN) {
                 ws.close();
             }
             socketRef.current = null;
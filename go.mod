@@ -7,15 +7,18 @@ require (
 	github.com/sqlc-dev/pqtype v0.3.0
 )
 
-require github.com/gorilla/websocket v1.5.3
+require (
+	github.com/go-playground/validator/v10 v10.30.1
+	github.com/gorilla/websocket v1.5.3
+	github.com/rs/cors v1.11.1
+	golang.org/x/image v0.34.0
+)
 
 require (
 	github.com/gabriel-vasile/mimetype v1.4.12 // indirect
 	github.com/go-playground/locales v0.14.1 // indirect
 	github.com/go-playground/universal-translator v0.18.1 // indirect
-	github.com/go-playground/validator/v10 v10.30.1 // indirect
 	github.com/leodido/go-urn v1.4.0 // indirect
-	github.com/rs/cors v1.11.1 // indirect
 	golang.org/x/text v0.32.0 // indirect
 )
 